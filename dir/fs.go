@@ -54,6 +54,11 @@ func ConfigFS() SysFS {
 	return NewSysFS(userConfigDirPath())
 }
 
+// SystemConfigFS is the system level config SysFS
+func SystemConfigFS() SysFS {
+	return NewSysFS(systemConfigDirPath())
+}
+
 // PluginFS is the plugin SysFS
 func PluginFS() SysFS {
 	return NewSysFS(filepath.Join(userLibexecDirPath(), PathPlugins))
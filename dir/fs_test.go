@@ -82,3 +82,15 @@ func TestCRLFileCacheFS(t *testing.T) {
 		t.Fatalf(`SysPath() failed. got: %q, want: %q`, path, UserConfigDir)
 	}
 }
+
+func TestSystemConfigFS(t *testing.T) {
+	SystemConfigDir = "/etc/notation"
+	defer func() { SystemConfigDir = "" }()
+	path, err := SystemConfigFS().SysPath(PathOCITrustPolicy)
+	if err != nil {
+		t.Fatalf("SysPath() failed. err = %v", err)
+	}
+	if want := filepath.Join(SystemConfigDir, PathOCITrustPolicy); path != want {
+		t.Fatalf(`SysPath() failed. got: %q, want: %q`, path, want)
+	}
+}
@@ -31,20 +31,28 @@
 //   - Set custom configurations directory:
 //     dir.UserConfigDir = '/path/to/configurations/'
 //
-// Only user level directory is supported, and system level directory
-// may be added later.
+//   - Or set it with the NOTATION_CONFIG environment variable:
+//     export NOTATION_CONFIG=/path/to/configurations/
+//
+//   - Read system level trustpolicy.oci.json:
+//     file, err := dir.SystemConfigFS().Open(dir.PathOCITrustPolicy)
+//
+// Both user level and system level {NOTATION_CONFIG} directories are
+// supported. Other directories are user level only.
 package dir
 
 import (
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
 )
 
 var (
-	UserConfigDir  string // Absolute path of user level {NOTATION_CONFIG}
-	UserLibexecDir string // Absolute path of user level {NOTATION_LIBEXEC}
-	UserCacheDir   string // Absolute path of user level {NOTATION_CACHE}
+	UserConfigDir   string // Absolute path of user level {NOTATION_CONFIG}
+	UserLibexecDir  string // Absolute path of user level {NOTATION_LIBEXEC}
+	UserCacheDir    string // Absolute path of user level {NOTATION_CACHE}
+	SystemConfigDir string // Absolute path of system level {NOTATION_CONFIG}
 )
 
 const (
@@ -52,6 +60,18 @@ const (
 	notation = "notation"
 )
 
+// Environment variables overriding the default user level directories.
+// They are honored only if the corresponding directory variable, such as
+// [UserConfigDir], is not set.
+const (
+	// EnvConfigDir overrides the user level {NOTATION_CONFIG} directory.
+	EnvConfigDir = "NOTATION_CONFIG"
+	// EnvLibexecDir overrides the user level {NOTATION_LIBEXEC} directory.
+	EnvLibexecDir = "NOTATION_LIBEXEC"
+	// EnvCacheDir overrides the user level {NOTATION_CACHE} directory.
+	EnvCacheDir = "NOTATION_CACHE"
+)
+
 // The relative path to {NOTATION_CONFIG}
 const (
 	// PathConfigFile is the config.json file relative path.
@@ -87,18 +107,30 @@ const (
 const (
 	// PathCRLCache is the crl file cache directory relative path.
 	PathCRLCache = "crl"
+
+	// PathSignatureCache is the signature blob file cache directory relative
+	// path.
+	PathSignatureCache = "signatures"
 )
 
 // for unit tests
 var (
+	// userConfigDir and userCacheDir honor XDG_CONFIG_HOME and XDG_CACHE_HOME
+	// on Linux and other Unix systems.
 	userConfigDir = os.UserConfigDir
 
 	userCacheDir = os.UserCacheDir
+
+	goos = runtime.GOOS
 )
 
 // userConfigDirPath returns the user level {NOTATION_CONFIG} path.
 func userConfigDirPath() string {
 	if UserConfigDir == "" {
+		if envDir := os.Getenv(EnvConfigDir); envDir != "" {
+			UserConfigDir = envDir
+			return UserConfigDir
+		}
 		userDir, err := userConfigDir()
 		if err != nil {
 			// fallback to current directory
@@ -114,6 +146,10 @@ func userConfigDirPath() string {
 // userLibexecDirPath returns the user level {NOTATION_LIBEXEC} path.
 func userLibexecDirPath() string {
 	if UserLibexecDir == "" {
+		if envDir := os.Getenv(EnvLibexecDir); envDir != "" {
+			UserLibexecDir = envDir
+			return UserLibexecDir
+		}
 		// set user libexec
 		UserLibexecDir = userConfigDirPath()
 	}
@@ -123,6 +159,10 @@ func userLibexecDirPath() string {
 // userCacheDirPath returns the user level {NOTATION_CACHE} path.
 func userCacheDirPath() string {
 	if UserCacheDir == "" {
+		if envDir := os.Getenv(EnvCacheDir); envDir != "" {
+			UserCacheDir = envDir
+			return UserCacheDir
+		}
 		userDir, err := userCacheDir()
 		if err != nil {
 			// fallback to current directory
@@ -135,6 +175,21 @@ func userCacheDirPath() string {
 	return UserCacheDir
 }
 
+// systemConfigDirPath returns the system level {NOTATION_CONFIG} path.
+func systemConfigDirPath() string {
+	if SystemConfigDir == "" {
+		switch goos {
+		case "windows":
+			SystemConfigDir = filepath.Join(os.Getenv("ProgramData"), notation)
+		case "darwin":
+			SystemConfigDir = filepath.Join("/Library/Application Support", notation)
+		default:
+			SystemConfigDir = filepath.Join("/etc", notation)
+		}
+	}
+	return SystemConfigDir
+}
+
 // LocalKeyPath returns the local key and local cert relative paths.
 func LocalKeyPath(name string) (keyPath, certPath string) {
 	basePath := path.Join(LocalKeysDir, name)
@@ -16,6 +16,7 @@ package dir
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 )
 
@@ -44,6 +45,7 @@ func Test_NoHomeVariable(t *testing.T) {
 	t.Setenv("XDG_CACHE_HOME", "")
 	setup()
 	userConfigDir = os.UserConfigDir
+	userCacheDir = os.UserCacheDir
 	got := userConfigDirPath()
 	if got != ".notation" {
 		t.Fatalf(`userConfigDirPath() = %q, want ".notation"`, got)
@@ -55,6 +57,51 @@ func Test_NoHomeVariable(t *testing.T) {
 	}
 }
 
+func Test_EnvironmentVariableOverride(t *testing.T) {
+	t.Setenv(EnvConfigDir, "/env/config")
+	t.Setenv(EnvLibexecDir, "/env/libexec")
+	t.Setenv(EnvCacheDir, "/env/cache")
+	userConfigDir = mockUserPath
+	userCacheDir = mockUserPath
+	setup()
+	defer setup()
+	if got := userConfigDirPath(); got != "/env/config" {
+		t.Fatalf(`userConfigDirPath() = %q, want "/env/config"`, got)
+	}
+	if got := userLibexecDirPath(); got != "/env/libexec" {
+		t.Fatalf(`userLibexecDirPath() = %q, want "/env/libexec"`, got)
+	}
+	if got := userCacheDirPath(); got != "/env/cache" {
+		t.Fatalf(`userCacheDirPath() = %q, want "/env/cache"`, got)
+	}
+
+	// explicitly set directories take precedence
+	UserConfigDir = "/custom/config"
+	if got := userConfigDirPath(); got != "/custom/config" {
+		t.Fatalf(`userConfigDirPath() = %q, want "/custom/config"`, got)
+	}
+}
+
+func Test_XDGBaseDirectories(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("XDG Base Directory Specification is only honored on Unix systems")
+	}
+	xdgConfig := filepath.Join(string(filepath.Separator)+"xdg", "config")
+	xdgCache := filepath.Join(string(filepath.Separator)+"xdg", "cache")
+	t.Setenv("XDG_CONFIG_HOME", xdgConfig)
+	t.Setenv("XDG_CACHE_HOME", xdgCache)
+	userConfigDir = os.UserConfigDir
+	userCacheDir = os.UserCacheDir
+	setup()
+	defer setup()
+	if got, want := userConfigDirPath(), filepath.Join(xdgConfig, notation); got != want {
+		t.Fatalf(`userConfigDirPath() = %q, want %q`, got, want)
+	}
+	if got, want := userCacheDirPath(), filepath.Join(xdgCache, notation); got != want {
+		t.Fatalf(`userCacheDirPath() = %q, want %q`, got, want)
+	}
+}
+
 func Test_UserLibexecDirPath(t *testing.T) {
 	userConfigDir = mockUserPath
 	setup()
@@ -96,3 +143,28 @@ func TestX509TrustStoreDir(t *testing.T) {
 		t.Fatalf(`X509TrustStoreDir() = %q, want "truststore/x509/ca/web"`, got)
 	}
 }
+
+func Test_SystemConfigDirPath(t *testing.T) {
+	defer func() {
+		goos = runtime.GOOS
+		SystemConfigDir = ""
+	}()
+	t.Setenv("ProgramData", "C:\\ProgramData")
+	tests := []struct {
+		goos string
+		want string
+	}{
+		{"linux", filepath.Join("/etc", "notation")},
+		{"darwin", filepath.Join("/Library/Application Support", "notation")},
+		{"windows", filepath.Join("C:\\ProgramData", "notation")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			goos = tt.goos
+			SystemConfigDir = ""
+			if got := systemConfigDirPath(); got != tt.want {
+				t.Fatalf(`systemConfigDirPath() = %q, want %q`, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dir
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWriteJSON(t *testing.T) {
+	root := t.TempDir()
+	fsys := NewSysFS(root)
+	want := map[string]string{"default": "key"}
+	if err := WriteJSON(fsys, PathSigningKeys, want); err != nil {
+		t.Fatalf("WriteJSON() failed. err = %v", err)
+	}
+	// overwrite existing file
+	want = map[string]string{"default": "new-key"}
+	if err := WriteJSON(fsys, PathSigningKeys, want); err != nil {
+		t.Fatalf("WriteJSON() failed. err = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(root, PathSigningKeys))
+	if err != nil {
+		t.Fatalf("failed to read file. err = %v", err)
+	}
+	var got map[string]string
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatalf("failed to unmarshal file. err = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("WriteJSON() got %v, want %v", got, want)
+	}
+
+	// no temporary file left behind
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatalf("failed to read dir. err = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 file in %s, got %d", root, len(entries))
+	}
+}
+
+func TestWriteJSONCreatesParentDir(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "notation")
+	if err := WriteJSON(NewSysFS(root), PathConfigFile, struct{}{}); err != nil {
+		t.Fatalf("WriteJSON() failed. err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, PathConfigFile)); err != nil {
+		t.Fatalf("expected file to exist. err = %v", err)
+	}
+}
+
+func TestWriteJSONMarshalError(t *testing.T) {
+	if err := WriteJSON(NewSysFS(t.TempDir()), PathConfigFile, make(chan int)); err == nil {
+		t.Fatal("WriteJSON() expected error for unsupported type")
+	}
+}
@@ -13,6 +13,11 @@
 
 package notation
 
+import (
+	"fmt"
+	"time"
+)
+
 // ErrorPushSignatureFailed is used when failed to push signature to the
 // target registry.
 type ErrorPushSignatureFailed struct {
@@ -90,3 +95,161 @@ func (e ErrorUserMetadataVerificationFailed) Error() string {
 	}
 	return "unable to find specified metadata in the signature"
 }
+
+// ErrorEnvelopeContentNotFound is used when the signature envelope content is
+// not available in a verification outcome
+type ErrorEnvelopeContentNotFound struct {
+	Msg string
+}
+
+func (e ErrorEnvelopeContentNotFound) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	return "unable to find envelope content for verification outcome"
+}
+
+// ErrorTargetArtifactMismatch is used when the target artifact in the
+// signature payload does not match the artifact being verified
+type ErrorTargetArtifactMismatch struct {
+	Msg string
+}
+
+func (e ErrorTargetArtifactMismatch) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	return "content descriptor mismatch"
+}
+
+// ErrorCertificateExpired is used when a certificate of the signing
+// certificate chain has expired at the time of verification and the
+// expiration is not covered by a trusted timestamp. It is distinct from a
+// revoked certificate, so that callers can e.g. ask for the artifact to be
+// re-signed rather than treating the signing key as compromised.
+type ErrorCertificateExpired struct {
+	Msg string
+
+	// Subject is the subject of the expired certificate.
+	Subject string
+
+	// NotAfter is the time when the certificate expired.
+	NotAfter time.Time
+
+	// Timestamped is true if the signature has a timestamp countersignature,
+	// but the timestamp does not prove that the signature was produced
+	// before the certificate expired.
+	Timestamped bool
+}
+
+func (e ErrorCertificateExpired) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	return fmt.Sprintf("certificate %q expired at %q", e.Subject, e.NotAfter.Format(time.RFC1123Z))
+}
+
+// ErrorInvalidSignatureMediaType is used when the media type of a signature
+// envelope declared in the registry is inconsistent with the format the
+// envelope is actually encoded in, e.g. due to a misconfigured signer or a
+// registry altering media types.
+type ErrorInvalidSignatureMediaType struct {
+	Msg string
+
+	// MediaType is the media type of the signature envelope declared in the
+	// registry.
+	MediaType string
+
+	// DetectedMediaType is the media type of the format the signature
+	// envelope is encoded in.
+	DetectedMediaType string
+}
+
+func (e ErrorInvalidSignatureMediaType) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	return fmt.Sprintf("signature media type %q is inconsistent with the signature envelope, which is encoded as %q", e.MediaType, e.DetectedMediaType)
+}
+
+// ErrorSignatureExpired is used when the signature has expired by its own
+// terms, i.e. the expiry set in the signed attributes of the signature
+// envelope at signing time has passed at the time of verification. It is
+// distinct from [ErrorCertificateExpired], so that short-lived signatures can
+// be told apart from expired signing certificates.
+type ErrorSignatureExpired struct {
+	Msg string
+
+	// Expiry is the expiry of the signature.
+	Expiry time.Time
+}
+
+func (e ErrorSignatureExpired) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	return fmt.Sprintf("digital signature has expired on %q", e.Expiry.Format(time.RFC1123Z))
+}
+
+// ErrorSignatureTooOld is used when a signature is valid but was signed
+// earlier than allowed by [VerifyOptions.MaxSignatureAge].
+type ErrorSignatureTooOld struct {
+	Msg string
+
+	// SigningTime is the signing time of the signature.
+	SigningTime time.Time
+
+	// MaxAge is the maximum age of the signature.
+	MaxAge time.Duration
+}
+
+func (e ErrorSignatureTooOld) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	return fmt.Sprintf("signature signed at %q is older than the maximum signature age of %v", e.SigningTime.Format(time.RFC1123Z), e.MaxAge)
+}
+
+// ErrorSignatureTooLarge is used when a signer produces a signature envelope
+// larger than the maximum size allowed, e.g. because a plugin embeds an
+// abnormally large certificate chain.
+type ErrorSignatureTooLarge struct {
+	Msg string
+
+	// Size is the size in bytes of the signature envelope.
+	Size int
+
+	// MaxSize is the maximum size in bytes of the signature envelope.
+	MaxSize int
+}
+
+func (e ErrorSignatureTooLarge) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	return fmt.Sprintf("signature envelope of %d bytes exceeds the maximum size of %d bytes", e.Size, e.MaxSize)
+}
+
+// ErrorSigningTimeInFuture is used when the signing time of a signature is
+// ahead of the time of verification by more than the maximum clock skew
+// allowed by the verifier, which hints at clock skew or tampering.
+type ErrorSigningTimeInFuture struct {
+	Msg string
+
+	// SigningTime is the signing time of the signature.
+	SigningTime time.Time
+
+	// Skew is the observed duration by which SigningTime is ahead of the
+	// time of verification.
+	Skew time.Duration
+
+	// MaxClockSkew is the maximum clock skew allowed by the verifier.
+	MaxClockSkew time.Duration
+}
+
+func (e ErrorSigningTimeInFuture) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	return fmt.Sprintf("signing time %q is %v in the future, exceeding the maximum clock skew of %v", e.SigningTime.Format(time.RFC1123Z), e.Skew, e.MaxClockSkew)
+}
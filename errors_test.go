@@ -13,7 +13,10 @@
 
 package notation
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestErrorMessages(t *testing.T) {
 	tests := []struct {
@@ -81,6 +84,86 @@ func TestErrorMessages(t *testing.T) {
 			err:  ErrorUserMetadataVerificationFailed{},
 			want: "unable to find specified metadata in the signature",
 		},
+		{
+			name: "ErrorEnvelopeContentNotFound with message",
+			err:  ErrorEnvelopeContentNotFound{Msg: "test message"},
+			want: "test message",
+		},
+		{
+			name: "ErrorEnvelopeContentNotFound without message",
+			err:  ErrorEnvelopeContentNotFound{},
+			want: "unable to find envelope content for verification outcome",
+		},
+		{
+			name: "ErrorTargetArtifactMismatch with message",
+			err:  ErrorTargetArtifactMismatch{Msg: "test message"},
+			want: "test message",
+		},
+		{
+			name: "ErrorTargetArtifactMismatch without message",
+			err:  ErrorTargetArtifactMismatch{},
+			want: "content descriptor mismatch",
+		},
+		{
+			name: "ErrorCertificateExpired with message",
+			err:  ErrorCertificateExpired{Msg: "test message"},
+			want: "test message",
+		},
+		{
+			name: "ErrorCertificateExpired without message",
+			err:  ErrorCertificateExpired{Subject: "CN=Test", NotAfter: time.Date(2024, 6, 18, 7, 30, 31, 0, time.UTC)},
+			want: "certificate \"CN=Test\" expired at \"Tue, 18 Jun 2024 07:30:31 +0000\"",
+		},
+		{
+			name: "ErrorSignatureExpired with message",
+			err:  ErrorSignatureExpired{Msg: "test message"},
+			want: "test message",
+		},
+		{
+			name: "ErrorSignatureExpired without message",
+			err:  ErrorSignatureExpired{Expiry: time.Date(2024, 6, 18, 7, 30, 31, 0, time.UTC)},
+			want: "digital signature has expired on \"Tue, 18 Jun 2024 07:30:31 +0000\"",
+		},
+		{
+			name: "ErrorSignatureTooLarge with message",
+			err:  ErrorSignatureTooLarge{Msg: "test message"},
+			want: "test message",
+		},
+		{
+			name: "ErrorSignatureTooLarge without message",
+			err:  ErrorSignatureTooLarge{Size: 2048, MaxSize: 1024},
+			want: "signature envelope of 2048 bytes exceeds the maximum size of 1024 bytes",
+		},
+		{
+			name: "ErrorSignatureTooOld with message",
+			err:  ErrorSignatureTooOld{Msg: "test message"},
+			want: "test message",
+		},
+		{
+			name: "ErrorSignatureTooOld without message",
+			err:  ErrorSignatureTooOld{SigningTime: time.Date(2024, 6, 18, 7, 30, 31, 0, time.UTC), MaxAge: 90 * 24 * time.Hour},
+			want: "signature signed at \"Tue, 18 Jun 2024 07:30:31 +0000\" is older than the maximum signature age of 2160h0m0s",
+		},
+		{
+			name: "ErrorInvalidSignatureMediaType with message",
+			err:  ErrorInvalidSignatureMediaType{Msg: "test message"},
+			want: "test message",
+		},
+		{
+			name: "ErrorInvalidSignatureMediaType without message",
+			err:  ErrorInvalidSignatureMediaType{MediaType: "application/cose", DetectedMediaType: "application/jose+json"},
+			want: "signature media type \"application/cose\" is inconsistent with the signature envelope, which is encoded as \"application/jose+json\"",
+		},
+		{
+			name: "ErrorSigningTimeInFuture with message",
+			err:  ErrorSigningTimeInFuture{Msg: "test message"},
+			want: "test message",
+		},
+		{
+			name: "ErrorSigningTimeInFuture without message",
+			err:  ErrorSigningTimeInFuture{SigningTime: time.Date(2024, 6, 18, 7, 30, 31, 0, time.UTC), Skew: time.Hour, MaxClockSkew: 5 * time.Minute},
+			want: "signing time \"Tue, 18 Jun 2024 07:30:31 +0000\" is 1h0m0s in the future, exceeding the maximum clock skew of 5m0s",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,42 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestNow(t *testing.T) {
+	t.Run("nil clock", func(t *testing.T) {
+		before := time.Now()
+		now := Now(nil)
+		if now.Before(before) || now.After(time.Now()) {
+			t.Fatalf("expected system time, got %v", now)
+		}
+	})
+
+	t.Run("fixed clock", func(t *testing.T) {
+		want := time.Date(2024, 6, 18, 7, 30, 31, 0, time.UTC)
+		if got := Now(fixedClock(want)); !got.Equal(want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	})
+}
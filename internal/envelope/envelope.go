@@ -14,11 +14,22 @@
 package envelope
 
 import (
+	"bytes"
+	"crypto"
+	_ "crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-core-go/signature/cose"
+	"github.com/notaryproject/notation-core-go/signature/jws"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
@@ -28,12 +39,57 @@ const (
 	AnnotationX509ChainThumbprint = "io.cncf.notary.x509chain.thumbprint#S256"
 )
 
+// x509ChainThumbprintHashes maps the x509 chain thumbprint annotation keys
+// defined by the Notary Project signature specification to their hash
+// functions. The `io.cncf.notary` annotation prefix is reserved by the
+// specification, so no other key is emitted or recognized.
+// reference: https://github.com/notaryproject/specifications/blob/v1.0.0/specs/signature-specification.md#storage
+var x509ChainThumbprintHashes = map[string]crypto.Hash{
+	AnnotationX509ChainThumbprint: crypto.SHA256,
+}
+
+// payloadContentTypePrefix and payloadContentTypeSuffix enclose the schema
+// version of the signature payload content type, e.g.
+// `application/vnd.cncf.notary.payload.v1+json`.
+const (
+	payloadContentTypePrefix = "application/vnd.cncf.notary.payload.v"
+	payloadContentTypeSuffix = "+json"
+)
+
 // Payload describes the content that gets signed.
+//
+// Payloads of a newer schema version are unmarshaled on the fields known to
+// this version, and their unknown fields are ignored.
 type Payload struct {
 	TargetArtifact ocispec.Descriptor `json:"targetArtifact"`
 }
 
+// cborTagCOSESign1 is the leading byte of a tagged COSE_Sign1 message, i.e.
+// the CBOR encoding of tag 18.
+const cborTagCOSESign1 = 0xd2
+
+// cborArrayCOSESign1 is the byte following cborTagCOSESign1 in a COSE_Sign1
+// message, i.e. the CBOR encoding of the header of an array of 4 items.
+const cborArrayCOSESign1 = 0x84
+
+// DetectMediaType returns the media type of the signature envelope format
+// that envelope is encoded in, judging by its leading bytes. It returns an
+// empty string if the format is not recognized.
+func DetectMediaType(envelope []byte) string {
+	trimmed := bytes.TrimLeft(envelope, " \t\r\n")
+	switch {
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		return jws.MediaTypeEnvelope
+	case len(envelope) > 1 && envelope[0] == cborTagCOSESign1 && envelope[1] == cborArrayCOSESign1:
+		return cose.MediaTypeEnvelope
+	default:
+		return ""
+	}
+}
+
 // ValidatePayloadContentType validates signature payload's content type.
+// Only [MediaTypePayloadV1] is accepted, so that signers do not produce
+// signatures of a payload schema unknown to this library.
 func ValidatePayloadContentType(payload *signature.Payload) error {
 	switch payload.ContentType {
 	case MediaTypePayloadV1:
@@ -43,6 +99,25 @@ func ValidatePayloadContentType(payload *signature.Payload) error {
 	}
 }
 
+// PayloadVersion returns the schema version of the signature payload of
+// content type contentType, e.g. 1 for [MediaTypePayloadV1]. Unlike
+// ValidatePayloadContentType, newer schema versions are accepted, so that
+// verifiers can verify their known fields.
+func PayloadVersion(contentType string) (int, error) {
+	version, ok := strings.CutPrefix(contentType, payloadContentTypePrefix)
+	if ok {
+		version, ok = strings.CutSuffix(version, payloadContentTypeSuffix)
+	}
+	if !ok || version == "" || version[0] == '0' || strings.Trim(version, "0123456789") != "" {
+		return 0, fmt.Errorf("payload content type %q not supported", contentType)
+	}
+	v, err := strconv.Atoi(version)
+	if err != nil {
+		return 0, fmt.Errorf("payload content type %q not supported", contentType)
+	}
+	return v, nil
+}
+
 // SanitizeTargetArtifact filters out unrelated ocispec.Descriptor fields based
 // on notation spec (https://github.com/notaryproject/notaryproject/blob/main/specs/signature-specification.md#payload).
 func SanitizeTargetArtifact(targetArtifact ocispec.Descriptor) ocispec.Descriptor {
@@ -66,3 +141,49 @@ func SigningTime(signerInfo *signature.SignerInfo) (time.Time, error) {
 	}
 	return signingTime.UTC(), nil
 }
+
+// X509ChainThumbprint returns the value of the
+// [AnnotationX509ChainThumbprint] annotation of certChain, i.e. the SHA-256
+// thumbprints of the certificates required by the specification regardless
+// of the signature algorithm.
+func X509ChainThumbprint(certChain []*x509.Certificate) (string, error) {
+	val, err := json.Marshal(x509ChainThumbprints(crypto.SHA256, certChain))
+	if err != nil {
+		return "", err
+	}
+	return string(val), nil
+}
+
+// VerifyX509ChainThumbprints verifies every supported x509 chain thumbprint
+// annotation in annotations against certChain. It returns false if annotations
+// contains no supported x509 chain thumbprint annotation.
+func VerifyX509ChainThumbprints(annotations map[string]string, certChain []*x509.Certificate) (bool, error) {
+	var found bool
+	for key, hash := range x509ChainThumbprintHashes {
+		val, ok := annotations[key]
+		if !ok {
+			continue
+		}
+		found = true
+		var thumbprints []string
+		if err := json.Unmarshal([]byte(val), &thumbprints); err != nil {
+			return true, fmt.Errorf("malformed annotation %q: %w", key, err)
+		}
+		if !slices.Equal(thumbprints, x509ChainThumbprints(hash, certChain)) {
+			return true, fmt.Errorf("annotation %q does not match the certificate chain of the signature", key)
+		}
+	}
+	return found, nil
+}
+
+// x509ChainThumbprints returns the hex encoded thumbprints of certChain
+// computed with hash.
+func x509ChainThumbprints(hash crypto.Hash, certChain []*x509.Certificate) []string {
+	var thumbprints []string
+	for _, cert := range certChain {
+		h := hash.New()
+		h.Write(cert.Raw)
+		thumbprints = append(thumbprints, hex.EncodeToString(h.Sum(nil)))
+	}
+	return thumbprints
+}
@@ -14,6 +14,9 @@
 package envelope
 
 import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"testing"
 	"time"
@@ -80,6 +83,48 @@ func TestValidateEnvelopeMediaType(t *testing.T) {
 	}
 }
 
+func TestDetectMediaType(t *testing.T) {
+	tests := []struct {
+		name      string
+		envelope  []byte
+		mediaType string
+	}{
+		{
+			name:      "jws envelope",
+			envelope:  []byte(`{"payload":"","protected":"","signature":""}`),
+			mediaType: jws.MediaTypeEnvelope,
+		},
+		{
+			name:      "jws envelope with leading whitespace",
+			envelope:  []byte("\n  {}"),
+			mediaType: jws.MediaTypeEnvelope,
+		},
+		{
+			name:      "cose envelope",
+			envelope:  []byte{0xd2, 0x84},
+			mediaType: cose.MediaTypeEnvelope,
+		},
+		{
+			name:     "cbor tag without cose sign1 structure",
+			envelope: []byte{0xd2, 0x01},
+		},
+		{
+			name:     "unrecognized envelope",
+			envelope: []byte("invalid"),
+		},
+		{
+			name: "empty envelope",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectMediaType(tt.envelope); got != tt.mediaType {
+				t.Fatalf("expected media type %q, got %q", tt.mediaType, got)
+			}
+		})
+	}
+}
+
 func TestValidatePayloadContentType(t *testing.T) {
 	payload := &signature.Payload{
 		ContentType: MediaTypePayloadV1,
@@ -89,16 +134,54 @@ func TestValidatePayloadContentType(t *testing.T) {
 		t.Fatalf("ValidatePayloadContentType() expects error: %v, but got: %v.", nil, err)
 	}
 
+	payload = &signature.Payload{
+		ContentType: "application/vnd.cncf.notary.payload.v2+json",
+	}
+	err = ValidatePayloadContentType(payload)
+	expect := errors.New("payload content type \"application/vnd.cncf.notary.payload.v2+json\" not supported")
+	if !isErrEqual(expect, err) {
+		t.Fatalf("ValidatePayloadContentType() expects error: %v, but got: %v.", expect, err)
+	}
+
 	payload = &signature.Payload{
 		ContentType: "invalid",
 	}
 	err = ValidatePayloadContentType(payload)
-	expect := errors.New("payload content type \"invalid\" not supported")
+	expect = errors.New("payload content type \"invalid\" not supported")
 	if !isErrEqual(expect, err) {
 		t.Fatalf("ValidatePayloadContentType() expects error: %v, but got: %v.", expect, err)
 	}
 }
 
+func TestPayloadVersion(t *testing.T) {
+	tests := []struct {
+		contentType string
+		version     int
+		wantErr     bool
+	}{
+		{contentType: MediaTypePayloadV1, version: 1},
+		{contentType: "application/vnd.cncf.notary.payload.v2+json", version: 2},
+		{contentType: "application/vnd.cncf.notary.payload.v10+json", version: 10},
+		{contentType: "application/vnd.cncf.notary.payload.v0+json", wantErr: true},
+		{contentType: "application/vnd.cncf.notary.payload.v01+json", wantErr: true},
+		{contentType: "application/vnd.cncf.notary.payload.v+json", wantErr: true},
+		{contentType: "application/vnd.cncf.notary.payload.v-1+json", wantErr: true},
+		{contentType: "application/vnd.cncf.notary.payload.v1", wantErr: true},
+		{contentType: "invalid", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			version, err := PayloadVersion(tt.contentType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PayloadVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if version != tt.version {
+				t.Fatalf("PayloadVersion() = %d, want %d", version, tt.version)
+			}
+		})
+	}
+}
+
 func TestSigningTime(t *testing.T) {
 	testTime, err := time.Parse(time.RFC3339, "2023-03-14T04:45:22Z")
 	if err != nil {
@@ -119,6 +202,41 @@ func TestSigningTime(t *testing.T) {
 	}
 }
 
+func TestX509ChainThumbprint(t *testing.T) {
+	certChain := []*x509.Certificate{{Raw: []byte("leaf")}, {Raw: []byte("root")}}
+	sum256 := func(b []byte) string { s := sha256.Sum256(b); return hex.EncodeToString(s[:]) }
+
+	val, err := X509ChainThumbprint(certChain)
+	if err != nil {
+		t.Fatalf("X509ChainThumbprint() failed: %v", err)
+	}
+	want := `["` + sum256([]byte("leaf")) + `","` + sum256([]byte("root")) + `"]`
+	if val != want {
+		t.Fatalf("X509ChainThumbprint() = %q, want %q", val, want)
+	}
+
+	found, err := VerifyX509ChainThumbprints(map[string]string{AnnotationX509ChainThumbprint: val}, certChain)
+	if !found || err != nil {
+		t.Fatalf("VerifyX509ChainThumbprints() = %v, %v, want true, nil", found, err)
+	}
+	if _, err := VerifyX509ChainThumbprints(map[string]string{AnnotationX509ChainThumbprint: val}, certChain[:1]); err == nil {
+		t.Fatal("VerifyX509ChainThumbprints() expected error for mismatched certificate chain")
+	}
+
+	t.Run("no thumbprint annotation", func(t *testing.T) {
+		found, err := VerifyX509ChainThumbprints(map[string]string{"io.cncf.notary.x509chain.thumbprint#S384": "invalid"}, certChain)
+		if found || err != nil {
+			t.Fatalf("VerifyX509ChainThumbprints() = %v, %v, want false, nil", found, err)
+		}
+	})
+
+	t.Run("malformed thumbprint annotation", func(t *testing.T) {
+		if _, err := VerifyX509ChainThumbprints(map[string]string{AnnotationX509ChainThumbprint: "invalid"}, certChain); err == nil {
+			t.Fatal("VerifyX509ChainThumbprints() expected error for malformed annotation")
+		}
+	})
+}
+
 func isErrEqual(wanted, got error) bool {
 	if wanted == nil && got == nil {
 		return true
@@ -143,6 +143,12 @@ func WriteFile(tempDir, path string, content []byte) (writeErr error) {
 		return fmt.Errorf("failed to write content to temp file: %w", err)
 	}
 
+	// flush to disk so that a crash after rename never exposes a partially
+	// written file
+	if err := tempFile.Sync(); err != nil {
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+
 	// close before moving
 	if err := tempFile.Close(); err != nil {
 		return fmt.Errorf("failed to close temp file: %w", err)
@@ -0,0 +1,91 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkcs7
+
+import (
+	"encoding/asn1"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseCertificates(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want []string
+	}{
+		{
+			name: "bundle",
+			file: "bundle.p7b",
+			want: []string{"Notation Test Encrypted Key", "Notation Test Encrypted EC Key"},
+		},
+		{
+			name: "no certificates",
+			file: "empty.p7b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			certs, err := ParseCertificates(data)
+			if err != nil {
+				t.Fatalf("ParseCertificates() error = %v", err)
+			}
+			if len(certs) != len(tt.want) {
+				t.Fatalf("ParseCertificates() returned %d certificates, want %d", len(certs), len(tt.want))
+			}
+			for i, cert := range certs {
+				if cert.Subject.CommonName != tt.want[i] {
+					t.Fatalf("certificate %d has common name %q, want %q", i, cert.Subject.CommonName, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseCertificatesError(t *testing.T) {
+	t.Run("malformed", func(t *testing.T) {
+		if _, err := ParseCertificates([]byte("malformed")); err == nil {
+			t.Fatal("ParseCertificates() expects error, got nil")
+		}
+	})
+
+	t.Run("trailing data", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join("testdata", "bundle.p7b"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ParseCertificates(append(data, 0))
+		if err == nil || err.Error() != "failed to parse PKCS #7 content info: trailing data" {
+			t.Fatalf("ParseCertificates() expects trailing data error, got %v", err)
+		}
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		data, err := asn1.Marshal(contentInfo{
+			ContentType: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ParseCertificates(data)
+		if err == nil || err.Error() != "unsupported PKCS #7 content type 1.2.840.113549.1.7.1" {
+			t.Fatalf("ParseCertificates() expects unsupported content type error, got %v", err)
+		}
+	})
+}
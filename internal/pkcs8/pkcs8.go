@@ -0,0 +1,184 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pkcs8 decrypts PKCS #8 encrypted private keys as produced by
+// OpenSSL.
+package pkcs8
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// maxIterationCount is the maximum PBKDF2 iteration count accepted by Decrypt,
+// preventing crafted private keys from stalling the key derivation. It is far
+// above the counts recommended for password hashing.
+const maxIterationCount = 10000000
+
+// ErrIncorrectPassphrase is returned when the private key cannot be
+// decrypted with the given passphrase.
+var ErrIncorrectPassphrase = errors.New("incorrect passphrase")
+
+// encryptedPrivateKeyInfo is defined in RFC 5208 section 6.
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm algorithmIdentifier
+	EncryptedData       []byte
+}
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+// pbes2Params is defined in RFC 8018 appendix A.4.
+type pbes2Params struct {
+	KeyDerivationFunc algorithmIdentifier
+	EncryptionScheme  algorithmIdentifier
+}
+
+// pbkdf2Params is defined in RFC 8018 appendix A.2.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                 `asn1:"optional"`
+	PRF            algorithmIdentifier `asn1:"optional"`
+}
+
+// Decrypt decrypts the DER encoded PKCS #8 EncryptedPrivateKeyInfo der with
+// passphrase, and returns the DER encoded PKCS #8 PrivateKeyInfo.
+//
+// Only the PBES2 encryption scheme with PBKDF2 and AES-CBC is supported,
+// which is the default of OpenSSL.
+func Decrypt(der, passphrase []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil || len(rest) != 0 {
+		return nil, errors.New("malformed encrypted private key")
+	}
+	if !info.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("unsupported encryption algorithm %s, only PBES2 is supported", info.EncryptionAlgorithm.Algorithm)
+	}
+	var params pbes2Params
+	if rest, err := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil || len(rest) != 0 {
+		return nil, errors.New("malformed PBES2 parameters")
+	}
+
+	// encryption scheme
+	var keyLen int
+	switch alg := params.EncryptionScheme.Algorithm; {
+	case alg.Equal(oidAES128CBC):
+		keyLen = 16
+	case alg.Equal(oidAES192CBC):
+		keyLen = 24
+	case alg.Equal(oidAES256CBC):
+		keyLen = 32
+	default:
+		return nil, fmt.Errorf("unsupported encryption scheme %s", alg)
+	}
+	var iv []byte
+	if rest, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil || len(rest) != 0 || len(iv) != aes.BlockSize {
+		return nil, errors.New("malformed AES-CBC initialization vector")
+	}
+
+	// key derivation function
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("unsupported key derivation function %s, only PBKDF2 is supported", params.KeyDerivationFunc.Algorithm)
+	}
+	var kdfParams pbkdf2Params
+	if rest, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams); err != nil || len(rest) != 0 {
+		return nil, errors.New("malformed PBKDF2 parameters")
+	}
+	if kdfParams.IterationCount <= 0 {
+		return nil, errors.New("malformed PBKDF2 parameters: iteration count must be positive")
+	}
+	if kdfParams.IterationCount > maxIterationCount {
+		return nil, fmt.Errorf("PBKDF2 iteration count %d exceeds the maximum %d", kdfParams.IterationCount, maxIterationCount)
+	}
+	if kdfParams.KeyLength != 0 && kdfParams.KeyLength != keyLen {
+		return nil, fmt.Errorf("PBKDF2 key length %d does not match the encryption scheme key length %d", kdfParams.KeyLength, keyLen)
+	}
+	prf, err := prfHash(kdfParams.PRF.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	key := pbkdf2.Key(passphrase, kdfParams.Salt, kdfParams.IterationCount, keyLen, prf)
+
+	// decrypt
+	if len(info.EncryptedData) == 0 || len(info.EncryptedData)%aes.BlockSize != 0 {
+		return nil, errors.New("malformed encrypted private key data")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, info.EncryptedData)
+	return unpad(plaintext)
+}
+
+// prfHash returns the hash function of the PBKDF2 pseudorandom function.
+func prfHash(alg asn1.ObjectIdentifier) (func() hash.Hash, error) {
+	switch {
+	case len(alg) == 0, alg.Equal(oidHMACWithSHA1):
+		// hmacWithSHA1 is the default
+		return sha1.New, nil
+	case alg.Equal(oidHMACWithSHA256):
+		return sha256.New, nil
+	case alg.Equal(oidHMACWithSHA384):
+		return sha512.New384, nil
+	case alg.Equal(oidHMACWithSHA512):
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unsupported PBKDF2 pseudorandom function %s", alg)
+	}
+}
+
+// unpad removes the PKCS #7 padding of data. A wrong passphrase is detected
+// by an invalid padding.
+func unpad(data []byte) ([]byte, error) {
+	n := int(data[len(data)-1])
+	if n == 0 || n > aes.BlockSize || n > len(data) {
+		return nil, ErrIncorrectPassphrase
+	}
+	padding := make([]byte, n)
+	for i := range padding {
+		padding[i] = byte(n)
+	}
+	if subtle.ConstantTimeCompare(data[len(data)-n:], padding) != 1 {
+		return nil, ErrIncorrectPassphrase
+	}
+	return data[:len(data)-n], nil
+}
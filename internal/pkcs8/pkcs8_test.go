@@ -0,0 +1,130 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkcs8
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readPEM(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("%s is not PEM encoded", name)
+	}
+	return block.Bytes
+}
+
+func TestDecrypt(t *testing.T) {
+	tests := []struct {
+		name      string
+		encrypted string
+		plain     string
+	}{
+		{
+			name:      "AES-256-CBC with hmacWithSHA256",
+			encrypted: "rsa_encrypted.key",
+			plain:     "rsa.key",
+		},
+		{
+			name:      "AES-128-CBC with hmacWithSHA512",
+			encrypted: "ec_encrypted.key",
+			plain:     "ec.key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decrypt(readPEM(t, tt.encrypted), []byte("notation"))
+			if err != nil {
+				t.Fatalf("Decrypt() error = %v", err)
+			}
+			if want := readPEM(t, tt.plain); !bytes.Equal(got, want) {
+				t.Fatal("Decrypt() does not return the original private key")
+			}
+		})
+	}
+}
+
+func TestDecryptError(t *testing.T) {
+	t.Run("incorrect passphrase", func(t *testing.T) {
+		_, err := Decrypt(readPEM(t, "rsa_encrypted.key"), []byte("wrong passphrase"))
+		if !errors.Is(err, ErrIncorrectPassphrase) {
+			t.Fatalf("Decrypt() expects error %v, got %v", ErrIncorrectPassphrase, err)
+		}
+	})
+
+	t.Run("not encrypted", func(t *testing.T) {
+		_, err := Decrypt(readPEM(t, "rsa.key"), []byte("notation"))
+		if err == nil {
+			t.Fatal("Decrypt() expects error, got nil")
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := Decrypt([]byte("malformed"), []byte("notation"))
+		if err == nil || err.Error() != "malformed encrypted private key" {
+			t.Fatalf("Decrypt() expects error %q, got %v", "malformed encrypted private key", err)
+		}
+	})
+
+	t.Run("iteration count too large", func(t *testing.T) {
+		der := withIterationCount(t, readPEM(t, "rsa_encrypted.key"), maxIterationCount+1)
+		_, err := Decrypt(der, []byte("notation"))
+		want := "PBKDF2 iteration count 10000001 exceeds the maximum 10000000"
+		if err == nil || err.Error() != want {
+			t.Fatalf("Decrypt() expects error %q, got %v", want, err)
+		}
+	})
+}
+
+// withIterationCount returns the encrypted private key der with its PBKDF2
+// iteration count replaced by count.
+func withIterationCount(t *testing.T, der []byte, count int) []byte {
+	t.Helper()
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		t.Fatal(err)
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		t.Fatal(err)
+	}
+	var kdfParams pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams); err != nil {
+		t.Fatal(err)
+	}
+	kdfParams.IterationCount = count
+
+	var err error
+	if params.KeyDerivationFunc.Parameters.FullBytes, err = asn1.Marshal(kdfParams); err != nil {
+		t.Fatal(err)
+	}
+	if info.EncryptionAlgorithm.Parameters.FullBytes, err = asn1.Marshal(params); err != nil {
+		t.Fatal(err)
+	}
+	if der, err = asn1.Marshal(info); err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
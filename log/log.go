@@ -16,14 +16,21 @@
 // log.Logger interface and include it in context by calling log.WithLogger.
 // 3rd party loggers that implement log.Logger: github.com/uber-go/zap.SugaredLogger
 // and github.com/sirupsen/logrus.Logger.
+// To correlate the log lines with a request, include a trace ID in context by
+// calling log.WithTraceID.
 package log
 
 import "context"
 
 type contextKey int
 
-// loggerKey is the associated key type for logger entry in context.
-const loggerKey contextKey = iota
+const (
+	// loggerKey is the associated key type for logger entry in context.
+	loggerKey contextKey = iota
+
+	// traceIDKey is the associated key type for trace ID entry in context.
+	traceIDKey
+)
 
 // Discard is a discardLogger that is used to disenable logging in notation.
 var Discard Logger = &discardLogger{}
@@ -80,11 +87,87 @@ func WithLogger(ctx context.Context, logger Logger) context.Context {
 }
 
 // GetLogger is used to retrieve the Logger from the context.
+// If a trace ID is set in the context by WithTraceID, every message logged by
+// the returned Logger is prefixed with the trace ID.
 func GetLogger(ctx context.Context) Logger {
-	if logger, ok := ctx.Value(loggerKey).(Logger); ok {
-		return logger
+	logger, ok := ctx.Value(loggerKey).(Logger)
+	if !ok {
+		return Discard
+	}
+	if traceID := GetTraceID(ctx); traceID != "" {
+		return &traceLogger{
+			Logger: logger,
+			prefix: "[trace_id=" + traceID + "]",
+		}
 	}
-	return Discard
+	return logger
+}
+
+// WithTraceID is used by callers to set the trace ID of a request in the
+// context, so that the log lines of notation operations performed with the
+// returned context can be correlated with the originating request.
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// GetTraceID is used to retrieve the trace ID from the context.
+func GetTraceID(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey).(string)
+	return traceID
+}
+
+// traceLogger wraps a Logger and prefixes every message with a trace ID.
+type traceLogger struct {
+	Logger
+	prefix string
+}
+
+func (tl *traceLogger) Debug(args ...interface{}) {
+	tl.Logger.Debug(append([]interface{}{tl.prefix + " "}, args...)...)
+}
+
+func (tl *traceLogger) Debugf(format string, args ...interface{}) {
+	tl.Logger.Debugf("%s "+format, append([]interface{}{tl.prefix}, args...)...)
+}
+
+func (tl *traceLogger) Debugln(args ...interface{}) {
+	tl.Logger.Debugln(append([]interface{}{tl.prefix}, args...)...)
+}
+
+func (tl *traceLogger) Info(args ...interface{}) {
+	tl.Logger.Info(append([]interface{}{tl.prefix + " "}, args...)...)
+}
+
+func (tl *traceLogger) Infof(format string, args ...interface{}) {
+	tl.Logger.Infof("%s "+format, append([]interface{}{tl.prefix}, args...)...)
+}
+
+func (tl *traceLogger) Infoln(args ...interface{}) {
+	tl.Logger.Infoln(append([]interface{}{tl.prefix}, args...)...)
+}
+
+func (tl *traceLogger) Warn(args ...interface{}) {
+	tl.Logger.Warn(append([]interface{}{tl.prefix + " "}, args...)...)
+}
+
+func (tl *traceLogger) Warnf(format string, args ...interface{}) {
+	tl.Logger.Warnf("%s "+format, append([]interface{}{tl.prefix}, args...)...)
+}
+
+func (tl *traceLogger) Warnln(args ...interface{}) {
+	tl.Logger.Warnln(append([]interface{}{tl.prefix}, args...)...)
+}
+
+func (tl *traceLogger) Error(args ...interface{}) {
+	tl.Logger.Error(append([]interface{}{tl.prefix + " "}, args...)...)
+}
+
+func (tl *traceLogger) Errorf(format string, args ...interface{}) {
+	tl.Logger.Errorf("%s "+format, append([]interface{}{tl.prefix}, args...)...)
+}
+
+func (tl *traceLogger) Errorln(args ...interface{}) {
+	tl.Logger.Errorln(append([]interface{}{tl.prefix}, args...)...)
 }
 
 // discardLogger implements Logger but logs nothing. It is used when user
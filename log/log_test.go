@@ -20,6 +20,8 @@ package log
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -39,3 +41,116 @@ func TestGetLoggerWithNoLogger(t *testing.T) {
 		t.Errorf("GetLogger() = %v, want Discard", got)
 	}
 }
+
+// recordingLogger records the messages formatted the way fmt does.
+type recordingLogger struct {
+	messages []string
+}
+
+func (rl *recordingLogger) record(msg string) {
+	rl.messages = append(rl.messages, msg)
+}
+
+func (rl *recordingLogger) Debug(args ...interface{}) {
+	rl.record(fmt.Sprint(args...))
+}
+
+func (rl *recordingLogger) Debugf(format string, args ...interface{}) {
+	rl.record(fmt.Sprintf(format, args...))
+}
+
+func (rl *recordingLogger) Debugln(args ...interface{}) {
+	rl.record(fmt.Sprintln(args...))
+}
+
+func (rl *recordingLogger) Info(args ...interface{}) {
+	rl.record(fmt.Sprint(args...))
+}
+
+func (rl *recordingLogger) Infof(format string, args ...interface{}) {
+	rl.record(fmt.Sprintf(format, args...))
+}
+
+func (rl *recordingLogger) Infoln(args ...interface{}) {
+	rl.record(fmt.Sprintln(args...))
+}
+
+func (rl *recordingLogger) Warn(args ...interface{}) {
+	rl.record(fmt.Sprint(args...))
+}
+
+func (rl *recordingLogger) Warnf(format string, args ...interface{}) {
+	rl.record(fmt.Sprintf(format, args...))
+}
+
+func (rl *recordingLogger) Warnln(args ...interface{}) {
+	rl.record(fmt.Sprintln(args...))
+}
+
+func (rl *recordingLogger) Error(args ...interface{}) {
+	rl.record(fmt.Sprint(args...))
+}
+
+func (rl *recordingLogger) Errorf(format string, args ...interface{}) {
+	rl.record(fmt.Sprintf(format, args...))
+}
+
+func (rl *recordingLogger) Errorln(args ...interface{}) {
+	rl.record(fmt.Sprintln(args...))
+}
+
+func TestGetLoggerWithTraceID(t *testing.T) {
+	rl := &recordingLogger{}
+	ctx := WithTraceID(WithLogger(context.Background(), rl), "abc")
+	if got := GetTraceID(ctx); got != "abc" {
+		t.Fatalf("GetTraceID() = %q, want %q", got, "abc")
+	}
+
+	logger := GetLogger(ctx)
+	logger.Debug("msg")
+	logger.Infof("msg %d", 1)
+	logger.Warnln("msg", 2)
+	logger.Error("msg")
+	want := []string{
+		"[trace_id=abc] msg",
+		"[trace_id=abc] msg 1",
+		"[trace_id=abc] msg 2\n",
+		"[trace_id=abc] msg",
+	}
+	if !reflect.DeepEqual(rl.messages, want) {
+		t.Fatalf("logged messages = %q, want %q", rl.messages, want)
+	}
+}
+
+func TestGetLoggerWithTraceIDPercent(t *testing.T) {
+	rl := &recordingLogger{}
+	ctx := WithTraceID(WithLogger(context.Background(), rl), "a%sb%d")
+
+	logger := GetLogger(ctx)
+	logger.Debugf("msg %s", "x")
+	logger.Infof("msg %d", 1)
+	logger.Warnf("msg")
+	logger.Errorf("msg %v", "y")
+	want := []string{
+		"[trace_id=a%sb%d] msg x",
+		"[trace_id=a%sb%d] msg 1",
+		"[trace_id=a%sb%d] msg",
+		"[trace_id=a%sb%d] msg y",
+	}
+	if !reflect.DeepEqual(rl.messages, want) {
+		t.Fatalf("logged messages = %q, want %q", rl.messages, want)
+	}
+}
+
+func TestGetLoggerWithoutTraceID(t *testing.T) {
+	rl := &recordingLogger{}
+	ctx := WithLogger(context.Background(), rl)
+	if got := GetLogger(ctx); got != rl {
+		t.Errorf("GetLogger() = %v, want %v", got, rl)
+	}
+
+	// trace ID without logger
+	if got := GetLogger(WithTraceID(context.Background(), "abc")); got != Discard {
+		t.Errorf("GetLogger() = %v, want Discard", got)
+	}
+}
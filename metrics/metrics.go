@@ -0,0 +1,104 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides timing instrumentation to notation.
+// Users who want to observe where time is spent during signing and
+// verification should implement the metrics.Metrics interface and include it
+// in context by calling metrics.WithMetrics. Implementations can forward the
+// observations to a metrics system such as Prometheus.
+package metrics
+
+import (
+	"context"
+	"time"
+)
+
+type contextKey int
+
+// metricsKey is the associated key type for metrics entry in context.
+const metricsKey contextKey = iota
+
+// Stages of signing and verification reported to [Metrics].
+const (
+	// StageResolve is the resolution of the artifact reference to a
+	// descriptor.
+	StageResolve = "resolve"
+
+	// StageSign is the generation of the signature envelope by the signer.
+	StageSign = "sign"
+
+	// StagePushSignature is the push of the signature to the repository.
+	StagePushSignature = "push_signature"
+
+	// StageFetchSignature is the fetch of a signature envelope from the
+	// repository.
+	StageFetchSignature = "fetch_signature"
+
+	// StageVerifySignature is the verification of a signature envelope as a
+	// whole, including all the stages below.
+	StageVerifySignature = "verify_signature"
+
+	// StageIntegrity is the integrity check of a signature envelope.
+	StageIntegrity = "integrity"
+
+	// StageAuthenticity is the authenticity check of a signature envelope,
+	// including loading the trust stores.
+	StageAuthenticity = "authenticity"
+
+	// StageAuthenticTimestamp is the authentic timestamp check of a signature
+	// envelope.
+	StageAuthenticTimestamp = "authentic_timestamp"
+
+	// StageRevocation is the revocation check of the signing certificate
+	// chain.
+	StageRevocation = "revocation"
+
+	// StagePlugin is the verification performed by a verification plugin.
+	StagePlugin = "plugin"
+)
+
+// Discard is a discardMetrics that is used to disable metrics in notation.
+var Discard Metrics = &discardMetrics{}
+
+// Metrics is implemented by users to observe the duration of each stage of
+// signing and verification.
+type Metrics interface {
+	// ObserveDuration records that stage took d to complete.
+	ObserveDuration(stage string, d time.Duration)
+}
+
+// WithMetrics is used by callers to set the Metrics in the context.
+func WithMetrics(ctx context.Context, m Metrics) context.Context {
+	return context.WithValue(ctx, metricsKey, m)
+}
+
+// GetMetrics is used to retrieve the Metrics from the context.
+func GetMetrics(ctx context.Context) Metrics {
+	if m, ok := ctx.Value(metricsKey).(Metrics); ok {
+		return m
+	}
+	return Discard
+}
+
+// ObserveSince records the time elapsed since start for stage to the Metrics
+// in the context.
+func ObserveSince(ctx context.Context, stage string, start time.Time) {
+	GetMetrics(ctx).ObserveDuration(stage, time.Since(start))
+}
+
+// discardMetrics implements Metrics but records nothing. It is used when
+// metricsKey is not in the context.
+type discardMetrics struct{}
+
+func (dm *discardMetrics) ObserveDuration(stage string, d time.Duration) {
+}
@@ -0,0 +1,55 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type recordingMetrics struct {
+	stages []string
+}
+
+func (m *recordingMetrics) ObserveDuration(stage string, d time.Duration) {
+	m.stages = append(m.stages, stage)
+}
+
+func TestWithMetricsAndGetMetrics(t *testing.T) {
+	m := &recordingMetrics{}
+	ctx := WithMetrics(context.Background(), m)
+
+	if got := GetMetrics(ctx); got != m {
+		t.Errorf("GetMetrics() = %v, want %v", got, m)
+	}
+}
+
+func TestGetMetricsWithNoMetrics(t *testing.T) {
+	if got := GetMetrics(context.Background()); got != Discard {
+		t.Errorf("GetMetrics() = %v, want Discard", got)
+	}
+	// must not panic
+	ObserveSince(context.Background(), StageResolve, time.Now())
+}
+
+func TestObserveSince(t *testing.T) {
+	m := &recordingMetrics{}
+	ctx := WithMetrics(context.Background(), m)
+
+	ObserveSince(ctx, StageSign, time.Now())
+	if len(m.stages) != 1 || m.stages[0] != StageSign {
+		t.Errorf("observed stages = %v, want [%s]", m.stages, StageSign)
+	}
+}
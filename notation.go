@@ -18,6 +18,7 @@ package notation
 import (
 	"context"
 	"crypto/sha256"
+	_ "crypto/sha512" // register SHA-384 and SHA-512 for sha384: and sha512: digests
 	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
@@ -25,6 +26,7 @@ import (
 	"fmt"
 	"io"
 	"mime"
+	"sort"
 	"strings"
 	"time"
 
@@ -32,11 +34,15 @@ import (
 	"oras.land/oras-go/v2/registry/remote"
 
 	"github.com/notaryproject/notation-core-go/revocation"
+	revocationresult "github.com/notaryproject/notation-core-go/revocation/result"
 	"github.com/notaryproject/notation-core-go/signature"
 	"github.com/notaryproject/notation-core-go/signature/cose"
 	"github.com/notaryproject/notation-core-go/signature/jws"
+	"github.com/notaryproject/notation-go/internal/clock"
 	"github.com/notaryproject/notation-go/internal/envelope"
+	"github.com/notaryproject/notation-go/internal/slices"
 	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation-go/metrics"
 	"github.com/notaryproject/notation-go/registry"
 	"github.com/notaryproject/notation-go/verifier/trustpolicy"
 	"github.com/notaryproject/tspclient-go"
@@ -46,6 +52,31 @@ import (
 
 var errDoneVerification = errors.New("done verification")
 
+// Prefixes of the user metadata values to be verified, which select a pattern
+// matching mode instead of the default exact matching.
+const (
+	// UserMetadataRegexPrefix marks a value as a regular expression, e.g.
+	// `regex:prod-.*`.
+	UserMetadataRegexPrefix = "regex:"
+
+	// UserMetadataGlobPrefix marks a value as a glob pattern, e.g.
+	// `glob:prod-*`.
+	UserMetadataGlobPrefix = "glob:"
+)
+
+// AnnotationPreviousSignature is the signature manifest annotation holding
+// the digest of the signature manifest previously produced by the same
+// signing certificate for the same artifact, forming a signature chain.
+// It is set outside of the `io.cncf.notary` prefix reserved by the Notary
+// Project specification. The annotation is not covered by the signature, and
+// the linked signature is not verified when linking, so the link is
+// unauthenticated and must only be used for auditing.
+const AnnotationPreviousSignature = "org.notaryproject.notation.previousSignature"
+
+// maxPreviousSignatureCandidates is the maximum number of signatures of an
+// artifact examined when looking up the previous signature.
+const maxPreviousSignatureCandidates = 50
+
 var reservedAnnotationPrefixes = [...]string{"io.cncf.notary"}
 
 // SignerSignOptions contains parameters for [Signer] and [BlobSigner].
@@ -75,6 +106,48 @@ type SignerSignOptions struct {
 	// timestamping certificate chain with context during signing.
 	// When present, only used when timestamping is performed.
 	TSARevocationValidator revocation.Validator
+
+	// PayloadPacker packs the payload to be signed in place of the notary
+	// payload, e.g. to sign an in-toto attestation statement.
+	// If nil, the notary payload of the signature specification is signed.
+	PayloadPacker PayloadPacker
+
+	// SigningTime sets the signing time of the signature, e.g. for
+	// reproducible signing. It must not be more than 5 minutes in the
+	// future, tolerating clock skew, and must be within the validity period
+	// of the signing certificate. The validity period is checked before
+	// signing only if the certificate chain of the signer is known
+	// beforehand, otherwise it is checked by the signature envelope. The
+	// signing time is truncated to seconds.
+	// The signing time is always placed in the protected header of the
+	// signature envelope, i.e. `io.cncf.notary.signingTime` for the
+	// notary.x509 signing scheme and `io.cncf.notary.authenticSigningTime`
+	// for the notary.x509.signingAuthority signing scheme, so it is covered
+	// by the signature. The Notary Project signature specification does not
+	// allow it in the unprotected header, hence its placement is not
+	// configurable.
+	// If nil, the current time is used.
+	SigningTime *time.Time
+
+	// Clock provides the current time used for signing, e.g. a fixed time
+	// in tests. If nil, the system time is used.
+	Clock Clock
+}
+
+// Clock provides the current time to signing and verification.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// PayloadPacker packs the payload to be signed for an artifact.
+// Signatures with payloads other than the notary payload cannot be verified
+// by [Verifier] implementations following the signature specification, such
+// as the one of the verifier package.
+type PayloadPacker interface {
+	// PackPayload returns the payload, and its content type, to be signed
+	// for the artifact described by desc.
+	PackPayload(ctx context.Context, desc ocispec.Descriptor) (signature.Payload, error)
 }
 
 // Signer is a generic interface for signing an OCI artifact.
@@ -96,6 +169,12 @@ type SignBlobOptions struct {
 	// UserMetadata contains key-value pairs that are added to the signature
 	// payload
 	UserMetadata map[string]string
+
+	// ContentDigest is the digest of the blob, if it is known beforehand.
+	// Signing fails if the blob read does not match it, e.g. because the
+	// blob was modified after its digest was computed.
+	// If empty, the blob is not checked.
+	ContentDigest digest.Digest
 }
 
 // BlobDescriptorGenerator creates descriptor using the digest Algorithm.
@@ -118,6 +197,19 @@ type BlobSigner interface {
 	SignBlob(ctx context.Context, genDesc BlobDescriptorGenerator, opts SignerSignOptions) ([]byte, *signature.SignerInfo, error)
 }
 
+// StreamingSigner is an optional interface implemented by a [BlobSigner] to
+// sign a blob read from a stream, e.g. to forward the blob to a remote
+// signing service while computing its digest. [SignBlob] uses it if
+// implemented.
+type StreamingSigner interface {
+	// SignStream signs the blob read from r, whose digest is computed while
+	// reading, and returns the signature and SignerInfo. The blob is
+	// described by the media type opts.ContentMediaType and annotated with
+	// opts.UserMetadata. SignStream must read r until io.EOF, and fail on
+	// any read error.
+	SignStream(ctx context.Context, r io.Reader, opts SignBlobOptions) ([]byte, *signature.SignerInfo, error)
+}
+
 // signerAnnotation facilitates return of manifest annotations by signers
 type signerAnnotation interface {
 	// PluginAnnotations returns signature manifest annotations returned from
@@ -136,10 +228,30 @@ type SignOptions struct {
 	// UserMetadata contains key-value pairs that are added to the signature
 	// payload
 	UserMetadata map[string]string
+
+	// RequireDigestReference makes Sign fail instead of resolving
+	// ArtifactReference when it is a tag, since tags are mutable.
+	RequireDigestReference bool
+
+	// LinkPreviousSignature looks up the latest existing signature of the
+	// artifact from the same signing certificate, i.e. with the same SHA-256
+	// fingerprint, and records its manifest digest in the
+	// [AnnotationPreviousSignature] annotation of the new signature. It is
+	// useful for auditing re-signing.
+	// At most the first 50 signatures of the artifact are examined, and the
+	// signatures that fail to be fetched or parsed are skipped. The existing
+	// signatures are not verified, so the link is unauthenticated.
+	LinkPreviousSignature bool
 }
 
 // Sign signs the OCI artifact and push the signature to the Repository.
 // The descriptor of the sign content is returned upon successful signing.
+//
+// The signature envelope embeds the complete certificate chain of the signing
+// key, from the leaf certificate up to the root certificate, and signing fails
+// if the chain is incomplete. Since verification builds the chain from the
+// envelope only, signatures can be verified offline without fetching any
+// intermediate certificate.
 func Sign(ctx context.Context, signer Signer, repo registry.Repository, signOpts SignOptions) (ocispec.Descriptor, error) {
 	// sanity check
 	if err := validateSignArguments(signer, signOpts.SignerSignOptions); err != nil {
@@ -149,13 +261,58 @@ func Sign(ctx context.Context, signer Signer, repo registry.Repository, signOpts
 		return ocispec.Descriptor{}, errors.New("repo cannot be nil")
 	}
 
+	targetDesc, err := resolveSignTarget(ctx, repo, signOpts)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return signDescriptor(ctx, signer, repo, targetDesc, signOpts)
+}
+
+// SignDualEnvelope signs the OCI artifact in both the JWS and the COSE
+// envelope formats, and pushes both signatures to the Repository, e.g. to
+// serve verifiers understanding only one of the formats during a migration.
+// Both envelopes sign the same payload with the same signing time.
+// signOpts.SignatureMediaType is ignored.
+// The descriptors of the JWS and the COSE signature manifests are returned,
+// in that order, upon successful signing.
+func SignDualEnvelope(ctx context.Context, signer Signer, repo registry.Repository, signOpts SignOptions) ([]ocispec.Descriptor, error) {
+	signOpts.SignatureMediaType = jws.MediaTypeEnvelope
+	// sanity check
+	if err := validateSignArguments(signer, signOpts.SignerSignOptions); err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return nil, errors.New("repo cannot be nil")
+	}
+	targetDesc, err := resolveSignTarget(ctx, repo, signOpts)
+	if err != nil {
+		return nil, err
+	}
+	if signOpts.SigningTime == nil {
+		// share the signing time between the envelopes
+		signingTime := clock.Now(signOpts.Clock).Truncate(time.Second)
+		signOpts.SigningTime = &signingTime
+	}
+	return signEnvelopes(ctx, signer, repo, targetDesc, signOpts, []string{jws.MediaTypeEnvelope, cose.MediaTypeEnvelope})
+}
+
+// resolveSignTarget resolves signOpts.ArtifactReference to the descriptor of
+// the artifact to be signed.
+func resolveSignTarget(ctx context.Context, repo registry.Repository, signOpts SignOptions) (ocispec.Descriptor, error) {
 	logger := log.GetLogger(ctx)
 	artifactRef := signOpts.ArtifactReference
 	if ref, err := orasRegistry.ParseReference(artifactRef); err == nil {
 		// artifactRef is a valid full reference
 		artifactRef = ref.Reference
 	}
+	if signOpts.RequireDigestReference {
+		if err := (orasRegistry.Reference{Reference: artifactRef}).ValidateReferenceAsDigest(); err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("artifact reference %s must be a digest reference, since signing by tag is not allowed", signOpts.ArtifactReference)
+		}
+	}
+	resolveStart := time.Now()
 	targetDesc, err := repo.Resolve(ctx, artifactRef)
+	metrics.ObserveSince(ctx, metrics.StageResolve, resolveStart)
 	if err != nil {
 		return ocispec.Descriptor{}, fmt.Errorf("failed to resolve reference: %w", err)
 	}
@@ -169,40 +326,160 @@ func Sign(ctx context.Context, signer Signer, repo registry.Repository, signOpts
 		}
 
 		// artifactRef is a tag
-		logger.Warnf("Always sign the artifact using digest(`@sha256:...`) rather than a tag(`:%s`) because tags are mutable and a tag reference can point to a different artifact than the one signed", artifactRef)
+		logger.Warnf("Always sign the artifact using digest(e.g. `@sha256:...`) rather than a tag(`:%s`) because tags are mutable and a tag reference can point to a different artifact than the one signed", artifactRef)
 		logger.Infof("Resolved artifact tag `%s` to digest `%v` before signing", artifactRef, targetDesc.Digest)
 	}
-	descToSign, err := addUserMetadataToDescriptor(ctx, targetDesc, signOpts.UserMetadata)
-	if err != nil {
+	return targetDesc, nil
+}
+
+// SignDescriptor signs the OCI artifact described by targetDesc and pushes
+// the signature to the Repository. Unlike [Sign], it does not resolve
+// signOpts.ArtifactReference, which saves a registry round-trip when the
+// caller already has the descriptor, e.g. right after pushing the artifact.
+// The descriptor of the sign content is returned upon successful signing.
+func SignDescriptor(ctx context.Context, signer Signer, repo registry.Repository, targetDesc ocispec.Descriptor, signOpts SignOptions) (ocispec.Descriptor, error) {
+	// sanity check
+	if err := validateSignArguments(signer, signOpts.SignerSignOptions); err != nil {
 		return ocispec.Descriptor{}, err
 	}
-	sig, signerInfo, err := signer.Sign(ctx, descToSign, signOpts.SignerSignOptions)
-	if err != nil {
-		return ocispec.Descriptor{}, err
+	if repo == nil {
+		return ocispec.Descriptor{}, errors.New("repo cannot be nil")
 	}
-
-	var pluginAnnotations map[string]string
-	if signerAnts, ok := signer.(signerAnnotation); ok {
-		pluginAnnotations = signerAnts.PluginAnnotations()
+	if err := targetDesc.Digest.Validate(); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("invalid target descriptor: %w", err)
 	}
-	logger.Debug("Generating annotation")
-	annotations, err := generateAnnotations(signerInfo, pluginAnnotations)
-	if err != nil {
+	return signDescriptor(ctx, signer, repo, targetDesc, signOpts)
+}
+
+// signDescriptor signs targetDesc and pushes the signature to repo.
+func signDescriptor(ctx context.Context, signer Signer, repo registry.Repository, targetDesc ocispec.Descriptor, signOpts SignOptions) (ocispec.Descriptor, error) {
+	if _, err := signEnvelopes(ctx, signer, repo, targetDesc, signOpts, []string{signOpts.SignatureMediaType}); err != nil {
 		return ocispec.Descriptor{}, err
 	}
-	logger.Debugf("Generated annotations: %+v", annotations)
-	logger.Debugf("Pushing signature of artifact descriptor: %+v, signature media type: %v", targetDesc, signOpts.SignatureMediaType)
-	_, _, err = repo.PushSignature(ctx, signOpts.SignatureMediaType, sig, targetDesc, annotations)
+	return targetDesc, nil
+}
+
+// signEnvelopes signs targetDesc in each of the envelope types of mediaTypes,
+// pushes the signatures to repo, and returns the descriptors of the signature
+// manifests.
+func signEnvelopes(ctx context.Context, signer Signer, repo registry.Repository, targetDesc ocispec.Descriptor, signOpts SignOptions, mediaTypes []string) ([]ocispec.Descriptor, error) {
+	logger := log.GetLogger(ctx)
+	descToSign, err := addUserMetadataToDescriptor(ctx, targetDesc, signOpts.UserMetadata)
 	if err != nil {
-		var referrerError *remote.ReferrersError
+		return nil, err
+	}
+	var previous digest.Digest
+	manifestDescs := make([]ocispec.Descriptor, 0, len(mediaTypes))
+	for i, mediaType := range mediaTypes {
+		opts := signOpts.SignerSignOptions
+		opts.SignatureMediaType = mediaType
+		signStart := time.Now()
+		sig, signerInfo, err := signer.Sign(ctx, descToSign, opts)
+		metrics.ObserveSince(ctx, metrics.StageSign, signStart)
+		if err != nil {
+			return nil, err
+		}
+
+		var pluginAnnotations map[string]string
+		if signerAnts, ok := signer.(signerAnnotation); ok {
+			pluginAnnotations = signerAnts.PluginAnnotations()
+		}
+		logger.Debug("Generating annotation")
+		annotations, err := generateAnnotations(signerInfo, pluginAnnotations)
+		if err != nil {
+			return nil, err
+		}
+		// the previous signature is looked up before pushing any signature,
+		// so that the envelopes do not link to each other
+		if signOpts.LinkPreviousSignature && i == 0 {
+			previous, err = findPreviousSignature(ctx, repo, targetDesc, signerInfo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up the previous signature: %w", err)
+			}
+			if previous != "" {
+				logger.Infof("Linking the signature to the previous signature %v", previous)
+			}
+		}
+		if previous != "" {
+			annotations[AnnotationPreviousSignature] = previous.String()
+		}
+		logger.Debugf("Generated annotations: %+v", annotations)
+		logger.Debugf("Pushing signature of artifact descriptor: %+v, signature media type: %v", targetDesc, mediaType)
+		pushStart := time.Now()
+		_, manifestDesc, err := repo.PushSignature(ctx, mediaType, sig, targetDesc, annotations)
+		metrics.ObserveSince(ctx, metrics.StagePushSignature, pushStart)
+		if err != nil {
+			var referrerError *remote.ReferrersError
 
-		// do not log an error for failing to delete referral index
-		if !errors.As(err, &referrerError) || !referrerError.IsReferrersIndexDelete() {
-			logger.Error("Failed to push the signature")
+			// do not log an error for failing to delete referral index
+			if !errors.As(err, &referrerError) || !referrerError.IsReferrersIndexDelete() {
+				logger.Error("Failed to push the signature")
+			}
+			return nil, ErrorPushSignatureFailed{Msg: err.Error()}
 		}
-		return ocispec.Descriptor{}, ErrorPushSignatureFailed{Msg: err.Error()}
+		manifestDescs = append(manifestDescs, manifestDesc)
 	}
-	return targetDesc, nil
+	return manifestDescs, nil
+}
+
+// findPreviousSignature returns the manifest digest of the latest signature
+// of targetDesc whose signing certificate is the one of signerInfo. An empty
+// digest is returned if there is no such signature among the first
+// maxPreviousSignatureCandidates signatures of targetDesc. The signatures are
+// not verified.
+func findPreviousSignature(ctx context.Context, repo registry.Repository, targetDesc ocispec.Descriptor, signerInfo *signature.SignerInfo) (digest.Digest, error) {
+	logger := log.GetLogger(ctx)
+	if len(signerInfo.CertificateChain) == 0 {
+		return "", errors.New("signing certificate is missing")
+	}
+	fingerprint := sha256.Sum256(signerInfo.CertificateChain[0].Raw)
+
+	var previous digest.Digest
+	var previousSigningTime time.Time
+	var numOfCandidates int
+	errDoneLookup := errors.New("done looking up the previous signature")
+	err := repo.ListSignatures(ctx, targetDesc, func(signatureManifests []ocispec.Descriptor) error {
+		for _, sigManifestDesc := range signatureManifests {
+			if numOfCandidates >= maxPreviousSignatureCandidates {
+				logger.Warnf("Stopped looking up the previous signature after %d signatures", maxPreviousSignatureCandidates)
+				return errDoneLookup
+			}
+			numOfCandidates++
+			sigBlob, sigDesc, err := repo.FetchSignatureBlob(ctx, sigManifestDesc)
+			if err != nil {
+				logger.Warnf("Skipping signature %v, failed to retrieve the signature envelope: %v", sigManifestDesc.Digest, err)
+				continue
+			}
+			sigEnv, err := signature.ParseEnvelope(sigDesc.MediaType, sigBlob)
+			if err != nil {
+				logger.Warnf("Skipping signature %v, failed to parse the signature envelope: %v", sigManifestDesc.Digest, err)
+				continue
+			}
+			content, err := sigEnv.Content()
+			if err != nil {
+				logger.Warnf("Skipping signature %v, failed to get the signature envelope content: %v", sigManifestDesc.Digest, err)
+				continue
+			}
+			certChain := content.SignerInfo.CertificateChain
+			if len(certChain) == 0 || sha256.Sum256(certChain[0].Raw) != fingerprint {
+				continue
+			}
+			signingTime, err := envelope.SigningTime(&content.SignerInfo)
+			if err != nil {
+				logger.Warnf("Skipping signature %v: %v", sigManifestDesc.Digest, err)
+				continue
+			}
+			if previous == "" || signingTime.After(previousSigningTime) {
+				previous = sigManifestDesc.Digest
+				previousSigningTime = signingTime
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDoneLookup) {
+		return "", err
+	}
+	return previous, nil
 }
 
 // SignBlob signs the arbitrary data from blobReader and returns
@@ -221,11 +498,52 @@ func SignBlob(ctx context.Context, signer BlobSigner, blobReader io.Reader, sign
 	if err := validateContentMediaType(signBlobOpts.ContentMediaType); err != nil {
 		return nil, nil, err
 	}
+	if signBlobOpts.ContentDigest != "" {
+		if err := signBlobOpts.ContentDigest.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("invalid content digest: %w", err)
+		}
+		blobReader = newDigestVerifyingReader(blobReader, signBlobOpts.ContentDigest)
+	}
 
+	if streamingSigner, ok := signer.(StreamingSigner); ok {
+		return streamingSigner.SignStream(ctx, blobReader, signBlobOpts)
+	}
 	getDescFunc := getDescriptorFunc(ctx, blobReader, signBlobOpts.ContentMediaType, signBlobOpts.UserMetadata)
 	return signer.SignBlob(ctx, getDescFunc, signBlobOpts.SignerSignOptions)
 }
 
+// digestVerifyingReader reads from an underlying reader, and returns an
+// error instead of io.EOF if the content read does not match the expected
+// digest.
+type digestVerifyingReader struct {
+	r        io.Reader
+	digester digest.Digester
+	expected digest.Digest
+}
+
+// newDigestVerifyingReader returns a reader of r verifying that the content
+// of r matches expected. The algorithm of expected must be available.
+func newDigestVerifyingReader(r io.Reader, expected digest.Digest) *digestVerifyingReader {
+	return &digestVerifyingReader{
+		r:        r,
+		digester: expected.Algorithm().Digester(),
+		expected: expected,
+	}
+}
+
+// Read reads from the underlying reader and updates the digest of the
+// content read.
+func (vr *digestVerifyingReader) Read(p []byte) (int, error) {
+	n, err := vr.r.Read(p)
+	vr.digester.Hash().Write(p[:n])
+	if err == io.EOF {
+		if got := vr.digester.Digest(); got != vr.expected {
+			return n, fmt.Errorf("blob digest %s does not match the content digest %s", got, vr.expected)
+		}
+	}
+	return n, err
+}
+
 func validateSignArguments(signer any, signOpts SignerSignOptions) error {
 	if signer == nil {
 		return errors.New("signer cannot be nil")
@@ -280,6 +598,21 @@ type ValidationResult struct {
 	Error error
 }
 
+// SkipReason is the reason why a signature verification was skipped.
+type SkipReason string
+
+const (
+	// SkipReasonVerificationLevel indicates that the applicable trust policy
+	// sets the signature verification level to 'skip'.
+	SkipReasonVerificationLevel SkipReason = "verificationLevelSkip"
+
+	// SkipReasonUnsigned indicates that no signature is associated with the
+	// artifact, which is accepted by [VerifyOptions.AllowUnsigned] as the
+	// verification level of the applicable trust policy does not enforce
+	// authenticity, e.g. 'audit'.
+	SkipReasonUnsigned SkipReason = "unsigned"
+)
+
 // VerificationOutcome encapsulates a signature envelope blob, its content,
 // the verification level and results for each verification type that was
 // performed.
@@ -295,18 +628,76 @@ type VerificationOutcome struct {
 	// performing signature verification
 	VerificationLevel *trustpolicy.VerificationLevel
 
+	// TrustPolicyName is the name of the trust policy statement applied to
+	// the signature, on success and on failure. It is not set by
+	// [notation.Verify] for the outcome of a verification skipped because of
+	// the verification level.
+	TrustPolicyName string
+
 	// VerificationResults contains the verifications performed on the signature
 	// and their results
 	VerificationResults []*ValidationResult
 
 	// Error that caused the verification to fail (if it fails)
 	Error error
+
+	// SkipReason tells why the signature verification was skipped. It is
+	// empty if the verification was not skipped.
+	SkipReason SkipReason
+
+	// PreviousSignature is the manifest digest of the signature that this
+	// signature links to by the [AnnotationPreviousSignature] annotation, if
+	// any. It is only set by [notation.Verify]. The annotation is not covered
+	// by the signature, so the link is unauthenticated.
+	PreviousSignature digest.Digest
+
+	// Platform is the platform of the image index manifest the signature
+	// is verified against. It is only set by [notation.Verify] for the
+	// outcomes of [VerifyOptions.VerifyPlatforms].
+	Platform *ocispec.Platform
+
+	// RevocationEvidence contains the revocation status of each certificate
+	// of the signing certificate chain, as reported by the queried
+	// revocation servers. It is only set if the revocation check was
+	// performed and evidence collection was requested, e.g. by
+	// [VerifierVerifyOptions.CollectRevocationEvidence].
+	RevocationEvidence []RevocationEvidence
+
+	// PayloadVersion is the schema version of the signature payload, e.g. 1
+	// for `application/vnd.cncf.notary.payload.v1+json`. Payloads of a newer
+	// version than the one known to this library are verified on the known
+	// fields, and their unknown fields are ignored.
+	PayloadVersion int
+}
+
+// RevocationEvidence is the result of the revocation check of a certificate,
+// retained for auditing.
+type RevocationEvidence struct {
+	// Certificate is the certificate whose revocation status was checked.
+	Certificate *x509.Certificate
+
+	// Result is the revocation result of Certificate, including the
+	// revocation method used and the result and URL of each revocation
+	// server queried.
+	Result *revocationresult.CertRevocationResult
+}
+
+// RevocationDataProvider supplies pre-fetched revocation data, e.g. a
+// revocation bundle shipped alongside the artifacts for the verification in
+// air-gapped environments.
+type RevocationDataProvider interface {
+	// CRL returns the DER encoded CRL issued by issuer.
+	CRL(ctx context.Context, issuer *x509.Certificate) ([]byte, error)
+
+	// OCSPResponse returns the DER encoded OCSP response for cert, which is
+	// issued by issuer.
+	OCSPResponse(ctx context.Context, cert, issuer *x509.Certificate) ([]byte, error)
 }
 
 // UserMetadata returns the user metadata from the signature envelope.
 func (outcome *VerificationOutcome) UserMetadata() (map[string]string, error) {
 	if outcome.EnvelopeContent == nil {
-		return nil, errors.New("unable to find envelope content for verification outcome")
+		return nil, ErrorEnvelopeContentNotFound{}
 	}
 
 	var payload envelope.Payload
@@ -320,6 +711,42 @@ func (outcome *VerificationOutcome) UserMetadata() (map[string]string, error) {
 	return payload.TargetArtifact.Annotations, nil
 }
 
+// Skipped reports whether the signature verification was intentionally
+// skipped, e.g. because the applicable trust policy sets the verification
+// level to 'skip'. A skipped outcome carries no signature.
+func (outcome *VerificationOutcome) Skipped() bool {
+	return outcome.SkipReason != ""
+}
+
+// SigningCertificateChain returns the certificate chain of the signing
+// certificate from the signature envelope. The first certificate is the
+// signing certificate.
+func (outcome *VerificationOutcome) SigningCertificateChain() ([]*x509.Certificate, error) {
+	if outcome.EnvelopeContent == nil {
+		return nil, ErrorEnvelopeContentNotFound{}
+	}
+	certChain := outcome.EnvelopeContent.SignerInfo.CertificateChain
+	if len(certChain) == 0 {
+		return nil, errors.New("certificate chain is not present in the signature envelope")
+	}
+	return certChain, nil
+}
+
+// SigningTime returns the signing time from the signature envelope.
+// The signing time is read from the protected header, so it is authenticated
+// by the signature, but it is asserted by the signer. Use the timestamp
+// countersignature of the envelope for a trusted time.
+func (outcome *VerificationOutcome) SigningTime() (time.Time, error) {
+	if outcome.EnvelopeContent == nil {
+		return time.Time{}, ErrorEnvelopeContentNotFound{}
+	}
+	signingTime := outcome.EnvelopeContent.SignerInfo.SignedAttributes.SigningTime
+	if signingTime.IsZero() {
+		return time.Time{}, errors.New("signing time is not present in the signature envelope")
+	}
+	return signingTime, nil
+}
+
 // VerifierVerifyOptions contains parameters for [Verifier.Verify] used for
 // verifying OCI artifact.
 type VerifierVerifyOptions struct {
@@ -337,7 +764,65 @@ type VerifierVerifyOptions struct {
 
 	// UserMetadata contains key-value pairs that must be present in the
 	// signature.
+	// By default, values are matched exactly. A value prefixed with
+	// [UserMetadataRegexPrefix] is matched as a regular expression against
+	// the whole signature value, and a value prefixed with
+	// [UserMetadataGlobPrefix] is matched as a [path.Match] pattern.
 	UserMetadata map[string]string
+
+	// OCITrustPolicy is the trust policy document to verify against instead
+	// of the one the verifier is configured with, e.g. the policy of the
+	// tenant owning the artifact in a multi-tenant service. It is validated
+	// by the verifier. If nil, the verifier's own trust policy document is
+	// used.
+	OCITrustPolicy *trustpolicy.OCIDocument
+
+	// CollectRevocationEvidence makes the verifier retain the revocation
+	// results of the signing certificate chain in
+	// [VerificationOutcome.RevocationEvidence], e.g. to prove at audit time
+	// that revocation was checked.
+	CollectRevocationEvidence bool
+
+	// RequireRevocationCheck makes the verifier fail verification when the
+	// revocation status of the signing certificate chain cannot be
+	// determined, e.g. because the OCSP responders or CRL distribution
+	// points are unreachable, even if the verification level only logs
+	// revocation failures. The error states the revocation method and why
+	// it failed.
+	// If the trust policy skips the revocation validation, verification
+	// fails as no revocation check is performed. If a verification plugin
+	// checks the revocation status instead, a failed plugin check fails
+	// verification. It has no effect if the whole verification is skipped.
+	RequireRevocationCheck bool
+
+	// CertificateChainValidator is an optional hook inspecting the signing
+	// certificate chain, from the leaf certificate to the root certificate,
+	// once the built-in authenticity checks have passed, e.g. for custom
+	// organizational checks on certificate policy OIDs. A non-nil error
+	// fails verification with the message of the error, regardless of the
+	// verification level.
+	CertificateChainValidator func(chain []*x509.Certificate) error
+
+	// RevocationData supplies the revocation data of the signing
+	// certificate chain, consulted instead of the OCSP responders and the CRL
+	// distribution points of the certificates, e.g. for offline
+	// verification. The revocation status of the timestamping certificate
+	// chain is still checked by the revocation validator of the verifier.
+	// If nil, the revocation data is fetched from the network.
+	RevocationData RevocationDataProvider
+
+	// RevocationDataMaxAge rejects the CRLs and OCSP responses supplied by
+	// RevocationData that were produced, i.e. their thisUpdate time, longer
+	// ago than RevocationDataMaxAge. Revocation data past its nextUpdate time
+	// is always rejected. If zero, the age of the revocation data is not
+	// checked.
+	RevocationDataMaxAge time.Duration
+
+	// Clock provides the time of verification, against which the expiry of
+	// the signature, the validity period of the signing certificate chain
+	// and the freshness of the data supplied by RevocationData are checked,
+	// e.g. a fixed time in tests. If nil, the system time is used.
+	Clock Clock
 }
 
 // Verifier is a generic interface for verifying an OCI artifact.
@@ -355,18 +840,34 @@ type BlobVerifierVerifyOptions struct {
 	// SignatureMediaType is the envelope type of the signature.
 	// Currently only `application/jose+json` and `application/cose` are
 	// supported.
+	// If empty, [notation.VerifyBlob] detects it from the signature with
+	// [DetectEnvelopeMediaType].
 	SignatureMediaType string
 
 	// PluginConfig is a map of plugin configs.
 	PluginConfig map[string]string
 
 	// UserMetadata contains key-value pairs that must be present in the
-	// signature.
+	// signature. Values are matched as in
+	// [VerifierVerifyOptions.UserMetadata].
 	UserMetadata map[string]string
 
 	// TrustPolicyName is the name of trust policy picked by caller.
 	// If empty, the global trust policy will be applied.
 	TrustPolicyName string
+
+	// CollectRevocationEvidence retains the revocation results as in
+	// [VerifierVerifyOptions.CollectRevocationEvidence].
+	CollectRevocationEvidence bool
+
+	// RequireRevocationCheck fails verification when the revocation status
+	// cannot be determined as in
+	// [VerifierVerifyOptions.RequireRevocationCheck].
+	RequireRevocationCheck bool
+
+	// Clock provides the time of verification as in
+	// [VerifierVerifyOptions.Clock].
+	Clock Clock
 }
 
 // BlobVerifier is a generic interface for verifying a blob.
@@ -397,8 +898,141 @@ type VerifyOptions struct {
 	MaxSignatureAttempts int
 
 	// UserMetadata contains key-value pairs that must be present in the
-	// signature
+	// signature. Values are matched as in
+	// [VerifierVerifyOptions.UserMetadata].
 	UserMetadata map[string]string
+
+	// RequireDigestReference makes Verify fail instead of resolving
+	// ArtifactReference when it is a tag, since tags are mutable.
+	RequireDigestReference bool
+
+	// AcceptedSignatureMediaTypes restricts the envelope types of the
+	// signatures to be verified, e.g. `application/cose` only.
+	// Signatures of other envelope types are skipped, and still count
+	// towards MaxSignatureAttempts.
+	// If empty, all supported envelope types are accepted.
+	AcceptedSignatureMediaTypes []string
+
+	// ProgressFunc is an optional callback invoked each time a signature
+	// has been processed, whether it was verified, failed or skipped.
+	// processed is the number of signatures processed so far, total is the
+	// number of signatures discovered so far capped by MaxSignatureAttempts,
+	// and current is the descriptor of the signature manifest just
+	// processed. As signatures are listed page by page, total may grow
+	// between invocations.
+	// ProgressFunc is always invoked from the goroutine calling
+	// [notation.Verify].
+	ProgressFunc func(processed, total int, current ocispec.Descriptor)
+
+	// OCITrustPolicy overrides the trust policy document of the verifier as
+	// in [VerifierVerifyOptions.OCITrustPolicy].
+	OCITrustPolicy *trustpolicy.OCIDocument
+
+	// CollectRevocationEvidence retains the revocation results as in
+	// [VerifierVerifyOptions.CollectRevocationEvidence].
+	CollectRevocationEvidence bool
+
+	// RequireRevocationCheck fails verification when the revocation status
+	// cannot be determined as in
+	// [VerifierVerifyOptions.RequireRevocationCheck].
+	RequireRevocationCheck bool
+
+	// CertificateChainValidator inspects the signing certificate chain as in
+	// [VerifierVerifyOptions.CertificateChainValidator].
+	CertificateChainValidator func(chain []*x509.Certificate) error
+
+	// RevocationData supplies pre-fetched revocation data as in
+	// [VerifierVerifyOptions.RevocationData].
+	RevocationData RevocationDataProvider
+
+	// RevocationDataMaxAge is the maximum age of the revocation data
+	// supplied by RevocationData as in
+	// [VerifierVerifyOptions.RevocationDataMaxAge]. A negative value is
+	// rejected.
+	RevocationDataMaxAge time.Duration
+
+	// MaxSignatureAge is the maximum age of a signature, measured from the
+	// signing time of the signature envelope. A signature signed earlier
+	// fails verification with an [ErrorSignatureTooOld] even if it is
+	// otherwise valid, e.g. to require artifacts to be re-signed
+	// periodically.
+	// If zero, the age of signatures is not checked. A negative value is
+	// rejected.
+	MaxSignatureAge time.Duration
+
+	// PinnedCertificateFingerprint is the hex encoded SHA-256 fingerprint of
+	// the only signing certificate accepted, on top of the trusted
+	// identities of the trust policy. Colons between bytes, as printed by
+	// openssl, are allowed.
+	// A signature whose signing certificate has another fingerprint fails
+	// verification.
+	// If empty, any signing certificate trusted by the trust policy is
+	// accepted.
+	PinnedCertificateFingerprint string
+
+	// RequiredTargetAnnotations contains annotations that must be present in
+	// the target artifact descriptor of the signature payload, e.g.
+	// "org.opencontainers.image.version", to ensure that they are covered by
+	// the signature rather than added afterwards. Values are matched
+	// exactly, and an empty value only requires the annotation to be
+	// present.
+	RequiredTargetAnnotations map[string]string
+
+	// AllowUnsigned accepts an artifact with no signature associated when the
+	// verification level of the applicable trust policy does not enforce
+	// authenticity, e.g. 'audit', instead of failing with an
+	// [ErrorSignatureRetrievalFailed]. The verification then succeeds with a
+	// single outcome with the [SkipReasonUnsigned] skip reason.
+	// It applies to the manifests of VerifyPlatforms, and to the manifests
+	// verified by [VerifyIndex], as well.
+	AllowUnsigned bool
+
+	// VerifyPlatforms requires the manifests of the given platforms of the
+	// image index referenced by ArtifactReference to be signed as well, on
+	// top of the index itself. The signatures of each platform manifest are
+	// verified as the ones of the index, and the successful outcome of each
+	// platform is returned after the outcome of the index, with
+	// [VerificationOutcome.Platform] set.
+	// A platform matches a manifest of the index if the OS and the
+	// architecture are equal, as well as the variant and the OS version if
+	// they are set.
+	// If empty, only the signatures of the artifact itself are verified.
+	VerifyPlatforms []ocispec.Platform
+
+	// Clock provides the time of verification as in
+	// [VerifierVerifyOptions.Clock]. It is also the time against which
+	// MaxSignatureAge is checked.
+	Clock Clock
+
+	// PrefetchSignaturePages is the maximum number of pages of signature
+	// manifests listed ahead of the page being verified, so that listing the
+	// referrers of artifacts with many signatures overlaps with verification.
+	// Prefetched pages still count towards MaxSignatureAttempts only once
+	// their signatures are processed, and an error listing a prefetched page
+	// is returned once the signatures of the previous pages are processed.
+	// If zero, the next page is listed after the current page is processed.
+	// A negative value is rejected.
+	PrefetchSignaturePages int
+
+	// AggregateErrors combines the errors of the signatures that failed
+	// verification or were skipped, in the order they were processed, when
+	// no signature is verified successfully, e.g. to keep only the most
+	// relevant error or to deduplicate them. The returned error is joined
+	// with an [ErrorVerificationFailed].
+	// If nil, all the errors are joined with [errors.Join], so that every
+	// reason every signature failed can be inspected with errors.Is,
+	// errors.As or the Unwrap() []error method.
+	AggregateErrors func(errs []error) error
+
+	// SignatureRepository is the repository the signatures of the artifact
+	// are listed and fetched from, when they are stored apart from the
+	// artifact, e.g. in a registry centralizing signatures. The artifact
+	// descriptor is still resolved from the repository passed to Verify.
+	// The signatures must sign the artifact resolved from that repository:
+	// if SignatureRepository implements [registry.SignatureSubjectFetcher],
+	// a signature whose manifest has another subject is skipped.
+	// If nil, the signatures are listed from the repository of the artifact.
+	SignatureRepository registry.Repository
 }
 
 // VerifyBlobOptions contains parameters for [notation.VerifyBlob].
@@ -428,6 +1062,13 @@ func VerifyBlob(ctx context.Context, blobVerifier BlobVerifier, blobReader io.Re
 	if err := validateContentMediaType(verifyBlobOpts.ContentMediaType); err != nil {
 		return ocispec.Descriptor{}, nil, err
 	}
+	if verifyBlobOpts.SignatureMediaType == "" {
+		mediaType, err := DetectEnvelopeMediaType(signature)
+		if err != nil {
+			return ocispec.Descriptor{}, nil, err
+		}
+		verifyBlobOpts.SignatureMediaType = mediaType
+	}
 	if err := validateSigMediaType(verifyBlobOpts.SignatureMediaType); err != nil {
 		return ocispec.Descriptor{}, nil, err
 	}
@@ -444,74 +1085,342 @@ func VerifyBlob(ctx context.Context, blobVerifier BlobVerifier, blobReader io.Re
 	return desc, vo, nil
 }
 
+// ParseSignatureEnvelope decodes the signature envelope of type mediaType,
+// i.e. `application/jose+json` or `application/cose`, and returns its
+// content, including the payload and the signer information.
+//
+// It performs parsing only: neither the integrity of the envelope nor the
+// trust of the signing certificate chain is validated, so the returned
+// content must not be relied upon. It is suitable for read-only inspection of
+// untrusted signatures. Use [Verify] or [VerifySignature] to verify them.
+func ParseSignatureEnvelope(mediaType string, envelope []byte) (*signature.EnvelopeContent, error) {
+	if err := validateSigMediaType(mediaType); err != nil {
+		return nil, err
+	}
+	if len(envelope) == 0 {
+		return nil, errors.New("signature envelope cannot be nil or empty")
+	}
+	sigEnv, err := signature.ParseEnvelope(mediaType, envelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature envelope (no verification performed): %w", err)
+	}
+	content, err := sigEnv.Content()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature envelope content (no verification performed): %w", err)
+	}
+	return content, nil
+}
+
+// VerifySignatureOptions contains parameters for [notation.VerifySignature].
+type VerifySignatureOptions struct {
+	VerifierVerifyOptions
+}
+
+// VerifySignature performs signature verification of a caller-provided
+// signature envelope against the artifact described by artifactDesc, without
+// fetching anything from a registry. It returns the successful signature
+// verification outcome.
+// If the applicable verification level is 'skip', the returned outcome only
+// contains the verification level.
+// For more details on signature verification, see
+// https://github.com/notaryproject/notaryproject/blob/main/specs/trust-store-trust-policy.md#signature-verification
+func VerifySignature(ctx context.Context, verifier Verifier, artifactDesc ocispec.Descriptor, envelope []byte, verifyOpts VerifySignatureOptions) (*VerificationOutcome, error) {
+	logger := log.GetLogger(ctx)
+
+	// sanity check
+	if verifier == nil {
+		return nil, errors.New("verifier cannot be nil")
+	}
+	if err := artifactDesc.Digest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid artifact descriptor: %w", err)
+	}
+	if len(envelope) == 0 {
+		return nil, errors.New("signature envelope cannot be nil or empty")
+	}
+	if err := validateSigMediaType(verifyOpts.SignatureMediaType); err != nil {
+		return nil, err
+	}
+
+	if skipChecker, ok := verifier.(verifySkipper); ok {
+		logger.Info("Checking whether signature verification should be skipped or not")
+		skip, verificationLevel, err := skipChecker.SkipVerify(ctx, verifyOpts.VerifierVerifyOptions)
+		if err != nil {
+			return nil, err
+		}
+		if skip {
+			logger.Infoln("Signature verification skipped for", verifyOpts.ArtifactReference)
+			return &VerificationOutcome{VerificationLevel: verificationLevel, SkipReason: SkipReasonVerificationLevel}, nil
+		}
+		logger.Info("Check over. The signature verification level is not set to 'skip' in the trust policy.")
+	}
+
+	verifyStart := time.Now()
+	outcome, err := verifier.Verify(ctx, artifactDesc, envelope, verifyOpts.VerifierVerifyOptions)
+	metrics.ObserveSince(ctx, metrics.StageVerifySignature, verifyStart)
+	if err != nil {
+		return outcome, errors.Join(ErrorVerificationFailed{}, err)
+	}
+	logger.Debugf("Signature verification succeeded for artifact %v", artifactDesc.Digest)
+	return outcome, nil
+}
+
 // Verify performs signature verification on each of the notation supported
 // verification types (like integrity, authenticity, etc.) and returns the
 // successful signature verification outcome.
+// If the applicable verification level is 'skip', an empty descriptor and a
+// single outcome reporting [VerificationOutcome.Skipped] are returned.
+// If no signature is associated with the artifact, an
+// [ErrorSignatureRetrievalFailed] is returned, unless
+// [VerifyOptions.AllowUnsigned] is set and the applicable verification level
+// does not enforce authenticity, e.g. 'audit', in which case the artifact
+// descriptor and a single outcome with the [SkipReasonUnsigned] skip reason
+// are returned.
 // For more details on signature verification, see
 // https://github.com/notaryproject/notaryproject/blob/main/specs/trust-store-trust-policy.md#signature-verification
 func Verify(ctx context.Context, verifier Verifier, repo registry.Repository, verifyOpts VerifyOptions) (ocispec.Descriptor, []*VerificationOutcome, error) {
 	logger := log.GetLogger(ctx)
 
+	params, err := prepareVerify(ctx, verifier, repo, verifyOpts)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	if params.skipped {
+		return ocispec.Descriptor{}, []*VerificationOutcome{{VerificationLevel: params.verificationLevel, SkipReason: SkipReasonVerificationLevel}}, nil
+	}
+	opts, verificationLevel, pinnedFingerprint := params.opts, params.verificationLevel, params.pinnedFingerprint
+
+	// get artifact descriptor
+	artifactRef := verifyOpts.ArtifactReference
+	artifactDescriptor, err := resolveArtifactDescriptor(ctx, repo, artifactRef, verifyOpts.RequireDigestReference)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+
+	verificationOutcomes, err := verifySignatures(ctx, verifier, repo, artifactRef, artifactDescriptor, verifyOpts, opts, verificationLevel, pinnedFingerprint)
+	if err != nil {
+		return ocispec.Descriptor{}, verificationOutcomes, err
+	}
+	if len(verifyOpts.VerifyPlatforms) == 0 {
+		return artifactDescriptor, verificationOutcomes, nil
+	}
+
+	// verify the platform manifests of the image index
+	platformDescriptors, err := resolvePlatformDescriptors(ctx, repo, artifactDescriptor, verifyOpts.VerifyPlatforms)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	for _, platformDesc := range platformDescriptors {
+		platform := platformString(*platformDesc.Platform)
+		logger.Infof("Verifying signatures of platform %s manifest %v", platform, platformDesc.Digest)
+		platformOutcomes, err := verifySignatures(ctx, verifier, repo, platformDesc.Digest.String(), platformDesc, verifyOpts, opts, verificationLevel, pinnedFingerprint)
+		for _, outcome := range platformOutcomes {
+			outcome.Platform = platformDesc.Platform
+		}
+		verificationOutcomes = append(verificationOutcomes, platformOutcomes...)
+		if err != nil {
+			return ocispec.Descriptor{}, verificationOutcomes, fmt.Errorf("failed to verify platform %s manifest %v: %w", platform, platformDesc.Digest, err)
+		}
+	}
+	return artifactDescriptor, verificationOutcomes, nil
+}
+
+// VerifyIndex verifies the signatures of the image index referenced by
+// indexRef, and the signatures of every manifest referenced by the image
+// index, e.g. for deployment gates requiring every image of a bundle to be
+// signed. The outcomes are returned keyed by the digest of the image index and
+// of each referenced manifest. The manifests are verified against the trust
+// policy applicable to indexRef.
+// verifyOpts.ArtifactReference and verifyOpts.VerifyPlatforms are ignored.
+// If the applicable verification level is 'skip', each digest gets a single
+// outcome reporting [VerificationOutcome.Skipped].
+// Verification stops at the first image index or manifest failing
+// verification, and the outcomes collected so far are returned with the
+// error.
+func VerifyIndex(ctx context.Context, verifier Verifier, repo registry.Repository, indexRef string, verifyOpts VerifyOptions) (map[digest.Digest][]*VerificationOutcome, error) {
+	logger := log.GetLogger(ctx)
+
+	verifyOpts.ArtifactReference = indexRef
+	verifyOpts.VerifyPlatforms = nil
+	params, err := prepareVerify(ctx, verifier, repo, verifyOpts)
+	if err != nil {
+		return nil, err
+	}
+	indexFetcher, ok := repo.(registry.IndexFetcher)
+	if !ok {
+		return nil, errors.New("VerifyIndex requires a repository that supports fetching image indexes")
+	}
+	verify := func(artifactRef string, desc ocispec.Descriptor) ([]*VerificationOutcome, error) {
+		if params.skipped {
+			return []*VerificationOutcome{{VerificationLevel: params.verificationLevel, SkipReason: SkipReasonVerificationLevel}}, nil
+		}
+		return verifySignatures(ctx, verifier, repo, artifactRef, desc, verifyOpts, params.opts, params.verificationLevel, params.pinnedFingerprint)
+	}
+
+	indexDesc, err := resolveArtifactDescriptor(ctx, repo, indexRef, verifyOpts.RequireDigestReference)
+	if err != nil {
+		return nil, err
+	}
+	index, err := indexFetcher.FetchIndex(ctx, indexDesc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch image index %v: %w", indexDesc.Digest, err)
+	}
+	results := make(map[digest.Digest][]*VerificationOutcome, len(index.Manifests)+1)
+	outcomes, err := verify(indexRef, indexDesc)
+	results[indexDesc.Digest] = outcomes
+	if err != nil {
+		return results, err
+	}
+	for _, manifestDesc := range index.Manifests {
+		if _, ok := results[manifestDesc.Digest]; ok {
+			// the manifest is referenced more than once
+			continue
+		}
+		logger.Infof("Verifying signatures of manifest %v of image index %v", manifestDesc.Digest, indexDesc.Digest)
+		outcomes, err := verify(manifestDesc.Digest.String(), manifestDesc)
+		results[manifestDesc.Digest] = outcomes
+		if err != nil {
+			return results, fmt.Errorf("failed to verify manifest %v of image index %v: %w", manifestDesc.Digest, indexDesc.Digest, err)
+		}
+	}
+	return results, nil
+}
+
+// verifyParams holds the parameters of a verification derived from
+// VerifyOptions.
+type verifyParams struct {
+	// opts is passed to the verifier
+	opts VerifierVerifyOptions
+
+	// verificationLevel is the verification level reported by the verifier,
+	// if it implements verifySkipper
+	verificationLevel *trustpolicy.VerificationLevel
+
+	// pinnedFingerprint is the parsed
+	// VerifyOptions.PinnedCertificateFingerprint
+	pinnedFingerprint string
+
+	// skipped is true if the verification level is 'skip'
+	skipped bool
+}
+
+// prepareVerify validates verifyOpts and checks whether the verification is
+// skipped.
+func prepareVerify(ctx context.Context, verifier Verifier, repo registry.Repository, verifyOpts VerifyOptions) (verifyParams, error) {
+	logger := log.GetLogger(ctx)
+
 	// sanity check
 	if verifier == nil {
-		return ocispec.Descriptor{}, nil, errors.New("verifier cannot be nil")
+		return verifyParams{}, errors.New("verifier cannot be nil")
 	}
 	if repo == nil {
-		return ocispec.Descriptor{}, nil, errors.New("repo cannot be nil")
+		return verifyParams{}, errors.New("repo cannot be nil")
 	}
 	if verifyOpts.MaxSignatureAttempts <= 0 {
-		return ocispec.Descriptor{}, nil, ErrorSignatureRetrievalFailed{Msg: fmt.Sprintf("verifyOptions.MaxSignatureAttempts expects a positive number, got %d", verifyOpts.MaxSignatureAttempts)}
+		return verifyParams{}, ErrorSignatureRetrievalFailed{Msg: fmt.Sprintf("verifyOptions.MaxSignatureAttempts expects a positive number, got %d", verifyOpts.MaxSignatureAttempts)}
+	}
+	for _, mediaType := range verifyOpts.AcceptedSignatureMediaTypes {
+		if err := validateSigMediaType(mediaType); err != nil {
+			return verifyParams{}, fmt.Errorf("verifyOptions.AcceptedSignatureMediaTypes contains %w", err)
+		}
+	}
+	if verifyOpts.PrefetchSignaturePages < 0 {
+		return verifyParams{}, fmt.Errorf("verifyOptions.PrefetchSignaturePages expects a non-negative number, got %d", verifyOpts.PrefetchSignaturePages)
+	}
+	if verifyOpts.MaxSignatureAge < 0 {
+		return verifyParams{}, fmt.Errorf("verifyOptions.MaxSignatureAge expects a non-negative duration, got %v", verifyOpts.MaxSignatureAge)
+	}
+	if verifyOpts.RevocationDataMaxAge < 0 {
+		return verifyParams{}, fmt.Errorf("verifyOptions.RevocationDataMaxAge expects a non-negative duration, got %v", verifyOpts.RevocationDataMaxAge)
+	}
+	pinnedFingerprint, err := parseCertificateFingerprint(verifyOpts.PinnedCertificateFingerprint)
+	if err != nil {
+		return verifyParams{}, err
+	}
+	for _, platform := range verifyOpts.VerifyPlatforms {
+		if platform.OS == "" || platform.Architecture == "" {
+			return verifyParams{}, fmt.Errorf("verifyOptions.VerifyPlatforms expects platforms with both OS and architecture, got %q", platformString(platform))
+		}
 	}
 
 	// opts to be passed in verifier.Verify()
-	opts := VerifierVerifyOptions{
-		ArtifactReference: verifyOpts.ArtifactReference,
-		PluginConfig:      verifyOpts.PluginConfig,
-		UserMetadata:      verifyOpts.UserMetadata,
+	params := verifyParams{pinnedFingerprint: pinnedFingerprint}
+	params.opts = VerifierVerifyOptions{
+		ArtifactReference:         verifyOpts.ArtifactReference,
+		PluginConfig:              verifyOpts.PluginConfig,
+		UserMetadata:              verifyOpts.UserMetadata,
+		OCITrustPolicy:            verifyOpts.OCITrustPolicy,
+		CollectRevocationEvidence: verifyOpts.CollectRevocationEvidence,
+		RequireRevocationCheck:    verifyOpts.RequireRevocationCheck,
+		CertificateChainValidator: verifyOpts.CertificateChainValidator,
+		RevocationData:            verifyOpts.RevocationData,
+		RevocationDataMaxAge:      verifyOpts.RevocationDataMaxAge,
+		Clock:                     verifyOpts.Clock,
 	}
 	if skipChecker, ok := verifier.(verifySkipper); ok {
 		logger.Info("Checking whether signature verification should be skipped or not")
-		skip, verificationLevel, err := skipChecker.SkipVerify(ctx, opts)
+		skip, verificationLevel, err := skipChecker.SkipVerify(ctx, params.opts)
 		if err != nil {
-			return ocispec.Descriptor{}, nil, err
+			return verifyParams{}, err
 		}
+		params.verificationLevel = verificationLevel
 		if skip {
 			logger.Infoln("Signature verification skipped for", verifyOpts.ArtifactReference)
-			return ocispec.Descriptor{}, []*VerificationOutcome{{VerificationLevel: verificationLevel}}, nil
+			params.skipped = true
+			return params, nil
 		}
 		logger.Info("Check over. The signature verification level is not set to 'skip' in the trust policy.")
 	}
+	return params, nil
+}
 
-	// get artifact descriptor
-	artifactRef := verifyOpts.ArtifactReference
-	ref, err := orasRegistry.ParseReference(artifactRef)
-	if err != nil {
-		return ocispec.Descriptor{}, nil, ErrorSignatureRetrievalFailed{Msg: err.Error()}
-	}
-	if ref.Reference == "" {
-		return ocispec.Descriptor{}, nil, ErrorSignatureRetrievalFailed{Msg: "reference is missing digest or tag"}
+// verifySignatureSubject verifies that the signature manifest described by
+// sigManifestDesc has artifactDescriptor as subject, if sigRepo can fetch the
+// subjects of signature manifests.
+func verifySignatureSubject(ctx context.Context, sigRepo registry.Repository, sigManifestDesc, artifactDescriptor ocispec.Descriptor) error {
+	fetcher, ok := sigRepo.(registry.SignatureSubjectFetcher)
+	if !ok {
+		return nil
 	}
-	artifactDescriptor, err := repo.Resolve(ctx, ref.Reference)
+	subject, err := fetcher.SignatureSubject(ctx, sigManifestDesc)
 	if err != nil {
-		return ocispec.Descriptor{}, nil, ErrorSignatureRetrievalFailed{Msg: err.Error()}
+		return err
 	}
-	if ref.ValidateReferenceAsDigest() != nil {
-		// artifactRef is not a digest reference
-		logger.Infof("Resolved artifact tag `%s` to digest `%v` before verification", ref.Reference, artifactDescriptor.Digest)
-		logger.Warn("The resolved digest may not point to the same signed artifact, since tags are mutable")
-	} else if ref.Reference != artifactDescriptor.Digest.String() {
-		return ocispec.Descriptor{}, nil, ErrorSignatureRetrievalFailed{Msg: fmt.Sprintf("user input digest %s does not match the resolved digest %s", ref.Reference, artifactDescriptor.Digest.String())}
+	if subject.Digest != artifactDescriptor.Digest {
+		return ErrorTargetArtifactMismatch{Msg: fmt.Sprintf("signature manifest subject %v does not match the artifact %v", subject.Digest, artifactDescriptor.Digest)}
 	}
+	return nil
+}
+
+// verifySignatures verifies the signatures of the artifact described by
+// artifactDescriptor until one of them is verified successfully, and returns
+// the successful signature verification outcome.
+// verificationLevel is the verification level of the applicable trust policy,
+// if known. It decides whether an unsigned artifact is accepted by
+// verifyOpts.AllowUnsigned.
+func verifySignatures(ctx context.Context, verifier Verifier, repo registry.Repository, artifactRef string, artifactDescriptor ocispec.Descriptor, verifyOpts VerifyOptions, opts VerifierVerifyOptions, verificationLevel *trustpolicy.VerificationLevel, pinnedFingerprint string) ([]*VerificationOutcome, error) {
+	logger := log.GetLogger(ctx)
 
 	var verificationSucceeded bool
 	var verificationOutcomes []*VerificationOutcome
-	var verificationFailedErrorArray = []error{ErrorVerificationFailed{}}
+	var verificationFailedErrorArray []error
 	errExceededMaxVerificationLimit := ErrorVerificationFailed{Msg: fmt.Sprintf("signature evaluation stopped. The configured limit of %d signatures to verify per artifact exceeded", verifyOpts.MaxSignatureAttempts)}
 	numOfSignatureProcessed := 0
+	numOfSignatureDiscovered := 0
+	reportProgress := func(sigManifestDesc ocispec.Descriptor) {
+		if verifyOpts.ProgressFunc != nil {
+			verifyOpts.ProgressFunc(numOfSignatureProcessed, min(numOfSignatureDiscovered, verifyOpts.MaxSignatureAttempts), sigManifestDesc)
+		}
+	}
+
+	sigRepo := repo
+	if verifyOpts.SignatureRepository != nil {
+		sigRepo = verifyOpts.SignatureRepository
+	}
 
 	// get signature manifests
 	logger.Debug("Fetching signature manifests")
-	err = repo.ListSignatures(ctx, artifactDescriptor, func(signatureManifests []ocispec.Descriptor) error {
+	err := listSignatures(ctx, sigRepo, artifactDescriptor, verifyOpts.PrefetchSignaturePages, func(signatureManifests []ocispec.Descriptor) error {
+		numOfSignatureDiscovered += len(signatureManifests)
 		// process signatures
 		for _, sigManifestDesc := range signatureManifests {
 			if numOfSignatureProcessed >= verifyOpts.MaxSignatureAttempts {
@@ -520,16 +1429,51 @@ func Verify(ctx context.Context, verifier Verifier, repo registry.Repository, ve
 			numOfSignatureProcessed++
 			logger.Infof("Processing signature with manifest mediaType: %v and digest: %v", sigManifestDesc.MediaType, sigManifestDesc.Digest)
 			// get signature envelope
-			sigBlob, sigDesc, err := repo.FetchSignatureBlob(ctx, sigManifestDesc)
+			if verifyOpts.SignatureRepository != nil {
+				if err := verifySignatureSubject(ctx, sigRepo, sigManifestDesc, artifactDescriptor); err != nil {
+					logger.Warnf("Skipping signature %v: %v", sigManifestDesc.Digest, err)
+					verificationFailedErrorArray = append(verificationFailedErrorArray, fmt.Errorf("signature with digest %v was skipped, %w", sigManifestDesc.Digest, err))
+					reportProgress(sigManifestDesc)
+					continue
+				}
+			}
+			fetchStart := time.Now()
+			sigBlob, sigDesc, err := sigRepo.FetchSignatureBlob(ctx, sigManifestDesc)
+			metrics.ObserveSince(ctx, metrics.StageFetchSignature, fetchStart)
 			if err != nil {
+				var malformedErr registry.ErrorMalformedSignatureManifest
+				if errors.As(err, &malformedErr) {
+					// a corrupt signature must not prevent the other
+					// signatures from being verified
+					logger.Warnf("Skipping signature %v: %v", sigManifestDesc.Digest, err)
+					verificationFailedErrorArray = append(verificationFailedErrorArray, fmt.Errorf("signature with digest %v was skipped, %w", sigManifestDesc.Digest, err))
+					reportProgress(sigManifestDesc)
+					continue
+				}
 				return ErrorSignatureRetrievalFailed{Msg: fmt.Sprintf("unable to retrieve digital signature with digest %q associated with %q from the Repository, error : %v", sigManifestDesc.Digest, artifactRef, err.Error())}
 			}
 
+			if err := verifySignatureMediaType(sigDesc.MediaType, sigBlob); err != nil {
+				logger.Warnf("Skipping signature %v: %v", sigManifestDesc.Digest, err)
+				verificationFailedErrorArray = append(verificationFailedErrorArray, fmt.Errorf("signature with digest %v was skipped, %w", sigManifestDesc.Digest, err))
+				reportProgress(sigManifestDesc)
+				continue
+			}
+
+			if len(verifyOpts.AcceptedSignatureMediaTypes) > 0 && !slices.Contains(verifyOpts.AcceptedSignatureMediaTypes, sigDesc.MediaType) {
+				logger.Infof("Skipping signature %v, signature media type %q is not accepted", sigManifestDesc.Digest, sigDesc.MediaType)
+				verificationFailedErrorArray = append(verificationFailedErrorArray, fmt.Errorf("signature with digest %v was skipped, signature media type %q is not accepted", sigManifestDesc.Digest, sigDesc.MediaType))
+				reportProgress(sigManifestDesc)
+				continue
+			}
+
 			// using signature media type fetched from registry
 			opts.SignatureMediaType = sigDesc.MediaType
 
 			// verify each signature
+			verifyStart := time.Now()
 			outcome, err := verifier.Verify(ctx, artifactDescriptor, sigBlob, opts)
+			metrics.ObserveSince(ctx, metrics.StageVerifySignature, verifyStart)
 			if err != nil {
 				logger.Warnf("Signature %v failed verification with error: %v", sigManifestDesc.Digest, err)
 				if outcome == nil {
@@ -538,15 +1482,43 @@ func Verify(ctx context.Context, verifier Verifier, repo registry.Repository, ve
 				}
 				outcome.Error = fmt.Errorf("failed to verify signature with digest %v, %w", sigManifestDesc.Digest, outcome.Error)
 				verificationFailedErrorArray = append(verificationFailedErrorArray, outcome.Error)
+				reportProgress(sigManifestDesc)
+				continue
+			}
+			err = verifySignatureAge(outcome, verifyOpts.MaxSignatureAge, verifyOpts.Clock)
+			if err == nil {
+				err = verifyPinnedCertificate(outcome, pinnedFingerprint)
+			}
+			if err == nil {
+				err = verifyRequiredTargetAnnotations(outcome, verifyOpts.RequiredTargetAnnotations)
+			}
+			if err != nil {
+				logger.Warnf("Signature %v failed verification with error: %v", sigManifestDesc.Digest, err)
+				outcome.Error = fmt.Errorf("failed to verify signature with digest %v, %w", sigManifestDesc.Digest, err)
+				verificationFailedErrorArray = append(verificationFailedErrorArray, outcome.Error)
+				reportProgress(sigManifestDesc)
 				continue
 			}
 			// at this point, the signature is verified successfully
 			verificationSucceeded = true
+			if previous, ok := sigManifestDesc.Annotations[AnnotationPreviousSignature]; ok {
+				if previousDigest, err := digest.Parse(previous); err == nil {
+					outcome.PreviousSignature = previousDigest
+				} else {
+					logger.Warnf("Signature manifest %v has invalid %s annotation: %v", sigManifestDesc.Digest, AnnotationPreviousSignature, err)
+				}
+			}
+			if outcome.EnvelopeContent != nil {
+				if _, err := envelope.VerifyX509ChainThumbprints(sigManifestDesc.Annotations, outcome.EnvelopeContent.SignerInfo.CertificateChain); err != nil {
+					logger.Warnf("Signature manifest %v has inconsistent annotations: %v", sigManifestDesc.Digest, err)
+				}
+			}
 
 			// on success, verificationOutcomes only contains the
 			// succeeded outcome
 			verificationOutcomes = []*VerificationOutcome{outcome}
 			logger.Debugf("Signature verification succeeded for artifact %v with signature digest %v", artifactDescriptor.Digest, sigManifestDesc.Digest)
+			reportProgress(sigManifestDesc)
 
 			// early break on success
 			return errDoneVerification
@@ -558,24 +1530,403 @@ func Verify(ctx context.Context, verifier Verifier, repo registry.Repository, ve
 	})
 	if err != nil && !errors.Is(err, errDoneVerification) {
 		if errors.Is(err, errExceededMaxVerificationLimit) {
-			return ocispec.Descriptor{}, verificationOutcomes, err
+			return verificationOutcomes, err
 		}
-		return ocispec.Descriptor{}, nil, err
+		return nil, err
 	}
 
 	// If there's no signature associated with the reference
 	if numOfSignatureProcessed == 0 {
-		return ocispec.Descriptor{}, nil, ErrorSignatureRetrievalFailed{Msg: fmt.Sprintf("no signature is associated with %q, make sure the artifact was signed successfully", artifactRef)}
+		if verifyOpts.AllowUnsigned && verificationLevel != nil && verificationLevel.Enforcement[trustpolicy.TypeAuthenticity] != trustpolicy.ActionEnforce {
+			logger.Infof("No signature is associated with %q, accepted by verification level %q", artifactRef, verificationLevel.Name)
+			return []*VerificationOutcome{{VerificationLevel: verificationLevel, SkipReason: SkipReasonUnsigned}}, nil
+		}
+		return nil, ErrorSignatureRetrievalFailed{Msg: fmt.Sprintf("no signature is associated with %q, make sure the artifact was signed successfully", artifactRef)}
 	}
 
 	// Verification Failed
 	if !verificationSucceeded {
 		logger.Debugf("Signature verification failed for all the signatures associated with artifact %v", artifactDescriptor.Digest)
-		return ocispec.Descriptor{}, verificationOutcomes, errors.Join(verificationFailedErrorArray...)
+		if verifyOpts.AggregateErrors != nil {
+			return verificationOutcomes, errors.Join(ErrorVerificationFailed{}, verifyOpts.AggregateErrors(verificationFailedErrorArray))
+		}
+		return verificationOutcomes, errors.Join(append([]error{ErrorVerificationFailed{}}, verificationFailedErrorArray...)...)
 	}
 
 	// Verification Succeeded
-	return artifactDescriptor, verificationOutcomes, nil
+	return verificationOutcomes, nil
+}
+
+// listSignatures lists the signature manifests of the artifact described by
+// desc as repo.ListSignatures does. If prefetch is positive, the pages are
+// listed in a separate goroutine, up to prefetch pages ahead of the page
+// being processed by fn, while fn is still invoked from the calling
+// goroutine.
+func listSignatures(ctx context.Context, repo registry.Repository, desc ocispec.Descriptor, prefetch int, fn func(signatureManifests []ocispec.Descriptor) error) error {
+	if prefetch <= 0 {
+		return repo.ListSignatures(ctx, desc, fn)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	pages := make(chan []ocispec.Descriptor, prefetch)
+	listErr := make(chan error, 1)
+	go func() {
+		defer close(pages)
+		listErr <- repo.ListSignatures(ctx, desc, func(signatureManifests []ocispec.Descriptor) error {
+			// the page may be reused by the repository once fn returns
+			page := append([]ocispec.Descriptor(nil), signatureManifests...)
+			select {
+			case pages <- page:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	for page := range pages {
+		if err := fn(page); err != nil {
+			// stop listing and wait for the lister to return
+			cancel()
+			for range pages {
+			}
+			return err
+		}
+	}
+	return <-listErr
+}
+
+// resolvePlatformDescriptors returns the manifest descriptors of the image
+// index described by indexDesc that match the given platforms.
+func resolvePlatformDescriptors(ctx context.Context, repo registry.Repository, indexDesc ocispec.Descriptor, platforms []ocispec.Platform) ([]ocispec.Descriptor, error) {
+	indexFetcher, ok := repo.(registry.IndexFetcher)
+	if !ok {
+		return nil, errors.New("verifyOptions.VerifyPlatforms requires a repository that supports fetching image indexes")
+	}
+	index, err := indexFetcher.FetchIndex(ctx, indexDesc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch image index %v: %w", indexDesc.Digest, err)
+	}
+	var platformDescriptors []ocispec.Descriptor
+	for _, platform := range platforms {
+		found := false
+		for _, desc := range index.Manifests {
+			if desc.Platform != nil && matchPlatform(*desc.Platform, platform) {
+				platformDescriptors = append(platformDescriptors, desc)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("platform %s is not found in image index %v", platformString(platform), indexDesc.Digest)
+		}
+	}
+	return platformDescriptors, nil
+}
+
+// matchPlatform reports whether got matches the wanted platform. The variant
+// and the OS version are only compared if they are set in want.
+func matchPlatform(got, want ocispec.Platform) bool {
+	return got.OS == want.OS &&
+		got.Architecture == want.Architecture &&
+		(want.Variant == "" || got.Variant == want.Variant) &&
+		(want.OSVersion == "" || got.OSVersion == want.OSVersion)
+}
+
+// platformString returns the platform in the os/arch[/variant] format.
+func platformString(platform ocispec.Platform) string {
+	s := platform.OS + "/" + platform.Architecture
+	if platform.Variant != "" {
+		s += "/" + platform.Variant
+	}
+	return s
+}
+
+// VerifySpecificSignatureOptions contains parameters for
+// [notation.VerifySpecificSignature].
+type VerifySpecificSignatureOptions struct {
+	// PluginConfig is a map of plugin configs.
+	PluginConfig map[string]string
+
+	// UserMetadata contains key-value pairs that must be present in the
+	// signature. Values are matched as in
+	// [VerifierVerifyOptions.UserMetadata].
+	UserMetadata map[string]string
+
+	// RequireDigestReference makes VerifySpecificSignature fail instead of
+	// resolving artifactRef when it is a tag, since tags are mutable.
+	RequireDigestReference bool
+
+	// OCITrustPolicy overrides the trust policy document of the verifier as
+	// in [VerifierVerifyOptions.OCITrustPolicy].
+	OCITrustPolicy *trustpolicy.OCIDocument
+
+	// CollectRevocationEvidence retains the revocation results as in
+	// [VerifierVerifyOptions.CollectRevocationEvidence].
+	CollectRevocationEvidence bool
+
+	// RequireRevocationCheck fails verification when the revocation status
+	// cannot be determined as in
+	// [VerifierVerifyOptions.RequireRevocationCheck].
+	RequireRevocationCheck bool
+
+	// CertificateChainValidator inspects the signing certificate chain as in
+	// [VerifierVerifyOptions.CertificateChainValidator].
+	CertificateChainValidator func(chain []*x509.Certificate) error
+
+	// RevocationData supplies pre-fetched revocation data as in
+	// [VerifierVerifyOptions.RevocationData].
+	RevocationData RevocationDataProvider
+
+	// RevocationDataMaxAge is the maximum age of the revocation data
+	// supplied by RevocationData as in
+	// [VerifierVerifyOptions.RevocationDataMaxAge]. A negative value is
+	// rejected.
+	RevocationDataMaxAge time.Duration
+
+	// MaxSignatureAge is the maximum age of the signature as in
+	// [VerifyOptions.MaxSignatureAge].
+	MaxSignatureAge time.Duration
+
+	// PinnedCertificateFingerprint is the fingerprint of the only signing
+	// certificate accepted as in [VerifyOptions.PinnedCertificateFingerprint].
+	PinnedCertificateFingerprint string
+
+	// RequiredTargetAnnotations contains annotations that must be present in
+	// the signed target artifact as in
+	// [VerifyOptions.RequiredTargetAnnotations].
+	RequiredTargetAnnotations map[string]string
+
+	// Clock provides the time of verification as in [VerifyOptions.Clock].
+	Clock Clock
+}
+
+// VerifySpecificSignature verifies the single signature whose manifest digest
+// is signatureDigest against the artifact referenced by artifactRef, and
+// returns the successful signature verification outcome.
+// Unlike [Verify], the other signatures of the artifact are not evaluated.
+// An [ErrorSignatureRetrievalFailed] is returned if signatureDigest is not a
+// signature of the artifact.
+// If the applicable verification level is 'skip', the returned outcome only
+// contains the verification level.
+func VerifySpecificSignature(ctx context.Context, verifier Verifier, repo registry.Repository, artifactRef string, signatureDigest digest.Digest, verifyOpts VerifySpecificSignatureOptions) (*VerificationOutcome, error) {
+	logger := log.GetLogger(ctx)
+
+	// sanity check
+	if verifier == nil {
+		return nil, errors.New("verifier cannot be nil")
+	}
+	if repo == nil {
+		return nil, errors.New("repo cannot be nil")
+	}
+	if err := signatureDigest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid signature digest: %w", err)
+	}
+	if verifyOpts.MaxSignatureAge < 0 {
+		return nil, fmt.Errorf("verifyOptions.MaxSignatureAge expects a non-negative duration, got %v", verifyOpts.MaxSignatureAge)
+	}
+	if verifyOpts.RevocationDataMaxAge < 0 {
+		return nil, fmt.Errorf("verifyOptions.RevocationDataMaxAge expects a non-negative duration, got %v", verifyOpts.RevocationDataMaxAge)
+	}
+	pinnedFingerprint, err := parseCertificateFingerprint(verifyOpts.PinnedCertificateFingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	// opts to be passed in verifier.Verify()
+	opts := VerifierVerifyOptions{
+		ArtifactReference:         artifactRef,
+		PluginConfig:              verifyOpts.PluginConfig,
+		UserMetadata:              verifyOpts.UserMetadata,
+		OCITrustPolicy:            verifyOpts.OCITrustPolicy,
+		CollectRevocationEvidence: verifyOpts.CollectRevocationEvidence,
+		RequireRevocationCheck:    verifyOpts.RequireRevocationCheck,
+		CertificateChainValidator: verifyOpts.CertificateChainValidator,
+		RevocationData:            verifyOpts.RevocationData,
+		RevocationDataMaxAge:      verifyOpts.RevocationDataMaxAge,
+		Clock:                     verifyOpts.Clock,
+	}
+	if skipChecker, ok := verifier.(verifySkipper); ok {
+		logger.Info("Checking whether signature verification should be skipped or not")
+		skip, verificationLevel, err := skipChecker.SkipVerify(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		if skip {
+			logger.Infoln("Signature verification skipped for", artifactRef)
+			return &VerificationOutcome{VerificationLevel: verificationLevel, SkipReason: SkipReasonVerificationLevel}, nil
+		}
+		logger.Info("Check over. The signature verification level is not set to 'skip' in the trust policy.")
+	}
+
+	artifactDescriptor, err := resolveArtifactDescriptor(ctx, repo, artifactRef, verifyOpts.RequireDigestReference)
+	if err != nil {
+		return nil, err
+	}
+
+	// find the signature manifest among the signatures of the artifact
+	logger.Debugf("Looking up signature manifest %v", signatureDigest)
+	var sigManifestDesc ocispec.Descriptor
+	var found bool
+	err = repo.ListSignatures(ctx, artifactDescriptor, func(signatureManifests []ocispec.Descriptor) error {
+		for _, desc := range signatureManifests {
+			if desc.Digest == signatureDigest {
+				sigManifestDesc = desc
+				found = true
+				return errDoneVerification
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDoneVerification) {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrorSignatureRetrievalFailed{Msg: fmt.Sprintf("signature with digest %q is not associated with %q", signatureDigest, artifactRef)}
+	}
+
+	fetchStart := time.Now()
+	sigBlob, sigDesc, err := repo.FetchSignatureBlob(ctx, sigManifestDesc)
+	metrics.ObserveSince(ctx, metrics.StageFetchSignature, fetchStart)
+	if err != nil {
+		return nil, ErrorSignatureRetrievalFailed{Msg: fmt.Sprintf("unable to retrieve digital signature with digest %q associated with %q from the Repository, error : %v", signatureDigest, artifactRef, err.Error())}
+	}
+
+	if err := verifySignatureMediaType(sigDesc.MediaType, sigBlob); err != nil {
+		return nil, errors.Join(ErrorVerificationFailed{}, fmt.Errorf("failed to verify signature with digest %v, %w", signatureDigest, err))
+	}
+
+	// using signature media type fetched from registry
+	opts.SignatureMediaType = sigDesc.MediaType
+	verifyStart := time.Now()
+	outcome, err := verifier.Verify(ctx, artifactDescriptor, sigBlob, opts)
+	metrics.ObserveSince(ctx, metrics.StageVerifySignature, verifyStart)
+	if err == nil {
+		err = verifySignatureAge(outcome, verifyOpts.MaxSignatureAge, verifyOpts.Clock)
+	}
+	if err == nil {
+		err = verifyPinnedCertificate(outcome, pinnedFingerprint)
+	}
+	if err == nil {
+		err = verifyRequiredTargetAnnotations(outcome, verifyOpts.RequiredTargetAnnotations)
+	}
+	if err != nil {
+		return outcome, errors.Join(ErrorVerificationFailed{}, fmt.Errorf("failed to verify signature with digest %v, %w", signatureDigest, err))
+	}
+	logger.Debugf("Signature verification succeeded for artifact %v with signature digest %v", artifactDescriptor.Digest, signatureDigest)
+	return outcome, nil
+}
+
+// verifySignatureAge returns an [ErrorSignatureTooOld] if the signature of
+// outcome was signed more than maxAge before the current time of clk. The
+// age is not checked if maxAge is zero or if the verification has been
+// skipped.
+func verifySignatureAge(outcome *VerificationOutcome, maxAge time.Duration, clk Clock) error {
+	if maxAge <= 0 || outcome.Skipped() {
+		return nil
+	}
+	signingTime, err := outcome.SigningTime()
+	if err != nil {
+		return fmt.Errorf("failed to check the age of the signature: %w", err)
+	}
+	if age := clock.Now(clk).Sub(signingTime); age > maxAge {
+		return ErrorSignatureTooOld{SigningTime: signingTime, MaxAge: maxAge}
+	}
+	return nil
+}
+
+// verifyRequiredTargetAnnotations returns an error if the target artifact
+// descriptor in the signature payload of outcome lacks one of the required
+// annotations, or has a different value for a required annotation with a
+// non-empty value. The annotations are not checked if the verification has
+// been skipped.
+func verifyRequiredTargetAnnotations(outcome *VerificationOutcome, required map[string]string) error {
+	if len(required) == 0 || outcome.Skipped() {
+		return nil
+	}
+	annotations, err := outcome.UserMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to check the annotations of the signed target artifact: %w", err)
+	}
+	keys := make([]string, 0, len(required))
+	for key := range required {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		got, ok := annotations[key]
+		if !ok {
+			return fmt.Errorf("required annotation %q is not present in the signed target artifact", key)
+		}
+		if want := required[key]; want != "" && got != want {
+			return fmt.Errorf("required annotation %q of the signed target artifact has value %q, expected %q", key, got, want)
+		}
+	}
+	return nil
+}
+
+// parseCertificateFingerprint returns the lowercase hex encoded SHA-256
+// fingerprint of fingerprint, optionally separated by colons. An empty
+// fingerprint is returned as is.
+func parseCertificateFingerprint(fingerprint string) (string, error) {
+	if fingerprint == "" {
+		return "", nil
+	}
+	normalized := strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+	if b, err := hex.DecodeString(normalized); err != nil || len(b) != sha256.Size {
+		return "", fmt.Errorf("verifyOptions.PinnedCertificateFingerprint expects a hex encoded SHA-256 fingerprint, got %q", fingerprint)
+	}
+	return normalized, nil
+}
+
+// verifyPinnedCertificate returns an error if the SHA-256 fingerprint of the
+// signing certificate of outcome is not pinnedFingerprint. The signing
+// certificate is not checked if pinnedFingerprint is empty or if the
+// verification has been skipped.
+func verifyPinnedCertificate(outcome *VerificationOutcome, pinnedFingerprint string) error {
+	if pinnedFingerprint == "" || outcome.Skipped() {
+		return nil
+	}
+	certChain, err := outcome.SigningCertificateChain()
+	if err != nil {
+		return fmt.Errorf("failed to check the pinned signing certificate: %w", err)
+	}
+	fingerprint := sha256.Sum256(certChain[0].Raw)
+	if got := hex.EncodeToString(fingerprint[:]); got != pinnedFingerprint {
+		return fmt.Errorf("signing certificate with subject %q has SHA-256 fingerprint %s, which does not match the pinned certificate fingerprint %s", certChain[0].Subject, got, pinnedFingerprint)
+	}
+	return nil
+}
+
+// resolveArtifactDescriptor resolves artifactRef, a full reference, to the
+// descriptor of the artifact to be verified. If artifactRef is a digest
+// reference, the resolved digest must match it.
+func resolveArtifactDescriptor(ctx context.Context, repo registry.Repository, artifactRef string, requireDigestReference bool) (ocispec.Descriptor, error) {
+	logger := log.GetLogger(ctx)
+	ref, err := orasRegistry.ParseReference(artifactRef)
+	if err != nil {
+		return ocispec.Descriptor{}, ErrorSignatureRetrievalFailed{Msg: err.Error()}
+	}
+	if ref.Reference == "" {
+		return ocispec.Descriptor{}, ErrorSignatureRetrievalFailed{Msg: "reference is missing digest or tag"}
+	}
+	if requireDigestReference && ref.ValidateReferenceAsDigest() != nil {
+		return ocispec.Descriptor{}, ErrorSignatureRetrievalFailed{Msg: fmt.Sprintf("artifact reference %s must be a digest reference, since verifying by tag is not allowed", artifactRef)}
+	}
+	resolveStart := time.Now()
+	artifactDescriptor, err := repo.Resolve(ctx, ref.Reference)
+	metrics.ObserveSince(ctx, metrics.StageResolve, resolveStart)
+	if err != nil {
+		return ocispec.Descriptor{}, ErrorSignatureRetrievalFailed{Msg: err.Error()}
+	}
+	if ref.ValidateReferenceAsDigest() != nil {
+		// artifactRef is not a digest reference
+		logger.Infof("Resolved artifact tag `%s` to digest `%v` before verification", ref.Reference, artifactDescriptor.Digest)
+		logger.Warn("The resolved digest may not point to the same signed artifact, since tags are mutable")
+	} else if ref.Reference != artifactDescriptor.Digest.String() {
+		return ocispec.Descriptor{}, ErrorSignatureRetrievalFailed{Msg: fmt.Sprintf("user input digest %s does not match the resolved digest %s", ref.Reference, artifactDescriptor.Digest.String())}
+	}
+	return artifactDescriptor, nil
 }
 
 func generateAnnotations(signerInfo *signature.SignerInfo, annotations map[string]string) (map[string]string, error) {
@@ -583,19 +1934,14 @@ func generateAnnotations(signerInfo *signature.SignerInfo, annotations map[strin
 	if signerInfo == nil {
 		return nil, errors.New("failed to generate annotations: signerInfo cannot be nil")
 	}
-	var thumbprints []string
-	for _, cert := range signerInfo.CertificateChain {
-		checkSum := sha256.Sum256(cert.Raw)
-		thumbprints = append(thumbprints, hex.EncodeToString(checkSum[:]))
-	}
-	val, err := json.Marshal(thumbprints)
+	val, err := envelope.X509ChainThumbprint(signerInfo.CertificateChain)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to generate annotations: %w", err)
 	}
 	if annotations == nil {
 		annotations = make(map[string]string)
 	}
-	annotations[envelope.AnnotationX509ChainThumbprint] = string(val)
+	annotations[envelope.AnnotationX509ChainThumbprint] = val
 	signingTime, err := envelope.SigningTime(signerInfo)
 	if err != nil {
 		return nil, err
@@ -629,6 +1975,31 @@ func validateContentMediaType(contentMediaType string) error {
 	return nil
 }
 
+// verifySignatureMediaType checks that the signature envelope sigBlob is
+// encoded in the format of its declared media type.
+// Envelopes in unrecognized formats are left to the verifier to reject.
+func verifySignatureMediaType(mediaType string, sigBlob []byte) error {
+	detected := envelope.DetectMediaType(sigBlob)
+	if detected == "" || detected == mediaType {
+		return nil
+	}
+	return ErrorInvalidSignatureMediaType{MediaType: mediaType, DetectedMediaType: detected}
+}
+
+// DetectEnvelopeMediaType returns the media type of the signature envelope
+// encoded in data, i.e. `application/jose+json` for a JWS envelope in JSON
+// serialization or `application/cose` for a COSE_Sign1 envelope, judging by
+// its leading bytes. It is meant for signatures whose media type is not
+// known, e.g. detached signatures of blobs read from files. The envelope is
+// not parsed, so that a detected envelope may still fail to parse.
+func DetectEnvelopeMediaType(data []byte) (string, error) {
+	mediaType := envelope.DetectMediaType(data)
+	if mediaType == "" {
+		return "", errors.New("unable to detect the signature envelope media type, the signature is neither a JWS nor a COSE_Sign1 envelope")
+	}
+	return mediaType, nil
+}
+
 func validateSigMediaType(sigMediaType string) error {
 	if !(sigMediaType == jws.MediaTypeEnvelope || sigMediaType == cose.MediaTypeEnvelope) {
 		return fmt.Errorf("invalid signature media-type %q", sigMediaType)
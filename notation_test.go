@@ -14,7 +14,11 @@
 package notation
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -22,18 +26,23 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras-go/v2/registry/remote"
 
 	"github.com/notaryproject/notation-core-go/signature"
 	"github.com/notaryproject/notation-core-go/signature/cose"
 	"github.com/notaryproject/notation-core-go/signature/jws"
+	"github.com/notaryproject/notation-core-go/testhelper"
 	"github.com/notaryproject/notation-go/internal/envelope"
 	"github.com/notaryproject/notation-go/internal/mock"
 	"github.com/notaryproject/notation-go/internal/mock/ocilayout"
+	"github.com/notaryproject/notation-go/metrics"
 	"github.com/notaryproject/notation-go/plugin"
 	"github.com/notaryproject/notation-go/registry"
 	"github.com/notaryproject/notation-go/verifier/trustpolicy"
@@ -68,6 +77,315 @@ func TestSignSuccess(t *testing.T) {
 	}
 }
 
+func TestSignAndVerifySHA512Artifact(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	manifest := []byte(`{"schemaVersion":2}`)
+	artifactDesc := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.SHA512.FromBytes(manifest),
+		Size:      int64(len(manifest)),
+	}
+	if err := store.Push(ctx, artifactDesc, bytes.NewReader(manifest)); err != nil {
+		t.Fatalf("failed to push artifact: %v", err)
+	}
+	if err := store.Tag(ctx, artifactDesc, artifactDesc.Digest.String()); err != nil {
+		t.Fatalf("failed to tag artifact: %v", err)
+	}
+	repo := registry.NewRepository(store)
+	artifactRef := "registry.acme-rockets.io/software/net-monitor@" + artifactDesc.Digest.String()
+
+	signOpts := SignOptions{ArtifactReference: artifactRef, RequireDigestReference: true}
+	signOpts.SignatureMediaType = jws.MediaTypeEnvelope
+	signedDesc, err := Sign(ctx, &dummySigner{}, repo, signOpts)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if !content.Equal(signedDesc, artifactDesc) {
+		t.Fatalf("expected signed descriptor %v, got %v", artifactDesc, signedDesc)
+	}
+
+	// the signature manifest is bound to the sha512 digest of the artifact
+	var sigManifests []ocispec.Descriptor
+	if err := repo.ListSignatures(ctx, artifactDesc, func(signatureManifests []ocispec.Descriptor) error {
+		sigManifests = append(sigManifests, signatureManifests...)
+		return nil
+	}); err != nil {
+		t.Fatalf("ListSignatures() error = %v", err)
+	}
+	if len(sigManifests) != 1 {
+		t.Fatalf("expected 1 signature, got %d", len(sigManifests))
+	}
+	subject, err := repo.(registry.SignatureSubjectFetcher).SignatureSubject(ctx, sigManifests[0])
+	if err != nil {
+		t.Fatalf("SignatureSubject() error = %v", err)
+	}
+	if subject.Digest != artifactDesc.Digest {
+		t.Fatalf("expected signature subject %s, got %s", artifactDesc.Digest, subject.Digest)
+	}
+
+	policyDocument := dummyPolicyDocument()
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+	verifyOpts := VerifyOptions{ArtifactReference: artifactRef, MaxSignatureAttempts: 50, RequireDigestReference: true}
+	verifiedDesc, outcomes, err := Verify(ctx, &verifier, repo, verifyOpts)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !content.Equal(verifiedDesc, artifactDesc) || len(outcomes) != 1 {
+		t.Fatalf("expected a single outcome for %v, got %d outcomes for %v", artifactDesc, len(outcomes), verifiedDesc)
+	}
+
+	// the sha256 digest of the same content is a different artifact
+	sha256Ref := "registry.acme-rockets.io/software/net-monitor@" + digest.SHA256.FromBytes(manifest).String()
+	verifyOpts.ArtifactReference = sha256Ref
+	if _, _, err := Verify(ctx, &verifier, repo, verifyOpts); err == nil {
+		t.Fatal("expected Verify() to fail for the sha256 digest of the artifact")
+	}
+}
+
+func TestSignDualEnvelope(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	manifest := []byte(`{"schemaVersion":2}`)
+	artifactDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, manifest)
+	if err := store.Push(ctx, artifactDesc, bytes.NewReader(manifest)); err != nil {
+		t.Fatalf("failed to push artifact: %v", err)
+	}
+	if err := store.Tag(ctx, artifactDesc, artifactDesc.Digest.String()); err != nil {
+		t.Fatalf("failed to tag artifact: %v", err)
+	}
+	repo := registry.NewRepository(store)
+	signer := &recordingSigner{}
+	signOpts := SignOptions{
+		ArtifactReference: artifactDesc.Digest.String(),
+		UserMetadata:      map[string]string{"foo": "bar"},
+	}
+	manifestDescs, err := SignDualEnvelope(ctx, signer, repo, signOpts)
+	if err != nil {
+		t.Fatalf("SignDualEnvelope() error = %v", err)
+	}
+	if len(manifestDescs) != 2 {
+		t.Fatalf("expected 2 signature manifests, got %d", len(manifestDescs))
+	}
+
+	// both envelopes sign the same payload with the same signing time
+	wantMediaTypes := []string{jws.MediaTypeEnvelope, cose.MediaTypeEnvelope}
+	if len(signer.opts) != 2 {
+		t.Fatalf("expected 2 signing calls, got %d", len(signer.opts))
+	}
+	for i, opts := range signer.opts {
+		if opts.SignatureMediaType != wantMediaTypes[i] {
+			t.Fatalf("expected signature media type %q, got %q", wantMediaTypes[i], opts.SignatureMediaType)
+		}
+		if opts.SigningTime == nil || !opts.SigningTime.Equal(*signer.opts[0].SigningTime) {
+			t.Fatalf("expected a shared signing time, got %v", opts.SigningTime)
+		}
+		if !reflect.DeepEqual(signer.descs[i], signer.descs[0]) {
+			t.Fatalf("expected identical signed descriptors, got %v and %v", signer.descs[0], signer.descs[i])
+		}
+	}
+
+	// both signature manifests are pushed
+	var sigManifests []ocispec.Descriptor
+	if err := repo.ListSignatures(ctx, artifactDesc, func(signatureManifests []ocispec.Descriptor) error {
+		sigManifests = append(sigManifests, signatureManifests...)
+		return nil
+	}); err != nil {
+		t.Fatalf("ListSignatures() error = %v", err)
+	}
+	if len(sigManifests) != 2 {
+		t.Fatalf("expected 2 signatures, got %d", len(sigManifests))
+	}
+	for i, manifestDesc := range manifestDescs {
+		_, blobDesc, err := repo.FetchSignatureBlob(ctx, manifestDesc)
+		if err != nil {
+			t.Fatalf("FetchSignatureBlob() error = %v", err)
+		}
+		if blobDesc.MediaType != wantMediaTypes[i] {
+			t.Fatalf("expected signature media type %q, got %q", wantMediaTypes[i], blobDesc.MediaType)
+		}
+	}
+
+	t.Run("nil repo", func(t *testing.T) {
+		if _, err := SignDualEnvelope(ctx, signer, nil, signOpts); err == nil || err.Error() != "repo cannot be nil" {
+			t.Fatalf("expected error %q, got %v", "repo cannot be nil", err)
+		}
+	})
+}
+
+// recordingSigner records the descriptors and options it signs with.
+type recordingSigner struct {
+	descs []ocispec.Descriptor
+	opts  []SignerSignOptions
+}
+
+func (s *recordingSigner) Sign(_ context.Context, desc ocispec.Descriptor, opts SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
+	s.descs = append(s.descs, desc)
+	s.opts = append(s.opts, opts)
+	return []byte("ABC"), &signature.SignerInfo{
+		SignedAttributes: signature.SignedAttributes{
+			SigningTime: *opts.SigningTime,
+		},
+	}, nil
+}
+
+type recordingMetrics struct {
+	stages []string
+}
+
+func (m *recordingMetrics) ObserveDuration(stage string, d time.Duration) {
+	m.stages = append(m.stages, stage)
+}
+
+func TestSignMetrics(t *testing.T) {
+	m := &recordingMetrics{}
+	ctx := metrics.WithMetrics(context.Background(), m)
+	opts := SignOptions{}
+	opts.SignatureMediaType = jws.MediaTypeEnvelope
+	opts.ArtifactReference = mock.SampleArtifactUri
+	if _, err := Sign(ctx, &dummySigner{}, mock.NewRepository(), opts); err != nil {
+		t.Fatalf("Sign failed with error: %v", err)
+	}
+	want := []string{metrics.StageResolve, metrics.StageSign, metrics.StagePushSignature}
+	if !reflect.DeepEqual(m.stages, want) {
+		t.Fatalf("observed stages = %v, want %v", m.stages, want)
+	}
+}
+
+func TestVerifyMetrics(t *testing.T) {
+	policyDocument := dummyPolicyDocument()
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+	m := &recordingMetrics{}
+	ctx := metrics.WithMetrics(context.Background(), m)
+	opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
+	if _, _, err := Verify(ctx, &verifier, mock.NewRepository(), opts); err != nil {
+		t.Fatalf("Verify failed with error: %v", err)
+	}
+	want := []string{metrics.StageResolve, metrics.StageFetchSignature, metrics.StageVerifySignature}
+	if !reflect.DeepEqual(m.stages, want) {
+		t.Fatalf("observed stages = %v, want %v", m.stages, want)
+	}
+}
+
+// annotationRecordingRepository records the annotations of the pushed
+// signature.
+type annotationRecordingRepository struct {
+	mock.Repository
+	annotations map[string]string
+}
+
+func (r *annotationRecordingRepository) PushSignature(ctx context.Context, mediaType string, blob []byte, subject ocispec.Descriptor, annotations map[string]string) (ocispec.Descriptor, ocispec.Descriptor, error) {
+	r.annotations = annotations
+	return r.Repository.PushSignature(ctx, mediaType, blob, subject, annotations)
+}
+
+// fetchCountingRepository counts the signature envelopes fetched.
+type fetchCountingRepository struct {
+	annotationRecordingRepository
+	fetches int
+}
+
+func (r *fetchCountingRepository) FetchSignatureBlob(ctx context.Context, desc ocispec.Descriptor) ([]byte, ocispec.Descriptor, error) {
+	r.fetches++
+	return r.Repository.FetchSignatureBlob(ctx, desc)
+}
+
+// certChainSigner returns the given certificate chain in the signer info.
+type certChainSigner struct {
+	certChain []*x509.Certificate
+}
+
+func (s *certChainSigner) Sign(_ context.Context, _ ocispec.Descriptor, _ SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
+	return []byte("ABC"), &signature.SignerInfo{
+		SignedAttributes: signature.SignedAttributes{
+			SigningTime: time.Now(),
+		},
+		CertificateChain: s.certChain,
+	}, nil
+}
+
+func TestSignLinkPreviousSignature(t *testing.T) {
+	sigEnv, err := signature.ParseEnvelope(jws.MediaTypeEnvelope, mock.MockCaValidSigEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := sigEnv.Content()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := SignOptions{
+		ArtifactReference:     mock.SampleArtifactUri,
+		LinkPreviousSignature: true,
+	}
+	opts.SignatureMediaType = jws.MediaTypeEnvelope
+
+	t.Run("same signing identity", func(t *testing.T) {
+		repo := &annotationRecordingRepository{Repository: mock.NewRepository()}
+		signer := &certChainSigner{certChain: content.SignerInfo.CertificateChain}
+		if _, err := Sign(context.Background(), signer, repo, opts); err != nil {
+			t.Fatalf("Sign failed with error: %v", err)
+		}
+		if got := repo.annotations[AnnotationPreviousSignature]; got != mock.SigManfiestDescriptor.Digest.String() {
+			t.Fatalf("expected previous signature %v, got %q", mock.SigManfiestDescriptor.Digest, got)
+		}
+	})
+
+	t.Run("different signing identity", func(t *testing.T) {
+		repo := &annotationRecordingRepository{Repository: mock.NewRepository()}
+		// same subject, but another certificate
+		leaf := *content.SignerInfo.CertificateChain[0]
+		leaf.Raw = []byte("other")
+		signer := &certChainSigner{certChain: []*x509.Certificate{&leaf}}
+		if _, err := Sign(context.Background(), signer, repo, opts); err != nil {
+			t.Fatalf("Sign failed with error: %v", err)
+		}
+		if got, ok := repo.annotations[AnnotationPreviousSignature]; ok {
+			t.Fatalf("expected no previous signature, got %q", got)
+		}
+	})
+
+	t.Run("fetch error skipped", func(t *testing.T) {
+		repo := &annotationRecordingRepository{Repository: mock.NewRepository()}
+		repo.FetchSignatureBlobError = errors.New("fetch failed")
+		signer := &certChainSigner{certChain: content.SignerInfo.CertificateChain}
+		if _, err := Sign(context.Background(), signer, repo, opts); err != nil {
+			t.Fatalf("Sign failed with error: %v", err)
+		}
+		if got, ok := repo.annotations[AnnotationPreviousSignature]; ok {
+			t.Fatalf("expected no previous signature, got %q", got)
+		}
+	})
+
+	t.Run("bounded lookup", func(t *testing.T) {
+		repo := &fetchCountingRepository{annotationRecordingRepository: annotationRecordingRepository{Repository: mock.NewRepository()}}
+		repo.ListSignaturesResponse = nil
+		for i := 0; i < maxPreviousSignatureCandidates+10; i++ {
+			sigManifestDesc := mock.SigManfiestDescriptor
+			sigManifestDesc.Digest = digest.FromString(fmt.Sprintf("signature %d", i))
+			repo.ListSignaturesResponse = append(repo.ListSignaturesResponse, sigManifestDesc)
+		}
+		signer := &certChainSigner{certChain: content.SignerInfo.CertificateChain}
+		if _, err := Sign(context.Background(), signer, repo, opts); err != nil {
+			t.Fatalf("Sign failed with error: %v", err)
+		}
+		if repo.fetches != maxPreviousSignatureCandidates {
+			t.Fatalf("expected %d signatures to be fetched, got %d", maxPreviousSignatureCandidates, repo.fetches)
+		}
+		if _, ok := repo.annotations[AnnotationPreviousSignature]; !ok {
+			t.Fatal("expected a previous signature")
+		}
+	})
+
+	t.Run("missing signing certificate", func(t *testing.T) {
+		repo := &annotationRecordingRepository{Repository: mock.NewRepository()}
+		_, err := Sign(context.Background(), &dummySigner{}, repo, opts)
+		want := "failed to look up the previous signature: signing certificate is missing"
+		if err == nil || err.Error() != want {
+			t.Fatalf("expected error %q, got %v", want, err)
+		}
+	})
+}
+
 func TestSignBlobSuccess(t *testing.T) {
 	reader := strings.NewReader("some content")
 	testCases := []struct {
@@ -105,6 +423,68 @@ func TestSignBlobSuccess(t *testing.T) {
 	}
 }
 
+func TestSignBlobContentDigest(t *testing.T) {
+	content := "some content"
+	opts := SignBlobOptions{
+		SignerSignOptions: SignerSignOptions{SignatureMediaType: jws.MediaTypeEnvelope},
+		ContentMediaType:  "video/mp4",
+	}
+	for _, signer := range []BlobSigner{&dummySigner{}, &streamingSigner{}} {
+		t.Run(fmt.Sprintf("%T", signer), func(t *testing.T) {
+			opts.ContentDigest = digest.FromString(content)
+			if _, _, err := SignBlob(context.Background(), signer, strings.NewReader(content), opts); err != nil {
+				t.Fatalf("SignBlob() error = %v", err)
+			}
+
+			opts.ContentDigest = digest.FromString("other content")
+			_, _, err := SignBlob(context.Background(), signer, strings.NewReader(content), opts)
+			wantErr := fmt.Sprintf("blob digest %s does not match the content digest %s", digest.FromString(content), opts.ContentDigest)
+			if err == nil || err.Error() != wantErr {
+				t.Fatalf("expected error %q, got %v", wantErr, err)
+			}
+		})
+	}
+
+	opts.ContentDigest = "sha256:abc"
+	if _, _, err := SignBlob(context.Background(), &dummySigner{}, strings.NewReader(content), opts); err == nil || !strings.HasPrefix(err.Error(), "invalid content digest") {
+		t.Fatalf("expected invalid content digest error, got %v", err)
+	}
+}
+
+func TestSignBlobStreamingSigner(t *testing.T) {
+	content := "some content"
+	signer := &streamingSigner{}
+	opts := SignBlobOptions{
+		SignerSignOptions: SignerSignOptions{SignatureMediaType: jws.MediaTypeEnvelope},
+		ContentMediaType:  "video/mp4",
+	}
+	if _, _, err := SignBlob(context.Background(), signer, strings.NewReader(content), opts); err != nil {
+		t.Fatalf("SignBlob() error = %v", err)
+	}
+	if signer.content != content {
+		t.Fatalf("expected SignStream to read %q, got %q", content, signer.content)
+	}
+}
+
+// streamingSigner is a BlobSigner implementing StreamingSigner, which fails
+// if SignBlob is used instead of SignStream.
+type streamingSigner struct {
+	content string
+}
+
+func (s *streamingSigner) SignBlob(_ context.Context, _ BlobDescriptorGenerator, _ SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
+	return nil, nil, errors.New("SignBlob should not be called")
+}
+
+func (s *streamingSigner) SignStream(_ context.Context, r io.Reader, _ SignBlobOptions) ([]byte, *signature.SignerInfo, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.content = string(content)
+	return []byte("ABC"), &signature.SignerInfo{}, nil
+}
+
 func TestSignBlobError(t *testing.T) {
 	reader := strings.NewReader("some content")
 	testCases := []struct {
@@ -212,6 +592,55 @@ func TestSignArtifactRefIsTag(t *testing.T) {
 	}
 }
 
+func TestSignRequireDigestReference(t *testing.T) {
+	repo := mock.NewRepository()
+	opts := SignOptions{RequireDigestReference: true}
+	opts.SignatureMediaType = jws.MediaTypeEnvelope
+
+	opts.ArtifactReference = "registry.acme-rockets.io/software/net-monitor:v1"
+	expectedErr := "artifact reference registry.acme-rockets.io/software/net-monitor:v1 must be a digest reference, since signing by tag is not allowed"
+	if _, err := Sign(context.Background(), &dummySigner{}, repo, opts); err == nil || err.Error() != expectedErr {
+		t.Fatalf("expect error %q, got %v", expectedErr, err)
+	}
+
+	opts.ArtifactReference = mock.SampleArtifactUri
+	if _, err := Sign(context.Background(), &dummySigner{}, repo, opts); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+}
+
+func TestSignDescriptor(t *testing.T) {
+	opts := SignOptions{}
+	opts.SignatureMediaType = jws.MediaTypeEnvelope
+
+	t.Run("success", func(t *testing.T) {
+		repo := mock.NewRepository()
+		// signing must not resolve the reference
+		repo.ResolveError = errors.New("resolve should not be called")
+		desc, err := SignDescriptor(context.Background(), &dummySigner{}, repo, mock.ImageDescriptor, opts)
+		if err != nil {
+			t.Fatalf("expect no error, got %v", err)
+		}
+		if !reflect.DeepEqual(desc, mock.ImageDescriptor) {
+			t.Fatalf("expect descriptor %v, got %v", mock.ImageDescriptor, desc)
+		}
+	})
+
+	t.Run("invalid descriptor", func(t *testing.T) {
+		expectedErr := "invalid target descriptor: invalid checksum digest format"
+		if _, err := SignDescriptor(context.Background(), &dummySigner{}, mock.NewRepository(), ocispec.Descriptor{}, opts); err == nil || err.Error() != expectedErr {
+			t.Fatalf("expect error %q, got %v", expectedErr, err)
+		}
+	})
+
+	t.Run("nil repo", func(t *testing.T) {
+		expectedErr := "repo cannot be nil"
+		if _, err := SignDescriptor(context.Background(), &dummySigner{}, nil, mock.ImageDescriptor, opts); err == nil || err.Error() != expectedErr {
+			t.Fatalf("expect error %q, got %v", expectedErr, err)
+		}
+	})
+}
+
 func TestSignWithPushSignatureError(t *testing.T) {
 	repo := mock.NewRepository()
 	repo.PushSignatureError = errors.New("error")
@@ -322,6 +751,98 @@ func TestRegistryResolveError(t *testing.T) {
 	}
 }
 
+func TestVerifySpecificSignature(t *testing.T) {
+	policyDocument := dummyPolicyDocument()
+	tests := []struct {
+		name            string
+		signatureDigest digest.Digest
+		failVerify      bool
+		skip            bool
+		wantErr         string
+	}{
+		{
+			name:            "success",
+			signatureDigest: mock.SigManfiestDescriptor.Digest,
+		},
+		{
+			name:            "skip verification",
+			signatureDigest: mock.SigManfiestDescriptor.Digest,
+			skip:            true,
+		},
+		{
+			name:            "invalid signature digest",
+			signatureDigest: "invalid",
+			wantErr:         "invalid signature digest: invalid checksum digest format",
+		},
+		{
+			name:            "signature not associated",
+			signatureDigest: mock.ZeroDigest,
+			wantErr:         fmt.Sprintf("signature with digest %q is not associated with %q", mock.ZeroDigest, mock.SampleArtifactUri),
+		},
+		{
+			name:            "verification failed",
+			signatureDigest: mock.SigManfiestDescriptor.Digest,
+			failVerify:      true,
+			wantErr:         fmt.Sprintf("signature verification failed\nfailed to verify signature with digest %v, failed verify", mock.SigManfiestDescriptor.Digest),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, tt.failVerify, *trustpolicy.LevelStrict, tt.skip}
+			outcome, err := VerifySpecificSignature(context.Background(), &verifier, mock.NewRepository(), mock.SampleArtifactUri, tt.signatureDigest, VerifySpecificSignatureOptions{})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifySpecificSignature failed with error: %v", err)
+			}
+			if outcome == nil {
+				t.Fatal("expected non-nil outcome")
+			}
+		})
+	}
+}
+
+func TestVerifySpecificSignatureCertificateChainValidator(t *testing.T) {
+	certChain := []*x509.Certificate{testhelper.GetRSALeafCertificate().Cert, testhelper.GetRSARootCertificate().Cert}
+	verifier := &chainValidatingVerifier{signerInfoVerifier{signingTime: time.Now(), certChain: certChain}}
+	var gotChain []*x509.Certificate
+	opts := VerifySpecificSignatureOptions{
+		CertificateChainValidator: func(chain []*x509.Certificate) error {
+			gotChain = chain
+			return errors.New("certificate policy OID not allowed")
+		},
+	}
+	_, err := VerifySpecificSignature(context.Background(), verifier, mock.NewRepository(), mock.SampleArtifactUri, mock.SigManfiestDescriptor.Digest, opts)
+	wantErr := fmt.Sprintf("signature verification failed\nfailed to verify signature with digest %v, certificate policy OID not allowed", mock.SigManfiestDescriptor.Digest)
+	if err == nil || err.Error() != wantErr {
+		t.Fatalf("expected error %q, got %v", wantErr, err)
+	}
+	if !reflect.DeepEqual(gotChain, certChain) {
+		t.Fatalf("expected certificate chain %v, got %v", certChain, gotChain)
+	}
+}
+
+func TestVerifyPreviousSignature(t *testing.T) {
+	policyDocument := dummyPolicyDocument()
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+	repo := mock.NewRepository()
+	sigManifestDesc := mock.SigManfiestDescriptor
+	sigManifestDesc.Annotations = map[string]string{AnnotationPreviousSignature: mock.ZeroDigest.String()}
+	repo.ListSignaturesResponse = []ocispec.Descriptor{sigManifestDesc}
+	opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
+	_, outcomes, err := Verify(context.Background(), &verifier, repo, opts)
+	if err != nil {
+		t.Fatalf("Verify failed with error: %v", err)
+	}
+	if outcomes[0].PreviousSignature != mock.ZeroDigest {
+		t.Fatalf("expected previous signature %v, got %q", mock.ZeroDigest, outcomes[0].PreviousSignature)
+	}
+}
+
 func TestVerifyEmptyReference(t *testing.T) {
 	repo := mock.NewRepository()
 	policyDocument := dummyPolicyDocument()
@@ -354,6 +875,23 @@ func TestVerifyTagReferenceFailed(t *testing.T) {
 	}
 }
 
+func TestVerifyRequireDigestReference(t *testing.T) {
+	repo := mock.NewRepository()
+	policyDocument := dummyPolicyDocument()
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+
+	opts := VerifyOptions{ArtifactReference: "registry.acme-rockets.io/software/net-monitor:v1", MaxSignatureAttempts: 50, RequireDigestReference: true}
+	expectedErr := ErrorSignatureRetrievalFailed{Msg: "artifact reference registry.acme-rockets.io/software/net-monitor:v1 must be a digest reference, since verifying by tag is not allowed"}
+	if _, _, err := Verify(context.Background(), &verifier, repo, opts); err == nil || !errors.Is(err, expectedErr) {
+		t.Fatalf("VerifyRequireDigestReference expected: %v got: %v", expectedErr, err)
+	}
+
+	opts.ArtifactReference = mock.SampleArtifactUri
+	if _, _, err := Verify(context.Background(), &verifier, repo, opts); err != nil {
+		t.Fatalf("expected nil error, but got: %v", err)
+	}
+}
+
 func TestVerifyDigestNotMatchResolve(t *testing.T) {
 	repo := mock.NewRepository()
 	repo.MissMatchDigest = true
@@ -384,84 +922,911 @@ func TestSignDigestNotMatchResolve(t *testing.T) {
 	errorMessage := fmt.Sprintf("user input digest %s does not match the resolved digest %s", mock.SampleDigest, mock.ZeroDigest)
 	expectedErr := errors.New(errorMessage)
 
-	_, err := Sign(context.Background(), &dummySigner{}, repo, signOpts)
-	if err == nil || err.Error() != errorMessage {
-		t.Fatalf("SignDigestNotMatch expected: %v got: %v", expectedErr, err)
-	}
+	_, err := Sign(context.Background(), &dummySigner{}, repo, signOpts)
+	if err == nil || err.Error() != errorMessage {
+		t.Fatalf("SignDigestNotMatch expected: %v got: %v", expectedErr, err)
+	}
+}
+
+func TestSkippedSignatureVerification(t *testing.T) {
+	repo := mock.NewRepository()
+	policyDocument := dummyPolicyDocument()
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelSkip, false}
+
+	opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
+	_, outcomes, err := Verify(context.Background(), &verifier, repo, opts)
+
+	if err != nil || outcomes[0].VerificationLevel.Name != trustpolicy.LevelSkip.Name {
+		t.Fatalf("\"skip\" verification level must pass overall signature verification")
+	}
+}
+
+func TestRegistryNoSignatureManifests(t *testing.T) {
+	repo := mock.NewRepository()
+	policyDocument := dummyPolicyDocument()
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+	errorMessage := fmt.Sprintf("no signature is associated with %q, make sure the artifact was signed successfully", mock.SampleArtifactUri)
+	expectedErr := ErrorSignatureRetrievalFailed{Msg: errorMessage}
+
+	// mock the repository
+	repo.ListSignaturesResponse = []ocispec.Descriptor{}
+	opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
+	_, _, err := Verify(context.Background(), &verifier, repo, opts)
+
+	if err == nil || !errors.Is(err, expectedErr) {
+		t.Fatalf("RegistryNoSignatureManifests expected: %v got: %v", expectedErr, err)
+	}
+}
+
+func TestVerifyUnsignedArtifact(t *testing.T) {
+	policyDocument := dummyPolicyDocument()
+	opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
+
+	t.Run("audit accepts unsigned artifact", func(t *testing.T) {
+		repo := mock.NewRepository()
+		repo.ListSignaturesResponse = []ocispec.Descriptor{}
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelAudit, false}
+		opts := opts
+		opts.AllowUnsigned = true
+		desc, outcomes, err := Verify(context.Background(), &verifier, repo, opts)
+		if err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+		if desc.Digest != mock.SampleDigest {
+			t.Fatalf("expected artifact digest %s, got %s", mock.SampleDigest, desc.Digest)
+		}
+		if len(outcomes) != 1 || outcomes[0].SkipReason != SkipReasonUnsigned || outcomes[0].VerificationLevel.Name != trustpolicy.LevelAudit.Name {
+			t.Fatalf("expected a single unsigned outcome with audit level, got %+v", outcomes)
+		}
+		if outcomes[0].RawSignature != nil {
+			t.Fatal("expected unsigned outcome to carry no signature")
+		}
+	})
+
+	t.Run("audit rejects unsigned artifact by default", func(t *testing.T) {
+		repo := mock.NewRepository()
+		repo.ListSignaturesResponse = []ocispec.Descriptor{}
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelAudit, false}
+		_, _, err := Verify(context.Background(), &verifier, repo, opts)
+		var errRetrieval ErrorSignatureRetrievalFailed
+		if !errors.As(err, &errRetrieval) {
+			t.Fatalf("expected ErrorSignatureRetrievalFailed, got %v", err)
+		}
+	})
+
+	t.Run("permissive rejects unsigned artifact", func(t *testing.T) {
+		repo := mock.NewRepository()
+		repo.ListSignaturesResponse = []ocispec.Descriptor{}
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelPermissive, false}
+		opts := opts
+		opts.AllowUnsigned = true
+		_, _, err := Verify(context.Background(), &verifier, repo, opts)
+		var errRetrieval ErrorSignatureRetrievalFailed
+		if !errors.As(err, &errRetrieval) {
+			t.Fatalf("expected ErrorSignatureRetrievalFailed, got %v", err)
+		}
+	})
+}
+
+func TestVerifySignatureRepository(t *testing.T) {
+	policyDocument := dummyPolicyDocument()
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+	artifactRepo := mock.NewRepository()
+	artifactRepo.ListSignaturesResponse = []ocispec.Descriptor{}
+
+	t.Run("signatures listed from signature repository", func(t *testing.T) {
+		opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50, SignatureRepository: mock.NewRepository()}
+		desc, outcomes, err := Verify(context.Background(), &verifier, artifactRepo, opts)
+		if err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+		if desc.Digest != mock.SampleDigest || len(outcomes) != 1 {
+			t.Fatalf("expected a single outcome for %s, got %d outcomes for %s", mock.SampleDigest, len(outcomes), desc.Digest)
+		}
+	})
+
+	t.Run("signature subject matches", func(t *testing.T) {
+		sigRepo := subjectRepository{Repository: mock.NewRepository(), subject: ocispec.Descriptor{Digest: mock.SampleDigest}}
+		opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50, SignatureRepository: sigRepo}
+		if _, _, err := Verify(context.Background(), &verifier, artifactRepo, opts); err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+	})
+
+	t.Run("signature subject mismatch", func(t *testing.T) {
+		otherDigest := digest.FromString("other")
+		sigRepo := subjectRepository{Repository: mock.NewRepository(), subject: ocispec.Descriptor{Digest: otherDigest}}
+		opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50, SignatureRepository: sigRepo}
+		_, _, err := Verify(context.Background(), &verifier, artifactRepo, opts)
+		var errMismatch ErrorTargetArtifactMismatch
+		if !errors.Is(err, ErrorVerificationFailed{}) || !errors.As(err, &errMismatch) {
+			t.Fatalf("expected ErrorVerificationFailed with ErrorTargetArtifactMismatch, got %v", err)
+		}
+		expectedMsg := fmt.Sprintf("signature manifest subject %v does not match the artifact %v", otherDigest, mock.SampleDigest)
+		if errMismatch.Msg != expectedMsg {
+			t.Fatalf("expected error message %q, got %q", expectedMsg, errMismatch.Msg)
+		}
+	})
+}
+
+// subjectRepository is a mock.Repository reporting subject as the subject of
+// every signature manifest.
+type subjectRepository struct {
+	mock.Repository
+	subject ocispec.Descriptor
+}
+
+func (r subjectRepository) SignatureSubject(_ context.Context, _ ocispec.Descriptor) (ocispec.Descriptor, error) {
+	return r.subject, nil
+}
+
+func TestRegistryFetchSignatureBlobError(t *testing.T) {
+	repo := mock.NewRepository()
+	policyDocument := dummyPolicyDocument()
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+	errorMessage := fmt.Sprintf("unable to retrieve digital signature with digest %q associated with %q from the Repository, error : network error", mock.SampleDigest, mock.SampleArtifactUri)
+	expectedErr := ErrorSignatureRetrievalFailed{Msg: errorMessage}
+
+	// mock the repository
+	repo.FetchSignatureBlobError = errors.New("network error")
+	opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
+	_, _, err := Verify(context.Background(), &verifier, repo, opts)
+
+	if err == nil || !errors.Is(err, expectedErr) {
+		t.Fatalf("RegistryFetchSignatureBlob expected: %v got: %v", expectedErr, err)
+	}
+}
+
+func TestVerifyValid(t *testing.T) {
+	repo := mock.NewRepository()
+	policyDocument := dummyPolicyDocument()
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+
+	// mock the repository
+	opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
+	_, outcomes, err := Verify(context.Background(), &verifier, repo, opts)
+
+	if err != nil {
+		t.Fatalf("expected nil error, but got: %v", err)
+	}
+	if outcomes[0].Skipped() {
+		t.Fatal("expected outcome not to be skipped")
+	}
+}
+
+func TestVerifyMaxSignatureAge(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		signingTime time.Time
+		maxAge      time.Duration
+		clock       Clock
+		wantErr     string
+	}{
+		{
+			name:        "not checked",
+			signingTime: now.Add(-365 * 24 * time.Hour),
+		},
+		{
+			name:        "fresh signature",
+			signingTime: now.Add(-time.Hour),
+			maxAge:      90 * 24 * time.Hour,
+		},
+		{
+			name:        "stale signature",
+			signingTime: now.Add(-91 * 24 * time.Hour),
+			maxAge:      90 * 24 * time.Hour,
+			wantErr:     "older than the maximum signature age of 2160h0m0s",
+		},
+		{
+			name:        "stale signature at the time of the clock",
+			signingTime: now.Add(-time.Hour),
+			maxAge:      90 * 24 * time.Hour,
+			clock:       fixedClock(now.Add(91 * 24 * time.Hour)),
+			wantErr:     "older than the maximum signature age of 2160h0m0s",
+		},
+		{
+			name:    "no signing time",
+			maxAge:  90 * 24 * time.Hour,
+			wantErr: "failed to check the age of the signature: signing time is not present in the signature envelope",
+		},
+		{
+			name:    "negative max age",
+			maxAge:  -time.Hour,
+			wantErr: "verifyOptions.MaxSignatureAge expects a non-negative duration, got -1h0m0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := &signerInfoVerifier{signingTime: tt.signingTime}
+			opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50, MaxSignatureAge: tt.maxAge, Clock: tt.clock}
+			_, _, err := Verify(context.Background(), verifier, mock.NewRepository(), opts)
+			specificOpts := VerifySpecificSignatureOptions{MaxSignatureAge: tt.maxAge, Clock: tt.clock}
+			_, specificErr := VerifySpecificSignature(context.Background(), verifier, mock.NewRepository(), mock.SampleArtifactUri, mock.SigManfiestDescriptor.Digest, specificOpts)
+			if tt.wantErr == "" {
+				if err != nil || specificErr != nil {
+					t.Fatalf("expected nil error, but got: %v, %v", err, specificErr)
+				}
+				return
+			}
+			for _, err := range []error{err, specificErr} {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, but got: %v", tt.wantErr, err)
+				}
+			}
+			if tt.maxAge > 0 && !errors.Is(err, ErrorVerificationFailed{}) {
+				t.Fatalf("expected ErrorVerificationFailed, but got: %v", err)
+			}
+			if strings.HasPrefix(tt.name, "stale signature") {
+				var tooOldErr ErrorSignatureTooOld
+				if !errors.As(err, &tooOldErr) || !errors.As(specificErr, &tooOldErr) {
+					t.Fatalf("expected ErrorSignatureTooOld, but got: %v, %v", err, specificErr)
+				}
+				if !tooOldErr.SigningTime.Equal(tt.signingTime) {
+					t.Fatalf("expected signing time %v, but got %v", tt.signingTime, tooOldErr.SigningTime)
+				}
+			}
+		})
+	}
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestVerifyPinnedCertificateFingerprint(t *testing.T) {
+	leafCert := testhelper.GetRSALeafCertificate().Cert
+	rootCert := testhelper.GetRSARootCertificate().Cert
+	leafFingerprint := sha256.Sum256(leafCert.Raw)
+	rootFingerprint := sha256.Sum256(rootCert.Raw)
+	certChain := []*x509.Certificate{leafCert, rootCert}
+
+	tests := []struct {
+		name        string
+		certChain   []*x509.Certificate
+		fingerprint string
+		wantErr     string
+	}{
+		{
+			name:      "not pinned",
+			certChain: certChain,
+		},
+		{
+			name:        "matching fingerprint",
+			certChain:   certChain,
+			fingerprint: hex.EncodeToString(leafFingerprint[:]),
+		},
+		{
+			name:        "matching fingerprint with colons",
+			certChain:   certChain,
+			fingerprint: strings.ToUpper(colonSeparated(leafFingerprint[:])),
+		},
+		{
+			name:        "fingerprint of another certificate of the chain",
+			certChain:   certChain,
+			fingerprint: hex.EncodeToString(rootFingerprint[:]),
+			wantErr:     fmt.Sprintf("signing certificate with subject %q has SHA-256 fingerprint %x, which does not match the pinned certificate fingerprint %x", leafCert.Subject, leafFingerprint, rootFingerprint),
+		},
+		{
+			name:        "no certificate chain",
+			fingerprint: hex.EncodeToString(leafFingerprint[:]),
+			wantErr:     "failed to check the pinned signing certificate: certificate chain is not present in the signature envelope",
+		},
+		{
+			name:        "invalid fingerprint",
+			certChain:   certChain,
+			fingerprint: "sha256:abc",
+			wantErr:     `verifyOptions.PinnedCertificateFingerprint expects a hex encoded SHA-256 fingerprint, got "sha256:abc"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := &signerInfoVerifier{signingTime: time.Now(), certChain: tt.certChain}
+			opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50, PinnedCertificateFingerprint: tt.fingerprint}
+			_, _, err := Verify(context.Background(), verifier, mock.NewRepository(), opts)
+			specificOpts := VerifySpecificSignatureOptions{PinnedCertificateFingerprint: tt.fingerprint}
+			_, specificErr := VerifySpecificSignature(context.Background(), verifier, mock.NewRepository(), mock.SampleArtifactUri, mock.SigManfiestDescriptor.Digest, specificOpts)
+			if tt.wantErr == "" {
+				if err != nil || specificErr != nil {
+					t.Fatalf("expected nil error, but got: %v, %v", err, specificErr)
+				}
+				return
+			}
+			for _, err := range []error{err, specificErr} {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, but got: %v", tt.wantErr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyRequiredTargetAnnotations(t *testing.T) {
+	annotations := map[string]string{
+		"org.opencontainers.image.version": "1.0.0",
+		"buildId":                          "101",
+	}
+	tests := []struct {
+		name     string
+		required map[string]string
+		wantErr  string
+	}{
+		{
+			name: "not required",
+		},
+		{
+			name:     "matching annotations",
+			required: map[string]string{"org.opencontainers.image.version": "1.0.0", "buildId": "101"},
+		},
+		{
+			name:     "present annotation",
+			required: map[string]string{"org.opencontainers.image.version": ""},
+		},
+		{
+			name:     "missing annotation",
+			required: map[string]string{"org.opencontainers.image.version": "1.0.0", "org.opencontainers.image.revision": ""},
+			wantErr:  `required annotation "org.opencontainers.image.revision" is not present in the signed target artifact`,
+		},
+		{
+			name:     "mismatched annotation",
+			required: map[string]string{"org.opencontainers.image.version": "2.0.0"},
+			wantErr:  `required annotation "org.opencontainers.image.version" of the signed target artifact has value "1.0.0", expected "2.0.0"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := &signerInfoVerifier{signingTime: time.Now(), annotations: annotations}
+			opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50, RequiredTargetAnnotations: tt.required}
+			_, _, err := Verify(context.Background(), verifier, mock.NewRepository(), opts)
+			specificOpts := VerifySpecificSignatureOptions{RequiredTargetAnnotations: tt.required}
+			_, specificErr := VerifySpecificSignature(context.Background(), verifier, mock.NewRepository(), mock.SampleArtifactUri, mock.SigManfiestDescriptor.Digest, specificOpts)
+			if tt.wantErr == "" {
+				if err != nil || specificErr != nil {
+					t.Fatalf("expected nil error, but got: %v, %v", err, specificErr)
+				}
+				return
+			}
+			for _, err := range []error{err, specificErr} {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, but got: %v", tt.wantErr, err)
+				}
+			}
+		})
+	}
+}
+
+// colonSeparated returns b hex encoded with colons between bytes.
+func colonSeparated(b []byte) string {
+	parts := make([]string, len(b))
+	for i, c := range b {
+		parts[i] = hex.EncodeToString([]byte{c})
+	}
+	return strings.Join(parts, ":")
+}
+
+// malformedManifestRepository fails to fetch the signature blob of the
+// signature manifest with digest malformed.
+type malformedManifestRepository struct {
+	mock.Repository
+	malformed digest.Digest
+}
+
+func (r malformedManifestRepository) FetchSignatureBlob(ctx context.Context, desc ocispec.Descriptor) ([]byte, ocispec.Descriptor, error) {
+	if desc.Digest == r.malformed {
+		return nil, ocispec.Descriptor{}, registry.ErrorMalformedSignatureManifest{Digest: desc.Digest, BlobCount: 0}
+	}
+	return r.Repository.FetchSignatureBlob(ctx, desc)
+}
+
+func TestVerifyMalformedSignatureManifest(t *testing.T) {
+	policyDocument := dummyPolicyDocument()
+	malformedDesc := mock.SigManfiestDescriptor
+	malformedDesc.Digest = mock.ZeroDigest
+	malformedErrMsg := fmt.Sprintf("signature with digest %v was skipped, signature manifest %v requires exactly one signature envelope blob, got 0", mock.ZeroDigest, mock.ZeroDigest)
+
+	t.Run("valid signature after malformed one", func(t *testing.T) {
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+		repo := malformedManifestRepository{Repository: mock.NewRepository(), malformed: mock.ZeroDigest}
+		repo.ListSignaturesResponse = []ocispec.Descriptor{malformedDesc, mock.SigManfiestDescriptor}
+		opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
+		_, outcomes, err := Verify(context.Background(), &verifier, repo, opts)
+		if err != nil {
+			t.Fatalf("expected nil error, but got: %v", err)
+		}
+		if len(outcomes) != 1 {
+			t.Fatalf("expected 1 outcome, but got %d", len(outcomes))
+		}
+	})
+
+	t.Run("only malformed signature", func(t *testing.T) {
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+		repo := malformedManifestRepository{Repository: mock.NewRepository(), malformed: mock.ZeroDigest}
+		repo.ListSignaturesResponse = []ocispec.Descriptor{malformedDesc}
+		opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
+		_, _, err := Verify(context.Background(), &verifier, repo, opts)
+		if err == nil || !errors.Is(err, ErrorVerificationFailed{}) {
+			t.Fatalf("VerificationFailed expected: %v got: %v", ErrorVerificationFailed{}, err)
+		}
+		var malformedErr registry.ErrorMalformedSignatureManifest
+		if !errors.As(err, &malformedErr) || !strings.Contains(err.Error(), malformedErrMsg) {
+			t.Fatalf("expected error to contain %q, got %v", malformedErrMsg, err)
+		}
+	})
+}
+
+// indexRepository serves index as the image index of the artifact, where the
+// manifest with digest unsigned has no signature.
+type indexRepository struct {
+	mock.Repository
+	index    ocispec.Index
+	unsigned digest.Digest
+}
+
+func (r indexRepository) FetchIndex(_ context.Context, _ ocispec.Descriptor) (ocispec.Index, error) {
+	return r.index, nil
+}
+
+func (r indexRepository) ListSignatures(ctx context.Context, desc ocispec.Descriptor, fn func(signatureManifests []ocispec.Descriptor) error) error {
+	if desc.Digest == r.unsigned {
+		return nil
+	}
+	return r.Repository.ListSignatures(ctx, desc, fn)
+}
+
+func TestVerifyPlatforms(t *testing.T) {
+	policyDocument := dummyPolicyDocument()
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+	amd64 := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("amd64"),
+		Size:      5,
+		Platform:  &ocispec.Platform{OS: "linux", Architecture: "amd64"},
+	}
+	arm64 := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("arm64"),
+		Size:      5,
+		Platform:  &ocispec.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"},
+	}
+	index := ocispec.Index{Manifests: []ocispec.Descriptor{amd64, arm64}}
+
+	t.Run("all platforms signed", func(t *testing.T) {
+		repo := indexRepository{Repository: mock.NewRepository(), index: index}
+		opts := VerifyOptions{
+			ArtifactReference:    mock.SampleArtifactUri,
+			MaxSignatureAttempts: 50,
+			VerifyPlatforms:      []ocispec.Platform{{OS: "linux", Architecture: "amd64"}, {OS: "linux", Architecture: "arm64"}},
+		}
+		desc, outcomes, err := Verify(context.Background(), &verifier, repo, opts)
+		if err != nil {
+			t.Fatalf("expected nil error, but got: %v", err)
+		}
+		if desc.Digest != mock.ImageDescriptor.Digest {
+			t.Fatalf("expected descriptor of the index %v, got %v", mock.ImageDescriptor.Digest, desc.Digest)
+		}
+		if len(outcomes) != 3 {
+			t.Fatalf("expected 3 outcomes, but got %d", len(outcomes))
+		}
+		wantPlatforms := []*ocispec.Platform{nil, amd64.Platform, arm64.Platform}
+		for i, outcome := range outcomes {
+			if outcome.Platform != wantPlatforms[i] {
+				t.Fatalf("expected outcome %d to have platform %v, got %v", i, wantPlatforms[i], outcome.Platform)
+			}
+		}
+	})
+
+	t.Run("unsigned platform", func(t *testing.T) {
+		repo := indexRepository{Repository: mock.NewRepository(), index: index, unsigned: arm64.Digest}
+		opts := VerifyOptions{
+			ArtifactReference:    mock.SampleArtifactUri,
+			MaxSignatureAttempts: 50,
+			VerifyPlatforms:      []ocispec.Platform{{OS: "linux", Architecture: "arm64", Variant: "v8"}},
+		}
+		_, _, err := Verify(context.Background(), &verifier, repo, opts)
+		var retrievalErr ErrorSignatureRetrievalFailed
+		if !errors.As(err, &retrievalErr) {
+			t.Fatalf("expected ErrorSignatureRetrievalFailed, got %v", err)
+		}
+		expectedErrMsg := fmt.Sprintf("failed to verify platform linux/arm64/v8 manifest %v: no signature is associated with %q, make sure the artifact was signed successfully", arm64.Digest, arm64.Digest)
+		if err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, got %q", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("platform not found", func(t *testing.T) {
+		repo := indexRepository{Repository: mock.NewRepository(), index: index}
+		opts := VerifyOptions{
+			ArtifactReference:    mock.SampleArtifactUri,
+			MaxSignatureAttempts: 50,
+			VerifyPlatforms:      []ocispec.Platform{{OS: "linux", Architecture: "arm64", Variant: "v7"}},
+		}
+		_, _, err := Verify(context.Background(), &verifier, repo, opts)
+		expectedErrMsg := fmt.Sprintf("platform linux/arm64/v7 is not found in image index %v", mock.ImageDescriptor.Digest)
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, got %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("repository without image index support", func(t *testing.T) {
+		opts := VerifyOptions{
+			ArtifactReference:    mock.SampleArtifactUri,
+			MaxSignatureAttempts: 50,
+			VerifyPlatforms:      []ocispec.Platform{{OS: "linux", Architecture: "amd64"}},
+		}
+		_, _, err := Verify(context.Background(), &verifier, mock.NewRepository(), opts)
+		expectedErrMsg := "verifyOptions.VerifyPlatforms requires a repository that supports fetching image indexes"
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, got %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("invalid platform", func(t *testing.T) {
+		repo := indexRepository{Repository: mock.NewRepository(), index: index}
+		opts := VerifyOptions{
+			ArtifactReference:    mock.SampleArtifactUri,
+			MaxSignatureAttempts: 50,
+			VerifyPlatforms:      []ocispec.Platform{{OS: "linux"}},
+		}
+		_, _, err := Verify(context.Background(), &verifier, repo, opts)
+		expectedErrMsg := `verifyOptions.VerifyPlatforms expects platforms with both OS and architecture, got "linux/"`
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, got %v", expectedErrMsg, err)
+		}
+	})
+}
+
+func TestVerifyIndex(t *testing.T) {
+	policyDocument := dummyPolicyDocument()
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+	amd64 := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("amd64"),
+		Size:      5,
+		Platform:  &ocispec.Platform{OS: "linux", Architecture: "amd64"},
+	}
+	attestation := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromString("attestation"),
+		Size:      11,
+	}
+	index := ocispec.Index{Manifests: []ocispec.Descriptor{amd64, attestation}}
+	opts := VerifyOptions{MaxSignatureAttempts: 50}
+
+	t.Run("all manifests signed", func(t *testing.T) {
+		repo := indexRepository{Repository: mock.NewRepository(), index: index}
+		results, err := VerifyIndex(context.Background(), &verifier, repo, mock.SampleArtifactUri, opts)
+		if err != nil {
+			t.Fatalf("expected nil error, but got: %v", err)
+		}
+		for _, d := range []digest.Digest{mock.ImageDescriptor.Digest, amd64.Digest, attestation.Digest} {
+			if len(results[d]) != 1 {
+				t.Fatalf("expected 1 outcome for %v, got %d", d, len(results[d]))
+			}
+		}
+		if len(results) != 3 {
+			t.Fatalf("expected outcomes for 3 digests, got %d", len(results))
+		}
+	})
+
+	t.Run("unsigned manifest", func(t *testing.T) {
+		repo := indexRepository{Repository: mock.NewRepository(), index: index, unsigned: attestation.Digest}
+		results, err := VerifyIndex(context.Background(), &verifier, repo, mock.SampleArtifactUri, opts)
+		var retrievalErr ErrorSignatureRetrievalFailed
+		if !errors.As(err, &retrievalErr) {
+			t.Fatalf("expected ErrorSignatureRetrievalFailed, got %v", err)
+		}
+		expectedErrMsg := fmt.Sprintf("failed to verify manifest %v of image index %v: no signature is associated with %q, make sure the artifact was signed successfully", attestation.Digest, mock.ImageDescriptor.Digest, attestation.Digest)
+		if err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, got %q", expectedErrMsg, err)
+		}
+		if len(results[mock.ImageDescriptor.Digest]) != 1 || len(results[amd64.Digest]) != 1 {
+			t.Fatalf("expected the outcomes collected before the failure, got %v", results)
+		}
+	})
+
+	t.Run("skip verification", func(t *testing.T) {
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelSkip, true}
+		repo := indexRepository{Repository: mock.NewRepository(), index: index}
+		results, err := VerifyIndex(context.Background(), &verifier, repo, mock.SampleArtifactUri, opts)
+		if err != nil {
+			t.Fatalf("expected nil error, but got: %v", err)
+		}
+		if len(results) != 3 {
+			t.Fatalf("expected outcomes for 3 digests, got %d", len(results))
+		}
+		for d, outcomes := range results {
+			if len(outcomes) != 1 || !outcomes[0].Skipped() {
+				t.Fatalf("expected a skipped outcome for %v, got %v", d, outcomes)
+			}
+		}
+	})
+
+	t.Run("repository without image index support", func(t *testing.T) {
+		_, err := VerifyIndex(context.Background(), &verifier, mock.NewRepository(), mock.SampleArtifactUri, opts)
+		expectedErrMsg := "VerifyIndex requires a repository that supports fetching image indexes"
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, got %v", expectedErrMsg, err)
+		}
+	})
 }
 
-func TestSkippedSignatureVerification(t *testing.T) {
+func TestVerifySkip(t *testing.T) {
 	repo := mock.NewRepository()
 	policyDocument := dummyPolicyDocument()
-	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelSkip, false}
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, true}
 
+	// mock the repository
 	opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
 	_, outcomes, err := Verify(context.Background(), &verifier, repo, opts)
 
-	if err != nil || outcomes[0].VerificationLevel.Name != trustpolicy.LevelSkip.Name {
-		t.Fatalf("\"skip\" verification level must pass overall signature verification")
+	if err != nil {
+		t.Fatalf("expected nil error, but got: %v", err)
+	}
+	if !outcomes[0].Skipped() {
+		t.Fatal("expected outcome to be skipped")
+	}
+	if outcomes[0].SkipReason != SkipReasonVerificationLevel {
+		t.Fatalf("expected skip reason %q, but got %q", SkipReasonVerificationLevel, outcomes[0].SkipReason)
 	}
 }
 
-func TestRegistryNoSignatureManifests(t *testing.T) {
+func TestVerifyAcceptedSignatureMediaTypes(t *testing.T) {
 	repo := mock.NewRepository()
 	policyDocument := dummyPolicyDocument()
 	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
-	errorMessage := fmt.Sprintf("no signature is associated with %q, make sure the artifact was signed successfully", mock.SampleArtifactUri)
-	expectedErr := ErrorSignatureRetrievalFailed{Msg: errorMessage}
 
-	// mock the repository
-	repo.ListSignaturesResponse = []ocispec.Descriptor{}
-	opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
-	_, _, err := Verify(context.Background(), &verifier, repo, opts)
+	t.Run("accepted", func(t *testing.T) {
+		opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50, AcceptedSignatureMediaTypes: []string{jws.MediaTypeEnvelope}}
+		if _, _, err := Verify(context.Background(), &verifier, repo, opts); err != nil {
+			t.Fatalf("expected nil error, but got: %v", err)
+		}
+	})
 
-	if err == nil || !errors.Is(err, expectedErr) {
-		t.Fatalf("RegistryNoSignatureManifests expected: %v got: %v", expectedErr, err)
-	}
+	t.Run("not accepted", func(t *testing.T) {
+		opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50, AcceptedSignatureMediaTypes: []string{cose.MediaTypeEnvelope}}
+		_, _, err := Verify(context.Background(), &verifier, repo, opts)
+		if err == nil || !errors.Is(err, ErrorVerificationFailed{}) {
+			t.Fatalf("VerificationFailed expected: %v got: %v", ErrorVerificationFailed{}, err)
+		}
+		expectedMsg := fmt.Sprintf("signature with digest %v was skipped, signature media type %q is not accepted", mock.SampleDigest, jws.MediaTypeEnvelope)
+		if !strings.Contains(err.Error(), expectedMsg) {
+			t.Fatalf("expected error to contain %q, got %q", expectedMsg, err)
+		}
+	})
+
+	t.Run("invalid media type", func(t *testing.T) {
+		opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50, AcceptedSignatureMediaTypes: []string{"invalid"}}
+		expectedErr := `verifyOptions.AcceptedSignatureMediaTypes contains invalid signature media-type "invalid"`
+		if _, _, err := Verify(context.Background(), &verifier, repo, opts); err == nil || err.Error() != expectedErr {
+			t.Fatalf("expected error %q, but got: %v", expectedErr, err)
+		}
+	})
 }
 
-func TestRegistryFetchSignatureBlobError(t *testing.T) {
+func TestVerifyInconsistentSignatureMediaType(t *testing.T) {
 	repo := mock.NewRepository()
+	// a COSE_Sign1 envelope fetched with the JWS media type
+	repo.FetchSignatureBlobResponse = []byte{0xd2, 0x84}
 	policyDocument := dummyPolicyDocument()
 	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
-	errorMessage := fmt.Sprintf("unable to retrieve digital signature with digest %q associated with %q from the Repository, error : network error", mock.SampleDigest, mock.SampleArtifactUri)
-	expectedErr := ErrorSignatureRetrievalFailed{Msg: errorMessage}
+	expectedErr := ErrorInvalidSignatureMediaType{MediaType: jws.MediaTypeEnvelope, DetectedMediaType: cose.MediaTypeEnvelope}
 
-	// mock the repository
-	repo.FetchSignatureBlobError = errors.New("network error")
-	opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
-	_, _, err := Verify(context.Background(), &verifier, repo, opts)
+	t.Run("Verify", func(t *testing.T) {
+		opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
+		_, _, err := Verify(context.Background(), &verifier, repo, opts)
+		if err == nil || !errors.Is(err, ErrorVerificationFailed{}) {
+			t.Fatalf("VerificationFailed expected: %v got: %v", ErrorVerificationFailed{}, err)
+		}
+		var mediaTypeErr ErrorInvalidSignatureMediaType
+		if !errors.As(err, &mediaTypeErr) || mediaTypeErr != expectedErr {
+			t.Fatalf("expected error to wrap %v, got %v", expectedErr, err)
+		}
+	})
 
-	if err == nil || !errors.Is(err, expectedErr) {
-		t.Fatalf("RegistryFetchSignatureBlob expected: %v got: %v", expectedErr, err)
-	}
+	t.Run("VerifySpecificSignature", func(t *testing.T) {
+		_, err := VerifySpecificSignature(context.Background(), &verifier, repo, mock.SampleArtifactUri, mock.SigManfiestDescriptor.Digest, VerifySpecificSignatureOptions{})
+		if err == nil || !errors.Is(err, ErrorVerificationFailed{}) {
+			t.Fatalf("VerificationFailed expected: %v got: %v", ErrorVerificationFailed{}, err)
+		}
+		var mediaTypeErr ErrorInvalidSignatureMediaType
+		if !errors.As(err, &mediaTypeErr) || mediaTypeErr != expectedErr {
+			t.Fatalf("expected error to wrap %v, got %v", expectedErr, err)
+		}
+	})
 }
 
-func TestVerifyValid(t *testing.T) {
-	repo := mock.NewRepository()
+func TestVerifyPrefetchSignaturePages(t *testing.T) {
+	policyDocument := dummyPolicyDocument()
+	listErr := errors.New("failed to list page")
+
+	t.Run("success", func(t *testing.T) {
+		repo := &pagedRepository{
+			Repository: mock.NewRepository(),
+			pages:      [][]ocispec.Descriptor{{mock.SigManfiestDescriptor}, {mock.SigManfiestDescriptor}, {mock.SigManfiestDescriptor}},
+		}
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+		opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50, PrefetchSignaturePages: 2}
+		_, outcomes, err := Verify(context.Background(), &verifier, repo, opts)
+		if err != nil {
+			t.Fatalf("expected nil error, but got: %v", err)
+		}
+		if len(outcomes) != 1 {
+			t.Fatalf("expected 1 outcome, but got %d", len(outcomes))
+		}
+	})
+
+	t.Run("max signature attempts", func(t *testing.T) {
+		repo := &pagedRepository{
+			Repository: mock.NewRepository(),
+			pages:      [][]ocispec.Descriptor{{mock.SigManfiestDescriptor}, {mock.SigManfiestDescriptor}, {mock.SigManfiestDescriptor}},
+		}
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, true, *trustpolicy.LevelStrict, false}
+		var processed int
+		opts := VerifyOptions{
+			ArtifactReference:      mock.SampleArtifactUri,
+			MaxSignatureAttempts:   2,
+			PrefetchSignaturePages: 1,
+			ProgressFunc: func(p, _ int, _ ocispec.Descriptor) {
+				processed = p
+			},
+		}
+		_, _, err := Verify(context.Background(), &verifier, repo, opts)
+		expectedErrMsg := "signature evaluation stopped. The configured limit of 2 signatures to verify per artifact exceeded"
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, but got: %v", expectedErrMsg, err)
+		}
+		if processed != 2 {
+			t.Fatalf("expected 2 signatures to be processed, but got %d", processed)
+		}
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		repo := &pagedRepository{
+			Repository: mock.NewRepository(),
+			pages:      [][]ocispec.Descriptor{{mock.SigManfiestDescriptor}},
+			err:        listErr,
+		}
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, true, *trustpolicy.LevelStrict, false}
+		opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50, PrefetchSignaturePages: 2}
+		if _, _, err := Verify(context.Background(), &verifier, repo, opts); !errors.Is(err, listErr) {
+			t.Fatalf("expected error %v, but got: %v", listErr, err)
+		}
+	})
+
+	t.Run("negative prefetch", func(t *testing.T) {
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+		opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50, PrefetchSignaturePages: -1}
+		expectedErrMsg := "verifyOptions.PrefetchSignaturePages expects a non-negative number, got -1"
+		if _, _, err := Verify(context.Background(), &verifier, mock.NewRepository(), opts); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, but got: %v", expectedErrMsg, err)
+		}
+	})
+}
+
+func TestVerifyNegativeRevocationDataMaxAge(t *testing.T) {
 	policyDocument := dummyPolicyDocument()
 	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+	opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50, RevocationDataMaxAge: -time.Hour}
+	expectedErrMsg := "verifyOptions.RevocationDataMaxAge expects a non-negative duration, got -1h0m0s"
+	if _, _, err := Verify(context.Background(), &verifier, mock.NewRepository(), opts); err == nil || err.Error() != expectedErrMsg {
+		t.Fatalf("expected error %q, but got: %v", expectedErrMsg, err)
+	}
+}
 
-	// mock the repository
-	opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
-	_, _, err := Verify(context.Background(), &verifier, repo, opts)
+func TestVerifySpecificSignatureRevocationData(t *testing.T) {
+	t.Run("negative max age", func(t *testing.T) {
+		policyDocument := dummyPolicyDocument()
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+		opts := VerifySpecificSignatureOptions{RevocationDataMaxAge: -time.Hour}
+		expectedErrMsg := "verifyOptions.RevocationDataMaxAge expects a non-negative duration, got -1h0m0s"
+		if _, err := VerifySpecificSignature(context.Background(), &verifier, mock.NewRepository(), mock.SampleArtifactUri, mock.SigManfiestDescriptor.Digest, opts); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, but got: %v", expectedErrMsg, err)
+		}
+	})
 
-	if err != nil {
-		t.Fatalf("expected nil error, but got: %v", err)
+	t.Run("forwarded to the verifier", func(t *testing.T) {
+		verifier := &optionsRecordingVerifier{}
+		provider := &dummyRevocationData{}
+		opts := VerifySpecificSignatureOptions{RevocationData: provider, RevocationDataMaxAge: time.Hour}
+		if _, err := VerifySpecificSignature(context.Background(), verifier, mock.NewRepository(), mock.SampleArtifactUri, mock.SigManfiestDescriptor.Digest, opts); err != nil {
+			t.Fatalf("VerifySpecificSignature failed with error: %v", err)
+		}
+		if verifier.opts.RevocationData != provider || verifier.opts.RevocationDataMaxAge != time.Hour {
+			t.Fatalf("expected revocation data options to be forwarded, got %+v", verifier.opts)
+		}
+	})
+}
+
+// pagedRepository lists the signature manifests in pages, followed by err.
+type pagedRepository struct {
+	mock.Repository
+	pages [][]ocispec.Descriptor
+	err   error
+}
+
+func (r *pagedRepository) ListSignatures(ctx context.Context, desc ocispec.Descriptor, fn func(signatureManifests []ocispec.Descriptor) error) error {
+	for _, page := range r.pages {
+		if err := fn(page); err != nil {
+			return err
+		}
 	}
+	return r.err
 }
 
-func TestVerifySkip(t *testing.T) {
+func TestVerifyAggregateErrors(t *testing.T) {
 	repo := mock.NewRepository()
+	repo.ExceededNumOfSignatures = true
 	policyDocument := dummyPolicyDocument()
-	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, true}
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, true, *trustpolicy.LevelStrict, false}
 
-	// mock the repository
-	opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
-	_, _, err := Verify(context.Background(), &verifier, repo, opts)
+	t.Run("default", func(t *testing.T) {
+		opts := VerifyOptions{ArtifactReference: mock.SampleArtifactUri, MaxSignatureAttempts: 50}
+		_, _, err := Verify(context.Background(), &verifier, repo, opts)
+		if !errors.Is(err, ErrorVerificationFailed{}) {
+			t.Fatalf("VerificationFailed expected: %v got: %v", ErrorVerificationFailed{}, err)
+		}
+		joinedErr, ok := err.(interface{ Unwrap() []error })
+		if !ok {
+			t.Fatalf("expected a joined error, got %T", err)
+		}
+		// ErrorVerificationFailed followed by the error of each signature
+		if got := len(joinedErr.Unwrap()); got != 3 {
+			t.Fatalf("expected 3 errors, got %d: %v", got, err)
+		}
+	})
 
-	if err != nil {
-		t.Fatalf("expected nil error, but got: %v", err)
+	t.Run("custom", func(t *testing.T) {
+		var aggregated []error
+		opts := VerifyOptions{
+			ArtifactReference:    mock.SampleArtifactUri,
+			MaxSignatureAttempts: 50,
+			AggregateErrors: func(errs []error) error {
+				aggregated = errs
+				return errs[len(errs)-1]
+			},
+		}
+		_, _, err := Verify(context.Background(), &verifier, repo, opts)
+		if !errors.Is(err, ErrorVerificationFailed{}) {
+			t.Fatalf("VerificationFailed expected: %v got: %v", ErrorVerificationFailed{}, err)
+		}
+		if len(aggregated) != 2 {
+			t.Fatalf("expected the errors of 2 signatures to be aggregated, got %d", len(aggregated))
+		}
+		expectedErr := errors.Join(ErrorVerificationFailed{}, aggregated[1])
+		if err.Error() != expectedErr.Error() {
+			t.Fatalf("expected error %q, got %q", expectedErr, err)
+		}
+	})
+}
+
+func TestVerifyProgressFunc(t *testing.T) {
+	repo := mock.NewRepository()
+	repo.ExceededNumOfSignatures = true
+	policyDocument := dummyPolicyDocument()
+	verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, true, *trustpolicy.LevelStrict, false}
+
+	type progress struct {
+		processed int
+		total     int
+	}
+	var got []progress
+	opts := VerifyOptions{
+		ArtifactReference:    mock.SampleArtifactUri,
+		MaxSignatureAttempts: 2,
+		ProgressFunc: func(processed, total int, current ocispec.Descriptor) {
+			if current.Digest != mock.SampleDigest {
+				t.Errorf("expected current digest %v, got %v", mock.SampleDigest, current.Digest)
+			}
+			got = append(got, progress{processed, total})
+		},
+	}
+	Verify(context.Background(), &verifier, repo, opts)
+
+	want := []progress{{1, 2}, {2, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected progress %v, got %v", want, got)
 	}
 }
 
@@ -541,6 +1906,73 @@ func TestVerifyFailed(t *testing.T) {
 	})
 }
 
+func TestVerifySignature(t *testing.T) {
+	policyDocument := dummyPolicyDocument()
+	opts := VerifySignatureOptions{
+		VerifierVerifyOptions: VerifierVerifyOptions{
+			ArtifactReference:  mock.SampleArtifactUri,
+			SignatureMediaType: jws.MediaTypeEnvelope,
+		},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+		outcome, err := VerifySignature(context.Background(), &verifier, mock.ImageDescriptor, mock.MockCaValidSigEnv, opts)
+		if err != nil {
+			t.Fatalf("expected nil error, but got: %v", err)
+		}
+		if outcome.VerificationLevel.Name != trustpolicy.LevelStrict.Name {
+			t.Fatalf("expected verification level %q, but got %q", trustpolicy.LevelStrict.Name, outcome.VerificationLevel.Name)
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, true, *trustpolicy.LevelStrict, true}
+		outcome, err := VerifySignature(context.Background(), &verifier, mock.ImageDescriptor, mock.MockCaValidSigEnv, opts)
+		if err != nil {
+			t.Fatalf("expected nil error, but got: %v", err)
+		}
+		if outcome.SkipReason != SkipReasonVerificationLevel {
+			t.Fatalf("expected skip reason %q, but got %q", SkipReasonVerificationLevel, outcome.SkipReason)
+		}
+	})
+
+	t.Run("verification error", func(t *testing.T) {
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, true, *trustpolicy.LevelStrict, false}
+		_, err := VerifySignature(context.Background(), &verifier, mock.ImageDescriptor, mock.MockCaValidSigEnv, opts)
+		if err == nil || !errors.Is(err, ErrorVerificationFailed{}) {
+			t.Fatalf("VerificationFailed expected: %v got: %v", ErrorVerificationFailed{}, err)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		verifier := dummyVerifier{&policyDocument, mock.PluginManager{}, false, *trustpolicy.LevelStrict, false}
+		invalidMediaTypeOpts := opts
+		invalidMediaTypeOpts.SignatureMediaType = "invalid"
+		tests := []struct {
+			name     string
+			verifier Verifier
+			desc     ocispec.Descriptor
+			envelope []byte
+			opts     VerifySignatureOptions
+			wantErr  string
+		}{
+			{"nil verifier", nil, mock.ImageDescriptor, mock.MockCaValidSigEnv, opts, "verifier cannot be nil"},
+			{"invalid descriptor", &verifier, ocispec.Descriptor{}, mock.MockCaValidSigEnv, opts, "invalid artifact descriptor: invalid checksum digest format"},
+			{"empty envelope", &verifier, mock.ImageDescriptor, nil, opts, "signature envelope cannot be nil or empty"},
+			{"invalid media type", &verifier, mock.ImageDescriptor, mock.MockCaValidSigEnv, invalidMediaTypeOpts, `invalid signature media-type "invalid"`},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := VerifySignature(context.Background(), tt.verifier, tt.desc, tt.envelope, tt.opts)
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("VerifySignature() expected error %q, got %v", tt.wantErr, err)
+				}
+			})
+		}
+	})
+}
+
 func TestVerifyBlobError(t *testing.T) {
 	reader := strings.NewReader("some content")
 	sig := []byte("signature")
@@ -560,6 +1992,7 @@ func TestVerifyBlobError(t *testing.T) {
 		{"nilReader", &dummyVerifier{}, sig, nil, "video/mp4", jws.MediaTypeEnvelope, "blobReader cannot be nil"},
 		{"invalidContentType", &dummyVerifier{}, sig, reader, "video/mp4/zoping", jws.MediaTypeEnvelope, "invalid content media-type \"video/mp4/zoping\": mime: unexpected content after media subtype"},
 		{"invalidSigType", &dummyVerifier{}, sig, reader, "video/mp4", "hola!", "invalid signature media-type \"hola!\""},
+		{"undetectedSigType", &dummyVerifier{}, sig, reader, "video/mp4", "", "unable to detect the signature envelope media type, the signature is neither a JWS nor a COSE_Sign1 envelope"},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -598,6 +2031,53 @@ func TestVerifyBlobValid(t *testing.T) {
 	}
 }
 
+func TestVerifyBlobDetectSignatureMediaType(t *testing.T) {
+	_, _, err := VerifyBlob(context.Background(), &dummyVerifier{}, strings.NewReader("some content"), []byte(`{"payload":""}`), VerifyBlobOptions{})
+	if err != nil {
+		t.Fatalf("expected nil error, but got: %v", err)
+	}
+}
+
+func TestDetectEnvelopeMediaType(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      []byte
+		mediaType string
+		wantErr   bool
+	}{
+		{
+			name:      "jws envelope",
+			data:      []byte(`{"payload":"","protected":"","signature":""}`),
+			mediaType: jws.MediaTypeEnvelope,
+		},
+		{
+			name:      "cose envelope",
+			data:      []byte{0xd2, 0x84, 0x40},
+			mediaType: cose.MediaTypeEnvelope,
+		},
+		{
+			name:    "unrecognized envelope",
+			data:    []byte("signature"),
+			wantErr: true,
+		},
+		{
+			name:    "empty envelope",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mediaType, err := DetectEnvelopeMediaType(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DetectEnvelopeMediaType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if mediaType != tt.mediaType {
+				t.Fatalf("DetectEnvelopeMediaType() = %q, want %q", mediaType, tt.mediaType)
+			}
+		})
+	}
+}
+
 func dummyPolicyDocument() (policyDoc trustpolicy.Document) {
 	policyDoc = trustpolicy.Document{
 		Version:       "1.0",
@@ -680,11 +2160,77 @@ func (v *dummyVerifier) Verify(_ context.Context, _ ocispec.Descriptor, _ []byte
 	return outcome, nil
 }
 
+// signerInfoVerifier returns successful outcomes of signatures signed at
+// signingTime with certChain.
+type signerInfoVerifier struct {
+	signingTime time.Time
+	certChain   []*x509.Certificate
+	annotations map[string]string
+}
+
+func (v *signerInfoVerifier) Verify(_ context.Context, _ ocispec.Descriptor, _ []byte, _ VerifierVerifyOptions) (*VerificationOutcome, error) {
+	payload, err := json.Marshal(envelope.Payload{TargetArtifact: ocispec.Descriptor{Annotations: v.annotations}})
+	if err != nil {
+		return nil, err
+	}
+	return &VerificationOutcome{
+		VerificationLevel: trustpolicy.LevelStrict,
+		EnvelopeContent: &signature.EnvelopeContent{
+			Payload: signature.Payload{ContentType: envelope.MediaTypePayloadV1, Content: payload},
+			SignerInfo: signature.SignerInfo{
+				SignedAttributes: signature.SignedAttributes{SigningTime: v.signingTime},
+				CertificateChain: v.certChain,
+			},
+		},
+	}, nil
+}
+
+// chainValidatingVerifier is a signerInfoVerifier running the
+// CertificateChainValidator of the verify options, as the verifier package
+// does.
+type chainValidatingVerifier struct {
+	signerInfoVerifier
+}
+
+func (v *chainValidatingVerifier) Verify(ctx context.Context, desc ocispec.Descriptor, signature []byte, opts VerifierVerifyOptions) (*VerificationOutcome, error) {
+	outcome, err := v.signerInfoVerifier.Verify(ctx, desc, signature, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.CertificateChainValidator != nil {
+		if err := opts.CertificateChainValidator(v.certChain); err != nil {
+			return outcome, err
+		}
+	}
+	return outcome, nil
+}
+
+// optionsRecordingVerifier records the options of the last verification.
+type optionsRecordingVerifier struct {
+	opts VerifierVerifyOptions
+}
+
+func (v *optionsRecordingVerifier) Verify(_ context.Context, _ ocispec.Descriptor, _ []byte, opts VerifierVerifyOptions) (*VerificationOutcome, error) {
+	v.opts = opts
+	return &VerificationOutcome{VerificationLevel: trustpolicy.LevelStrict}, nil
+}
+
+// dummyRevocationData is a RevocationDataProvider without revocation data.
+type dummyRevocationData struct{}
+
+func (*dummyRevocationData) CRL(_ context.Context, _ *x509.Certificate) ([]byte, error) {
+	return nil, errors.New("CRL not found")
+}
+
+func (*dummyRevocationData) OCSPResponse(_ context.Context, _, _ *x509.Certificate) ([]byte, error) {
+	return nil, errors.New("OCSP response not found")
+}
+
 func (v *dummyVerifier) SkipVerify(_ context.Context, _ VerifierVerifyOptions) (bool, *trustpolicy.VerificationLevel, error) {
 	if v.SkipVerification {
 		return true, nil, nil
 	}
-	return false, nil, nil
+	return false, &v.VerificationLevel, nil
 }
 
 func (v *dummyVerifier) VerifyBlob(_ context.Context, _ BlobDescriptorGenerator, _ []byte, _ BlobVerifierVerifyOptions) (*VerificationOutcome, error) {
@@ -838,3 +2384,97 @@ func TestUserMetadata(t *testing.T) {
 		}
 	})
 }
+
+func TestSigningCertificateChainAndSigningTime(t *testing.T) {
+	t.Run("EnvelopeContent is nil", func(t *testing.T) {
+		outcome := &VerificationOutcome{}
+		if _, err := outcome.SigningCertificateChain(); !errors.Is(err, ErrorEnvelopeContentNotFound{}) {
+			t.Fatalf("expected ErrorEnvelopeContentNotFound, got %v", err)
+		}
+		if _, err := outcome.SigningTime(); !errors.Is(err, ErrorEnvelopeContentNotFound{}) {
+			t.Fatalf("expected ErrorEnvelopeContentNotFound, got %v", err)
+		}
+	})
+
+	t.Run("SignerInfo is empty", func(t *testing.T) {
+		outcome := &VerificationOutcome{EnvelopeContent: &signature.EnvelopeContent{}}
+		if _, err := outcome.SigningCertificateChain(); err == nil || err.Error() != "certificate chain is not present in the signature envelope" {
+			t.Fatalf("expected missing certificate chain error, got %v", err)
+		}
+		if _, err := outcome.SigningTime(); err == nil || err.Error() != "signing time is not present in the signature envelope" {
+			t.Fatalf("expected missing signing time error, got %v", err)
+		}
+	})
+
+	t.Run("SignerInfo is valid", func(t *testing.T) {
+		signingTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		certChain := []*x509.Certificate{{Raw: []byte("leaf")}, {Raw: []byte("root")}}
+		outcome := &VerificationOutcome{
+			EnvelopeContent: &signature.EnvelopeContent{
+				SignerInfo: signature.SignerInfo{
+					SignedAttributes: signature.SignedAttributes{SigningTime: signingTime},
+					CertificateChain: certChain,
+				},
+			},
+		}
+		gotChain, err := outcome.SigningCertificateChain()
+		if err != nil {
+			t.Fatalf("unexpected error getting certificate chain: %v", err)
+		}
+		if !reflect.DeepEqual(gotChain, certChain) {
+			t.Fatalf("expected certificate chain %v, got %v", certChain, gotChain)
+		}
+		gotTime, err := outcome.SigningTime()
+		if err != nil {
+			t.Fatalf("unexpected error getting signing time: %v", err)
+		}
+		if !gotTime.Equal(signingTime) {
+			t.Fatalf("expected signing time %v, got %v", signingTime, gotTime)
+		}
+	})
+}
+
+func TestParseSignatureEnvelope(t *testing.T) {
+	t.Run("valid envelope", func(t *testing.T) {
+		content, err := ParseSignatureEnvelope(jws.MediaTypeEnvelope, mock.MockCaValidSigEnv)
+		if err != nil {
+			t.Fatalf("expected nil error, but got: %v", err)
+		}
+		if len(content.Payload.Content) == 0 || len(content.SignerInfo.CertificateChain) == 0 {
+			t.Fatalf("expected decoded payload and signer info, but got: %+v", content)
+		}
+	})
+
+	tests := []struct {
+		name      string
+		mediaType string
+		envelope  []byte
+		wantErr   string
+	}{
+		{
+			name:      "invalid media type",
+			mediaType: "application/json",
+			envelope:  mock.MockCaValidSigEnv,
+			wantErr:   `invalid signature media-type "application/json"`,
+		},
+		{
+			name:      "empty envelope",
+			mediaType: jws.MediaTypeEnvelope,
+			wantErr:   "signature envelope cannot be nil or empty",
+		},
+		{
+			name:      "malformed envelope",
+			mediaType: cose.MediaTypeEnvelope,
+			envelope:  mock.MockCaValidSigEnv,
+			wantErr:   "failed to parse signature envelope (no verification performed): ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSignatureEnvelope(tt.mediaType, tt.envelope)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error starting with %q, but got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
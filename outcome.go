@@ -0,0 +1,469 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notation
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Error types of the "error" object of the JSON encoding of
+// [VerificationOutcome] and [ValidationResult]. Errors of other types are
+// reported with [ErrorTypeGeneric].
+const (
+	ErrorTypeGeneric                        = "error"
+	ErrorTypeVerificationFailed             = "verificationFailed"
+	ErrorTypeVerificationInconclusive       = "verificationInconclusive"
+	ErrorTypeNoApplicableTrustPolicy        = "noApplicableTrustPolicy"
+	ErrorTypeSignatureRetrievalFailed       = "signatureRetrievalFailed"
+	ErrorTypeUserMetadataVerificationFailed = "userMetadataVerificationFailed"
+	ErrorTypeTargetArtifactMismatch         = "targetArtifactMismatch"
+	ErrorTypeCertificateExpired             = "certificateExpired"
+	ErrorTypeSignatureExpired               = "signatureExpired"
+	ErrorTypeSignatureTooOld                = "signatureTooOld"
+	ErrorTypeSigningTimeInFuture            = "signingTimeInFuture"
+	ErrorTypeInvalidSignatureMediaType      = "invalidSignatureMediaType"
+	ErrorTypeEnvelopeContentNotFound        = "envelopeContentNotFound"
+	ErrorTypePushSignatureFailed            = "pushSignatureFailed"
+)
+
+// errorJSON is the JSON encoding of an error.
+type errorJSON struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// newErrorJSON returns the JSON encoding of err, or nil if err is nil.
+func newErrorJSON(err error) *errorJSON {
+	if err == nil {
+		return nil
+	}
+	return &errorJSON{
+		Type:    errorType(err),
+		Message: err.Error(),
+	}
+}
+
+// errorType returns the error type of err, most specific first.
+func errorType(err error) string {
+	switch {
+	case errors.As(err, &ErrorCertificateExpired{}):
+		return ErrorTypeCertificateExpired
+	case errors.As(err, &ErrorSignatureExpired{}):
+		return ErrorTypeSignatureExpired
+	case errors.As(err, &ErrorSignatureTooOld{}):
+		return ErrorTypeSignatureTooOld
+	case errors.As(err, &ErrorSigningTimeInFuture{}):
+		return ErrorTypeSigningTimeInFuture
+	case errors.As(err, &ErrorInvalidSignatureMediaType{}):
+		return ErrorTypeInvalidSignatureMediaType
+	case errors.As(err, &ErrorUserMetadataVerificationFailed{}):
+		return ErrorTypeUserMetadataVerificationFailed
+	case errors.As(err, &ErrorTargetArtifactMismatch{}):
+		return ErrorTypeTargetArtifactMismatch
+	case errors.As(err, &ErrorEnvelopeContentNotFound{}):
+		return ErrorTypeEnvelopeContentNotFound
+	case errors.As(err, &ErrorNoApplicableTrustPolicy{}):
+		return ErrorTypeNoApplicableTrustPolicy
+	case errors.As(err, &ErrorSignatureRetrievalFailed{}):
+		return ErrorTypeSignatureRetrievalFailed
+	case errors.As(err, &ErrorPushSignatureFailed{}):
+		return ErrorTypePushSignatureFailed
+	case errors.As(err, &ErrorVerificationInconclusive{}):
+		return ErrorTypeVerificationInconclusive
+	case errors.As(err, &ErrorVerificationFailed{}):
+		return ErrorTypeVerificationFailed
+	default:
+		return ErrorTypeGeneric
+	}
+}
+
+// validationResultJSON is the JSON encoding of a [ValidationResult].
+type validationResultJSON struct {
+	Type   string     `json:"type"`
+	Action string     `json:"action"`
+	Error  *errorJSON `json:"error,omitempty"`
+}
+
+// MarshalJSON encodes the validation result as a JSON object of the form
+//
+//	{
+//	  "type": "authenticity",
+//	  "action": "enforce",
+//	  "error": {"type": "verificationFailed", "message": "..."}
+//	}
+//
+// where "error" is omitted if the validation succeeded. The "type" of the
+// error is one of the ErrorType constants.
+func (r ValidationResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(validationResultJSON{
+		Type:   string(r.Type),
+		Action: string(r.Action),
+		Error:  newErrorJSON(r.Error),
+	})
+}
+
+// signerJSON is the JSON encoding of the identity of a certificate, e.g. the
+// signing identity of a signature.
+type signerJSON struct {
+	Subject           string `json:"subject"`
+	Issuer            string `json:"issuer"`
+	SHA256Fingerprint string `json:"sha256Fingerprint"`
+}
+
+// newSignerJSON returns the JSON encoding of identity, or nil if identity is
+// nil.
+func newSignerJSON(identity *SigningIdentity) *signerJSON {
+	if identity == nil {
+		return nil
+	}
+	return &signerJSON{
+		Subject:           identity.Subject,
+		Issuer:            identity.Issuer,
+		SHA256Fingerprint: identity.SHA256Fingerprint,
+	}
+}
+
+// revocationEvidenceJSON is the JSON encoding of a [RevocationEvidence].
+type revocationEvidenceJSON struct {
+	Certificate      *signerJSON        `json:"certificate,omitempty"`
+	Result           string             `json:"result,omitempty"`
+	RevocationMethod string             `json:"revocationMethod,omitempty"`
+	ServerResults    []serverResultJSON `json:"serverResults,omitempty"`
+}
+
+// serverResultJSON is the JSON encoding of the revocation result of a single
+// OCSP responder or CRL distribution point.
+type serverResultJSON struct {
+	Server           string `json:"server,omitempty"`
+	Result           string `json:"result"`
+	RevocationMethod string `json:"revocationMethod"`
+	Error            string `json:"error,omitempty"`
+}
+
+// newRevocationEvidenceJSON returns the JSON encoding of evidence.
+func newRevocationEvidenceJSON(evidence []RevocationEvidence) []revocationEvidenceJSON {
+	if len(evidence) == 0 {
+		return nil
+	}
+	out := make([]revocationEvidenceJSON, 0, len(evidence))
+	for _, e := range evidence {
+		var evidenceJSON revocationEvidenceJSON
+		if e.Certificate != nil {
+			evidenceJSON.Certificate = newSignerJSON(certificateIdentity(e.Certificate))
+		}
+		if e.Result != nil {
+			evidenceJSON.Result = e.Result.Result.String()
+			evidenceJSON.RevocationMethod = e.Result.RevocationMethod.String()
+			for _, serverResult := range e.Result.ServerResults {
+				if serverResult == nil {
+					continue
+				}
+				serverJSON := serverResultJSON{
+					Server:           serverResult.Server,
+					Result:           serverResult.Result.String(),
+					RevocationMethod: serverResult.RevocationMethod.String(),
+				}
+				if serverResult.Error != nil {
+					serverJSON.Error = serverResult.Error.Error()
+				}
+				evidenceJSON.ServerResults = append(evidenceJSON.ServerResults, serverJSON)
+			}
+		}
+		out = append(out, evidenceJSON)
+	}
+	return out
+}
+
+// verificationOutcomeJSON is the JSON encoding of a [VerificationOutcome].
+type verificationOutcomeJSON struct {
+	VerificationLevel   string                   `json:"verificationLevel,omitempty"`
+	TrustPolicyName     string                   `json:"trustPolicyName,omitempty"`
+	Skipped             bool                     `json:"skipped"`
+	SkipReason          SkipReason               `json:"skipReason,omitempty"`
+	SigningScheme       string                   `json:"signingScheme,omitempty"`
+	PayloadVersion      int                      `json:"payloadVersion,omitempty"`
+	SigningTime         *time.Time               `json:"signingTime,omitempty"`
+	Expiry              *time.Time               `json:"expiry,omitempty"`
+	Signer              *signerJSON              `json:"signer,omitempty"`
+	PreviousSignature   digest.Digest            `json:"previousSignature,omitempty"`
+	Platform            *ocispec.Platform        `json:"platform,omitempty"`
+	RevocationEvidence  []revocationEvidenceJSON `json:"revocationEvidence,omitempty"`
+	VerificationResults []*ValidationResult      `json:"verificationResults"`
+	Error               *errorJSON               `json:"error,omitempty"`
+}
+
+// MarshalJSON encodes the verification outcome as a JSON object of the form
+//
+//	{
+//	  "verificationLevel": "strict",
+//	  "trustPolicyName": "wabbit-networks-images",
+//	  "skipped": false,
+//	  "signingScheme": "notary.x509",
+//	  "payloadVersion": 1,
+//	  "signingTime": "2024-06-18T07:30:31Z",
+//	  "expiry": "2024-06-19T07:30:31Z",
+//	  "signer": {
+//	    "subject": "CN=wabbit-networks.io,O=Notary,L=Seattle,ST=WA,C=US",
+//	    "issuer": "CN=wabbit-networks.io,O=Notary,L=Seattle,ST=WA,C=US",
+//	    "sha256Fingerprint": "..."
+//	  },
+//	  "revocationEvidence": [{
+//	    "certificate": {"subject": "...", "issuer": "...", "sha256Fingerprint": "..."},
+//	    "result": "OK",
+//	    "revocationMethod": "OCSP",
+//	    "serverResults": [{"server": "http://ocsp.example.com", "result": "OK", "revocationMethod": "OCSP"}]
+//	  }],
+//	  "verificationResults": [{"type": "integrity", "action": "enforce"}],
+//	  "error": {"type": "verificationFailed", "message": "..."}
+//	}
+//
+// where the signing identity summarizes the signing certificate, the
+// revocation evidence is encoded only if collected, and the verification
+// results are encoded as in [ValidationResult.MarshalJSON].
+// Empty fields are omitted, except for "skipped" and "verificationResults".
+// The signature envelope itself is not encoded.
+func (outcome VerificationOutcome) MarshalJSON() ([]byte, error) {
+	out := verificationOutcomeJSON{
+		TrustPolicyName:     outcome.TrustPolicyName,
+		Skipped:             outcome.Skipped(),
+		SkipReason:          outcome.SkipReason,
+		PayloadVersion:      outcome.PayloadVersion,
+		PreviousSignature:   outcome.PreviousSignature,
+		Platform:            outcome.Platform,
+		RevocationEvidence:  newRevocationEvidenceJSON(outcome.RevocationEvidence),
+		VerificationResults: outcome.VerificationResults,
+		Error:               newErrorJSON(outcome.Error),
+	}
+	if out.VerificationResults == nil {
+		out.VerificationResults = []*ValidationResult{}
+	}
+	if outcome.VerificationLevel != nil {
+		out.VerificationLevel = outcome.VerificationLevel.Name
+	}
+	if outcome.EnvelopeContent != nil {
+		signerInfo := outcome.EnvelopeContent.SignerInfo
+		out.SigningScheme = string(signerInfo.SignedAttributes.SigningScheme)
+		if signingTime := signerInfo.SignedAttributes.SigningTime; !signingTime.IsZero() {
+			out.SigningTime = &signingTime
+		}
+		if expiry := signerInfo.SignedAttributes.Expiry; !expiry.IsZero() {
+			out.Expiry = &expiry
+		}
+		out.Signer = newSignerJSON(signingIdentity(&outcome))
+	}
+	return json.Marshal(out)
+}
+
+// OutcomeDiff is the difference between two verification outcomes of a
+// signature, as returned by [DiffOutcomes]. Unchanged fields are nil.
+type OutcomeDiff struct {
+	// VerificationLevel is the change of the name of the verification level.
+	VerificationLevel *FieldChange
+
+	// TrustPolicyName is the change of the applied trust policy statement.
+	TrustPolicyName *FieldChange
+
+	// SkipReason is the change of the reason why the verification was
+	// skipped.
+	SkipReason *FieldChange
+
+	// SigningIdentity is the change of the signing certificate.
+	SigningIdentity *SigningIdentityChange
+
+	// Error is the change of the message of the error that caused the
+	// verification to fail.
+	Error *FieldChange
+
+	// VerificationResults are the changes of the verification results, at
+	// most one per verification type, in the order of the verification
+	// results of the new outcome followed by the removed ones.
+	VerificationResults []ValidationResultChange
+}
+
+// Empty reports whether the outcomes are identical as far as compared by
+// [DiffOutcomes].
+func (d OutcomeDiff) Empty() bool {
+	return d.VerificationLevel == nil &&
+		d.TrustPolicyName == nil &&
+		d.SkipReason == nil &&
+		d.SigningIdentity == nil &&
+		d.Error == nil &&
+		len(d.VerificationResults) == 0
+}
+
+// FieldChange is the change of a field of a [VerificationOutcome]. An empty
+// value means that the field is not set.
+type FieldChange struct {
+	Old string
+	New string
+}
+
+// SigningIdentity summarizes the signing certificate of a signature.
+type SigningIdentity struct {
+	Subject           string
+	Issuer            string
+	SHA256Fingerprint string
+}
+
+// SigningIdentityChange is the change of the signing certificate of a
+// signature. Old or New is nil if the outcome has no signing certificate,
+// e.g. because the verification was skipped.
+type SigningIdentityChange struct {
+	Old *SigningIdentity
+	New *SigningIdentity
+}
+
+// ValidationResultChange is the change of the verification result of a
+// verification type. Old or New is nil if the verification was not
+// performed, e.g. because it is skipped by the verification level.
+type ValidationResultChange struct {
+	Type trustpolicy.ValidationType
+	Old  *ValidationResult
+	New  *ValidationResult
+}
+
+// DiffOutcomes compares the verification outcomes old and new of a
+// signature, e.g. of the verifications before and after a certificate
+// rotation or a trust policy change, for auditing.
+// The verification level is compared by name, the signing identity by the
+// fingerprint of the signing certificate, and the errors by message. The
+// verification results are compared by action and error per verification
+// type. A nil outcome is compared as an empty one.
+func DiffOutcomes(old, new *VerificationOutcome) OutcomeDiff {
+	if old == nil {
+		old = &VerificationOutcome{}
+	}
+	if new == nil {
+		new = &VerificationOutcome{}
+	}
+
+	var diff OutcomeDiff
+	diff.VerificationLevel = diffField(verificationLevelName(old), verificationLevelName(new))
+	diff.TrustPolicyName = diffField(old.TrustPolicyName, new.TrustPolicyName)
+	diff.SkipReason = diffField(string(old.SkipReason), string(new.SkipReason))
+	diff.Error = diffField(errorMessage(old.Error), errorMessage(new.Error))
+
+	oldIdentity, newIdentity := signingIdentity(old), signingIdentity(new)
+	if oldIdentity.fingerprint() != newIdentity.fingerprint() {
+		diff.SigningIdentity = &SigningIdentityChange{Old: oldIdentity, New: newIdentity}
+	}
+
+	oldResults := validationResultsByType(old.VerificationResults)
+	newResults := validationResultsByType(new.VerificationResults)
+	for _, result := range new.VerificationResults {
+		if result == nil || newResults[result.Type] != result {
+			// not the first result of its type
+			continue
+		}
+		oldResult := oldResults[result.Type]
+		if !equalValidationResults(oldResult, result) {
+			diff.VerificationResults = append(diff.VerificationResults, ValidationResultChange{Type: result.Type, Old: oldResult, New: result})
+		}
+	}
+	for _, result := range old.VerificationResults {
+		if result == nil || oldResults[result.Type] != result {
+			continue
+		}
+		if _, ok := newResults[result.Type]; !ok {
+			diff.VerificationResults = append(diff.VerificationResults, ValidationResultChange{Type: result.Type, Old: result})
+		}
+	}
+	return diff
+}
+
+// diffField returns the change from old to new, or nil if they are equal.
+func diffField(old, new string) *FieldChange {
+	if old == new {
+		return nil
+	}
+	return &FieldChange{Old: old, New: new}
+}
+
+// verificationLevelName returns the name of the verification level of
+// outcome, or an empty string if it is not set.
+func verificationLevelName(outcome *VerificationOutcome) string {
+	if outcome.VerificationLevel == nil {
+		return ""
+	}
+	return outcome.VerificationLevel.Name
+}
+
+// errorMessage returns the message of err, or an empty string if err is nil.
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// signingIdentity returns the signing identity of outcome, or nil if the
+// outcome has no signing certificate.
+func signingIdentity(outcome *VerificationOutcome) *SigningIdentity {
+	if outcome.EnvelopeContent == nil || len(outcome.EnvelopeContent.SignerInfo.CertificateChain) == 0 {
+		return nil
+	}
+	return certificateIdentity(outcome.EnvelopeContent.SignerInfo.CertificateChain[0])
+}
+
+// certificateIdentity returns the identity of cert.
+func certificateIdentity(cert *x509.Certificate) *SigningIdentity {
+	fingerprint := sha256.Sum256(cert.Raw)
+	return &SigningIdentity{
+		Subject:           cert.Subject.String(),
+		Issuer:            cert.Issuer.String(),
+		SHA256Fingerprint: hex.EncodeToString(fingerprint[:]),
+	}
+}
+
+// fingerprint returns the SHA-256 fingerprint of the signing certificate, or
+// an empty string if identity is nil.
+func (identity *SigningIdentity) fingerprint() string {
+	if identity == nil {
+		return ""
+	}
+	return identity.SHA256Fingerprint
+}
+
+// validationResultsByType returns the first verification result of each
+// verification type.
+func validationResultsByType(results []*ValidationResult) map[trustpolicy.ValidationType]*ValidationResult {
+	byType := make(map[trustpolicy.ValidationType]*ValidationResult, len(results))
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		if _, ok := byType[result.Type]; !ok {
+			byType[result.Type] = result
+		}
+	}
+	return byType
+}
+
+// equalValidationResults reports whether the verification results a and b
+// have the same action and error.
+func equalValidationResults(a, b *ValidationResult) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Action == b.Action && errorMessage(a.Error) == errorMessage(b.Error)
+}
@@ -0,0 +1,249 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notation
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	revocationresult "github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+)
+
+func TestValidationResultMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		result ValidationResult
+		want   string
+	}{
+		{
+			name:   "success",
+			result: ValidationResult{Type: trustpolicy.TypeIntegrity, Action: trustpolicy.ActionEnforce},
+			want:   `{"type":"integrity","action":"enforce"}`,
+		},
+		{
+			name:   "generic error",
+			result: ValidationResult{Type: trustpolicy.TypeRevocation, Action: trustpolicy.ActionLog, Error: errors.New("revoked")},
+			want:   `{"type":"revocation","action":"log","error":{"type":"error","message":"revoked"}}`,
+		},
+		{
+			name:   "wrapped typed error",
+			result: ValidationResult{Type: trustpolicy.TypeAuthenticity, Action: trustpolicy.ActionEnforce, Error: fmt.Errorf("failed: %w", ErrorVerificationInconclusive{Msg: "timeout"})},
+			want:   `{"type":"authenticity","action":"enforce","error":{"type":"verificationInconclusive","message":"failed: timeout"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.result)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestVerificationOutcomeMarshalJSON(t *testing.T) {
+	t.Run("skipped", func(t *testing.T) {
+		outcome := &VerificationOutcome{
+			VerificationLevel: trustpolicy.LevelSkip,
+			TrustPolicyName:   "skip-policy",
+			SkipReason:        SkipReasonVerificationLevel,
+		}
+		got, err := json.Marshal(outcome)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		want := `{"verificationLevel":"skip","trustPolicyName":"skip-policy","skipped":true,"skipReason":"verificationLevelSkip","verificationResults":[]}`
+		if string(got) != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		cert := testhelper.GetRSALeafCertificate().Cert
+		signingTime := time.Date(2024, 6, 18, 7, 30, 31, 0, time.UTC)
+		outcome := &VerificationOutcome{
+			VerificationLevel: trustpolicy.LevelStrict,
+			TrustPolicyName:   "test-policy",
+			EnvelopeContent: &signature.EnvelopeContent{
+				SignerInfo: signature.SignerInfo{
+					SignedAttributes: signature.SignedAttributes{
+						SigningScheme: signature.SigningSchemeX509,
+						SigningTime:   signingTime,
+					},
+					CertificateChain: []*x509.Certificate{cert},
+				},
+			},
+			VerificationResults: []*ValidationResult{
+				{Type: trustpolicy.TypeIntegrity, Action: trustpolicy.ActionEnforce},
+				{Type: trustpolicy.TypeExpiry, Action: trustpolicy.ActionEnforce, Error: ErrorSignatureTooOld{SigningTime: signingTime, MaxAge: time.Hour}},
+			},
+			Error: ErrorSignatureTooOld{SigningTime: signingTime, MaxAge: time.Hour},
+		}
+		got, err := json.Marshal(outcome)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		fingerprint := sha256.Sum256(cert.Raw)
+		errJSON := `{"type":"signatureTooOld","message":"signature signed at \"Tue, 18 Jun 2024 07:30:31 +0000\" is older than the maximum signature age of 1h0m0s"}`
+		want := fmt.Sprintf(`{"verificationLevel":"strict","trustPolicyName":"test-policy","skipped":false,"signingScheme":"notary.x509","signingTime":"2024-06-18T07:30:31Z","signer":{"subject":%q,"issuer":%q,"sha256Fingerprint":%q},"verificationResults":[{"type":"integrity","action":"enforce"},{"type":"expiry","action":"enforce","error":%s}],"error":%s}`,
+			cert.Subject.String(), cert.Issuer.String(), hex.EncodeToString(fingerprint[:]), errJSON, errJSON)
+		if string(got) != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("payload version and revocation evidence", func(t *testing.T) {
+		leafCert := testhelper.GetRSALeafCertificate().Cert
+		rootCert := testhelper.GetRSARootCertificate().Cert
+		outcome := &VerificationOutcome{
+			VerificationLevel: trustpolicy.LevelStrict,
+			TrustPolicyName:   "test-policy",
+			PayloadVersion:    1,
+			RevocationEvidence: []RevocationEvidence{
+				{
+					Certificate: leafCert,
+					Result: &revocationresult.CertRevocationResult{
+						Result:           revocationresult.ResultUnknown,
+						RevocationMethod: revocationresult.RevocationMethodOCSP,
+						ServerResults: []*revocationresult.ServerResult{{
+							Result:           revocationresult.ResultUnknown,
+							Server:           "http://ocsp.example.com",
+							Error:            errors.New("timeout"),
+							RevocationMethod: revocationresult.RevocationMethodOCSP,
+						}},
+					},
+				},
+				{
+					Certificate: rootCert,
+					Result: &revocationresult.CertRevocationResult{
+						Result:        revocationresult.ResultNonRevokable,
+						ServerResults: []*revocationresult.ServerResult{{Result: revocationresult.ResultNonRevokable}},
+					},
+				},
+			},
+		}
+		got, err := json.Marshal(outcome)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		certJSON := func(cert *x509.Certificate) string {
+			fingerprint := sha256.Sum256(cert.Raw)
+			return fmt.Sprintf(`{"subject":%q,"issuer":%q,"sha256Fingerprint":%q}`, cert.Subject.String(), cert.Issuer.String(), hex.EncodeToString(fingerprint[:]))
+		}
+		want := fmt.Sprintf(`{"verificationLevel":"strict","trustPolicyName":"test-policy","skipped":false,"payloadVersion":1,"revocationEvidence":[`+
+			`{"certificate":%s,"result":"Unknown","revocationMethod":"OCSP","serverResults":[{"server":"http://ocsp.example.com","result":"Unknown","revocationMethod":"OCSP","error":"timeout"}]},`+
+			`{"certificate":%s,"result":"NonRevokable","revocationMethod":"Unknown","serverResults":[{"result":"NonRevokable","revocationMethod":"Unknown"}]}`+
+			`],"verificationResults":[]}`, certJSON(leafCert), certJSON(rootCert))
+		if string(got) != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	})
+}
+
+func TestDiffOutcomes(t *testing.T) {
+	leafCert := testhelper.GetRSALeafCertificate().Cert
+	rootCert := testhelper.GetRSARootCertificate().Cert
+	newOutcome := func(cert *x509.Certificate, level *trustpolicy.VerificationLevel, results ...*ValidationResult) *VerificationOutcome {
+		return &VerificationOutcome{
+			EnvelopeContent: &signature.EnvelopeContent{
+				SignerInfo: signature.SignerInfo{CertificateChain: []*x509.Certificate{cert}},
+			},
+			VerificationLevel:   level,
+			TrustPolicyName:     "test-policy",
+			VerificationResults: results,
+		}
+	}
+	integrity := &ValidationResult{Type: trustpolicy.TypeIntegrity, Action: trustpolicy.ActionEnforce}
+	revocation := &ValidationResult{Type: trustpolicy.TypeRevocation, Action: trustpolicy.ActionEnforce}
+	revoked := &ValidationResult{Type: trustpolicy.TypeRevocation, Action: trustpolicy.ActionEnforce, Error: errors.New("revoked")}
+	expiry := &ValidationResult{Type: trustpolicy.TypeExpiry, Action: trustpolicy.ActionLog}
+
+	t.Run("identical", func(t *testing.T) {
+		old := newOutcome(leafCert, trustpolicy.LevelStrict, integrity, revocation)
+		new := newOutcome(leafCert, trustpolicy.LevelStrict, &ValidationResult{Type: trustpolicy.TypeIntegrity, Action: trustpolicy.ActionEnforce}, revocation)
+		if diff := DiffOutcomes(old, new); !diff.Empty() {
+			t.Fatalf("expected no difference, got %+v", diff)
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		old := newOutcome(leafCert, trustpolicy.LevelStrict, integrity, revocation, expiry)
+		new := newOutcome(rootCert, trustpolicy.LevelPermissive, integrity, revoked)
+		new.Error = ErrorVerificationFailed{Msg: "revoked"}
+		diff := DiffOutcomes(old, new)
+		if diff.Empty() {
+			t.Fatal("expected differences")
+		}
+		if diff.VerificationLevel == nil || *diff.VerificationLevel != (FieldChange{Old: "strict", New: "permissive"}) {
+			t.Fatalf("unexpected verification level change %+v", diff.VerificationLevel)
+		}
+		if diff.TrustPolicyName != nil || diff.SkipReason != nil {
+			t.Fatalf("expected no trust policy and skip reason change, got %+v and %+v", diff.TrustPolicyName, diff.SkipReason)
+		}
+		if diff.Error == nil || *diff.Error != (FieldChange{New: "revoked"}) {
+			t.Fatalf("unexpected error change %+v", diff.Error)
+		}
+		if diff.SigningIdentity == nil || diff.SigningIdentity.Old.Subject != leafCert.Subject.String() || diff.SigningIdentity.New.Subject != rootCert.Subject.String() {
+			t.Fatalf("unexpected signing identity change %+v", diff.SigningIdentity)
+		}
+		want := []ValidationResultChange{
+			{Type: trustpolicy.TypeRevocation, Old: revocation, New: revoked},
+			{Type: trustpolicy.TypeExpiry, Old: expiry},
+		}
+		if len(diff.VerificationResults) != len(want) {
+			t.Fatalf("expected %d verification result changes, got %+v", len(want), diff.VerificationResults)
+		}
+		for i, change := range diff.VerificationResults {
+			if change != want[i] {
+				t.Fatalf("expected verification result change %+v, got %+v", want[i], change)
+			}
+		}
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		new := &VerificationOutcome{VerificationLevel: trustpolicy.LevelSkip, SkipReason: SkipReasonVerificationLevel}
+		diff := DiffOutcomes(newOutcome(leafCert, trustpolicy.LevelStrict, integrity), new)
+		if diff.SkipReason == nil || diff.SkipReason.New != string(SkipReasonVerificationLevel) {
+			t.Fatalf("unexpected skip reason change %+v", diff.SkipReason)
+		}
+		if diff.SigningIdentity == nil || diff.SigningIdentity.New != nil {
+			t.Fatalf("unexpected signing identity change %+v", diff.SigningIdentity)
+		}
+	})
+
+	t.Run("nil outcome", func(t *testing.T) {
+		diff := DiffOutcomes(nil, newOutcome(leafCert, trustpolicy.LevelStrict, integrity))
+		if diff.SigningIdentity == nil || diff.SigningIdentity.Old != nil {
+			t.Fatalf("unexpected signing identity change %+v", diff.SigningIdentity)
+		}
+		if len(diff.VerificationResults) != 1 || diff.VerificationResults[0].Old != nil {
+			t.Fatalf("unexpected verification result changes %+v", diff.VerificationResults)
+		}
+		if diff := DiffOutcomes(nil, nil); !diff.Empty() {
+			t.Fatalf("expected no difference, got %+v", diff)
+		}
+	})
+}
@@ -25,6 +25,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/notaryproject/notation-go/internal/io"
@@ -39,6 +40,27 @@ const maxPluginOutputSize = 64 * 1024 * 1024 // 64 MiB
 
 var executor commander = &execCommander{} // for unit test
 
+type contextKey int
+
+// envKey is the associated key type for plugin environment variables entry
+// in context.
+const envKey contextKey = iota
+
+// WithEnv is used by callers to set additional environment variables for the
+// CLI plugin processes executed with the returned context. The variables are
+// merged into the environment inherited from the current process, and are
+// never logged.
+func WithEnv(ctx context.Context, env map[string]string) context.Context {
+	return context.WithValue(ctx, envKey, env)
+}
+
+// GetEnv is used to retrieve the environment variables set by [WithEnv] from
+// the context.
+func GetEnv(ctx context.Context) map[string]string {
+	env, _ := ctx.Value(envKey).(map[string]string)
+	return env
+}
+
 // GenericPlugin is the base requirement to be a plugin.
 //
 // Deprecated: GenericPlugin exists for historical compatibility and should not be used.
@@ -90,24 +112,75 @@ func NewCLIPlugin(ctx context.Context, name, path string) (*CLIPlugin, error) {
 	}, nil
 }
 
+// ConfigSchema declares the plugin config keys accepted by a plugin.
+//
+// It is read from the optional `requiredConfigKeys` and `optionalConfigKeys`
+// fields of the get-plugin-metadata response, which notation-go supports on
+// top of the plugin contract. A plugin declaring any config key accepts no
+// other config keys.
+type ConfigSchema struct {
+	// RequiredConfigKeys are the plugin config keys that must be set.
+	RequiredConfigKeys []string `json:"requiredConfigKeys,omitempty"`
+
+	// OptionalConfigKeys are the plugin config keys that may be set.
+	OptionalConfigKeys []string `json:"optionalConfigKeys,omitempty"`
+}
+
+// Validate checks that config contains all the required config keys of the
+// schema, and, if the schema declares any config key, that config contains
+// no key other than the required and optional config keys.
+func (s *ConfigSchema) Validate(config map[string]string) error {
+	for _, key := range s.RequiredConfigKeys {
+		if _, ok := config[key]; !ok {
+			return fmt.Errorf("missing required plugin config key %q", key)
+		}
+	}
+	if len(s.RequiredConfigKeys) == 0 && len(s.OptionalConfigKeys) == 0 {
+		return nil
+	}
+	var unknownKeys []string
+	for key := range config {
+		if !slices.Contains(s.RequiredConfigKeys, key) && !slices.Contains(s.OptionalConfigKeys, key) {
+			unknownKeys = append(unknownKeys, key)
+		}
+	}
+	if len(unknownKeys) > 0 {
+		sort.Strings(unknownKeys)
+		return fmt.Errorf("unknown plugin config keys %q", unknownKeys)
+	}
+	return nil
+}
+
 // GetMetadata returns the metadata information of the plugin.
 func (p *CLIPlugin) GetMetadata(ctx context.Context, req *plugin.GetMetadataRequest) (*plugin.GetMetadataResponse, error) {
-	var metadata plugin.GetMetadataResponse
-	err := run(ctx, p.name, p.path, req, &metadata)
+	metadata, _, err := p.GetMetadataWithConfigSchema(ctx, req)
+	return metadata, err
+}
+
+// GetMetadataWithConfigSchema returns the metadata information of the plugin
+// along with the plugin config keys declared by the plugin. The returned
+// schema is empty if the plugin does not declare any config key.
+func (p *CLIPlugin) GetMetadataWithConfigSchema(ctx context.Context, req *plugin.GetMetadataRequest) (*plugin.GetMetadataResponse, *ConfigSchema, error) {
+	var resp struct {
+		plugin.GetMetadataResponse
+		ConfigSchema
+	}
+	err := run(ctx, p.name, p.path, req, &resp)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	metadata := resp.GetMetadataResponse
 	// validate metadata
 	if err = validate(&metadata); err != nil {
-		return nil, &PluginMalformedError{
+		return nil, nil, &PluginMalformedError{
 			Msg:        fmt.Sprintf("metadata validation failed for plugin %s: %s", p.name, err),
 			InnerError: err,
 		}
 	}
 	if metadata.Name != p.name {
-		return nil, fmt.Errorf("plugin executable file name must be %q instead of %q", binName(metadata.Name), filepath.Base(p.path))
+		return nil, nil, fmt.Errorf("plugin executable file name must be %q instead of %q", binName(metadata.Name), filepath.Base(p.path))
 	}
-	return &metadata, nil
+	return &metadata, &resp.ConfigSchema, nil
 }
 
 // DescribeKey returns the KeySpec of a key.
@@ -225,6 +298,12 @@ type execCommander struct{}
 func (c execCommander) Output(ctx context.Context, name string, command plugin.Command, req []byte) ([]byte, []byte, error) {
 	var stdout, stderr bytes.Buffer
 	cmd := exec.CommandContext(ctx, name, string(command))
+	if env := GetEnv(ctx); len(env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
 	cmd.Stdin = bytes.NewReader(req)
 	// The limit writer will be handled by the caller in run() by comparing the
 	// bytes written with the expected length of the bytes.
@@ -226,6 +226,23 @@ func TestNewCLIPlugin_Error(t *testing.T) {
 	})
 }
 
+func TestExecCommanderWithEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows")
+	}
+	ctx := WithEnv(context.Background(), map[string]string{"NOTATION_PLUGIN_TEST_ENV": "value"})
+	if env := GetEnv(ctx); env["NOTATION_PLUGIN_TEST_ENV"] != "value" {
+		t.Fatalf("GetEnv() = %v, want NOTATION_PLUGIN_TEST_ENV=value", env)
+	}
+	stdout, _, err := execCommander{}.Output(ctx, "printenv", proto.Command("NOTATION_PLUGIN_TEST_ENV"), nil)
+	if err != nil {
+		t.Fatalf("execCommander{}.Output() error = %v", err)
+	}
+	if got := strings.TrimSpace(string(stdout)); got != "value" {
+		t.Fatalf("execCommander{}.Output() = %q, want %q", got, "value")
+	}
+}
+
 func TestNewCLIPlugin_ValidError(t *testing.T) {
 	ctx := context.Background()
 	p, err := NewCLIPlugin(ctx, "foo", "./testdata/plugins/foo/notation-foo")
@@ -287,3 +304,67 @@ func TestNewCLIPlugin_ValidError(t *testing.T) {
 		}
 	})
 }
+
+func TestGetMetadataWithConfigSchema(t *testing.T) {
+	ctx := context.Background()
+	p, err := NewCLIPlugin(ctx, "foo", "./testdata/plugins/foo/notation-foo")
+	if err != nil {
+		t.Fatal("should no error.")
+	}
+
+	t.Run("with config schema", func(t *testing.T) {
+		var resp map[string]any
+		if err := json.Unmarshal(metadataJSON(validMetadata), &resp); err != nil {
+			t.Fatal(err)
+		}
+		resp["requiredConfigKeys"] = []string{"region"}
+		resp["optionalConfigKeys"] = []string{"profile"}
+		output, err := json.Marshal(resp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		executor = testCommander{stdout: output}
+		metadata, schema, err := p.GetMetadataWithConfigSchema(ctx, &proto.GetMetadataRequest{})
+		if err != nil {
+			t.Fatalf("should valid. got err = %v", err)
+		}
+		if !reflect.DeepEqual(metadata, &validMetadata) {
+			t.Fatalf("should be equal. got metadata = %+v, want %+v", metadata, validMetadata)
+		}
+		want := &ConfigSchema{RequiredConfigKeys: []string{"region"}, OptionalConfigKeys: []string{"profile"}}
+		if !reflect.DeepEqual(schema, want) {
+			t.Fatalf("should be equal. got schema = %+v, want %+v", schema, want)
+		}
+	})
+
+	t.Run("without config schema", func(t *testing.T) {
+		executor = testCommander{stdout: metadataJSON(validMetadata)}
+		_, schema, err := p.GetMetadataWithConfigSchema(ctx, &proto.GetMetadataRequest{})
+		if err != nil {
+			t.Fatalf("should valid. got err = %v", err)
+		}
+		if len(schema.RequiredConfigKeys) != 0 || len(schema.OptionalConfigKeys) != 0 {
+			t.Fatalf("expected empty schema, got %+v", schema)
+		}
+	})
+}
+
+func TestConfigSchemaValidate(t *testing.T) {
+	schema := ConfigSchema{RequiredConfigKeys: []string{"region"}, OptionalConfigKeys: []string{"profile"}}
+	if err := schema.Validate(map[string]string{"region": "us"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	err := schema.Validate(map[string]string{"profile": "dev"})
+	if err == nil || err.Error() != `missing required plugin config key "region"` {
+		t.Fatalf("expected missing required key error, got %v", err)
+	}
+	err = schema.Validate(map[string]string{"region": "us", "zone": "a", "account": "b"})
+	if err == nil || err.Error() != `unknown plugin config keys ["account" "zone"]` {
+		t.Fatalf("expected unknown keys error, got %v", err)
+	}
+
+	// a plugin declaring no config keys accepts any config
+	if err := (&ConfigSchema{}).Validate(map[string]string{"zone": "a"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/notaryproject/notation-go/internal/file"
+	"github.com/notaryproject/notation-go/log"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ErrCacheMiss is returned by a [SignatureBlobCache] when the signature
+// envelope blob is not cached.
+var ErrCacheMiss = errors.New("signature blob cache miss")
+
+// SignatureBlobCache is a content-addressed cache of signature envelope blobs,
+// consulted by a [Repository] before fetching a signature envelope blob from
+// the registry and populated after.
+type SignatureBlobCache interface {
+	// Get returns the signature envelope blob described by desc. If the blob
+	// is not cached, ErrCacheMiss is returned.
+	Get(ctx context.Context, desc ocispec.Descriptor) ([]byte, error)
+
+	// Set stores the signature envelope blob described by desc.
+	Set(ctx context.Context, desc ocispec.Descriptor, blob []byte) error
+}
+
+// FileSignatureBlobCache implements [SignatureBlobCache] on the file system.
+//
+// The signature envelope blobs are stored in files named after their digest,
+// under a directory per digest algorithm. The cache is bounded in size: when
+// storing a blob makes the cached blobs exceed the maximum size, the least
+// recently used blobs are evicted.
+//
+// As for the CRL file cache, writes rely on the atomic rename of the file
+// system, so that a blob is never partially read. The size bound is enforced
+// per process: concurrent processes sharing the cache directory may exceed it
+// until the next eviction.
+type FileSignatureBlobCache struct {
+	// root is the root directory of the cache
+	root string
+
+	// maxSize is the maximum total size in bytes of the cached blobs
+	maxSize int64
+
+	// lock serializes the evictions of the cache
+	lock sync.Mutex
+}
+
+// NewFileSignatureBlobCache creates a FileSignatureBlobCache with root as the
+// root directory, holding at most maxSize bytes of signature envelope blobs.
+//
+// An example for root is `dir.CacheFS().SysPath(dir.PathSignatureCache)`
+func NewFileSignatureBlobCache(root string, maxSize int64) (*FileSignatureBlobCache, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("maxSize expects a positive number, got %d", maxSize)
+	}
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create signature blob file cache: %w", err)
+	}
+	return &FileSignatureBlobCache{
+		root:    root,
+		maxSize: maxSize,
+	}, nil
+}
+
+// Get returns the signature envelope blob described by desc from c. If the
+// blob does not exist, or the cached content does not match desc,
+// ErrCacheMiss is returned.
+func (c *FileSignatureBlobCache) Get(ctx context.Context, desc ocispec.Descriptor) ([]byte, error) {
+	logger := log.GetLogger(ctx)
+	path, err := c.path(desc)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			logger.Debugf("Signature blob file cache miss. Key %q does not exist", desc.Digest)
+			return nil, ErrCacheMiss
+		}
+		return nil, fmt.Errorf("failed to get signature blob from file cache with key %q: %w", desc.Digest, err)
+	}
+	if int64(len(blob)) != desc.Size || desc.Digest.Algorithm().FromBytes(blob) != desc.Digest {
+		logger.Warnf("Signature blob file cache entry %q is corrupted, removing it", desc.Digest)
+		os.Remove(path)
+		return nil, ErrCacheMiss
+	}
+
+	// refresh the modification time for the least recently used eviction
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		logger.Debugf("Failed to refresh signature blob file cache entry %q: %v", desc.Digest, err)
+	}
+	return blob, nil
+}
+
+// Set stores the signature envelope blob described by desc in c, and evicts
+// the least recently used blobs if the cache exceeds its maximum size.
+// A blob larger than the maximum size of the cache is not stored.
+func (c *FileSignatureBlobCache) Set(ctx context.Context, desc ocispec.Descriptor, blob []byte) error {
+	logger := log.GetLogger(ctx)
+	path, err := c.path(desc)
+	if err != nil {
+		return err
+	}
+	if int64(len(blob)) != desc.Size || desc.Digest.Algorithm().FromBytes(blob) != desc.Digest {
+		return fmt.Errorf("failed to store signature blob in file cache: content does not match descriptor %q", desc.Digest)
+	}
+	if desc.Size > c.maxSize {
+		logger.Debugf("Signature blob %q of %d bytes exceeds the file cache size, not cached", desc.Digest, desc.Size)
+		return nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to store signature blob in file cache: %w", err)
+	}
+	if err := file.WriteFile(c.root, path, blob); err != nil {
+		return fmt.Errorf("failed to store signature blob in file cache: %w", err)
+	}
+	return c.evict(ctx)
+}
+
+// path returns the file path of the blob described by desc.
+func (c *FileSignatureBlobCache) path(desc ocispec.Descriptor) (string, error) {
+	if err := desc.Digest.Validate(); err != nil {
+		return "", fmt.Errorf("invalid signature blob digest %q: %w", desc.Digest, err)
+	}
+	return filepath.Join(c.root, desc.Digest.Algorithm().String(), desc.Digest.Encoded()), nil
+}
+
+// evict removes the least recently used blobs until the total size of the
+// cached blobs does not exceed the maximum size.
+func (c *FileSignatureBlobCache) evict(ctx context.Context) error {
+	type entry struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var entries []entry
+	var total int64
+	algorithms, err := os.ReadDir(c.root)
+	if err != nil {
+		return fmt.Errorf("failed to evict signature blob file cache: %w", err)
+	}
+	for _, algorithm := range algorithms {
+		if !algorithm.IsDir() {
+			continue
+		}
+		dir := filepath.Join(c.root, algorithm.Name())
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			return fmt.Errorf("failed to evict signature blob file cache: %w", err)
+		}
+		for _, f := range files {
+			info, err := f.Info()
+			if err != nil {
+				// removed by another process
+				continue
+			}
+			if !info.Mode().IsRegular() {
+				continue
+			}
+			entries = append(entries, entry{
+				path:    filepath.Join(dir, f.Name()),
+				size:    info.Size(),
+				modTime: info.ModTime(),
+			})
+			total += info.Size()
+		}
+	}
+	if total <= c.maxSize {
+		return nil
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	logger := log.GetLogger(ctx)
+	for _, e := range entries {
+		if total <= c.maxSize {
+			break
+		}
+		logger.Debugf("Evicting %s from signature blob file cache", e.path)
+		if err := os.Remove(e.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to evict signature blob file cache: %w", err)
+		}
+		total -= e.size
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestNewFileSignatureBlobCache(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "signatures")
+	if _, err := NewFileSignatureBlobCache(root, 1024); err != nil {
+		t.Fatalf("NewFileSignatureBlobCache() error = %v", err)
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		t.Fatalf("expected cache root directory to be created, got %v", err)
+	}
+
+	expectedErrMsg := "maxSize expects a positive number, got 0"
+	if _, err := NewFileSignatureBlobCache(root, 0); err == nil || err.Error() != expectedErrMsg {
+		t.Fatalf("expected error %q, got %v", expectedErrMsg, err)
+	}
+}
+
+func TestFileSignatureBlobCache(t *testing.T) {
+	ctx := context.Background()
+	cache, err := NewFileSignatureBlobCache(t.TempDir(), 1024)
+	if err != nil {
+		t.Fatalf("NewFileSignatureBlobCache() error = %v", err)
+	}
+	blob := []byte("signature")
+	desc := content.NewDescriptorFromBytes(joseTag, blob)
+
+	if _, err := cache.Get(ctx, desc); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("expected ErrCacheMiss, got %v", err)
+	}
+	if err := cache.Set(ctx, desc, blob); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	got, err := cache.Get(ctx, desc)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !bytes.Equal(got, blob) {
+		t.Fatalf("Get() = %q, want %q", got, blob)
+	}
+
+	t.Run("content mismatch", func(t *testing.T) {
+		if err := cache.Set(ctx, desc, []byte("other")); err == nil {
+			t.Fatal("expected error for content not matching the descriptor")
+		}
+	})
+
+	t.Run("corrupted entry", func(t *testing.T) {
+		path, err := cache.path(desc)
+		if err != nil {
+			t.Fatalf("path() error = %v", err)
+		}
+		if err := os.WriteFile(path, []byte("corrupted"), 0600); err != nil {
+			t.Fatalf("failed to corrupt cache entry: %v", err)
+		}
+		if _, err := cache.Get(ctx, desc); !errors.Is(err, ErrCacheMiss) {
+			t.Fatalf("expected ErrCacheMiss, got %v", err)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Fatalf("expected corrupted entry to be removed, got %v", err)
+		}
+	})
+
+	t.Run("invalid digest", func(t *testing.T) {
+		if _, err := cache.Get(ctx, ocispec.Descriptor{Digest: "invalid"}); err == nil {
+			t.Fatal("expected error for invalid digest")
+		}
+	})
+}
+
+func TestFileSignatureBlobCacheEviction(t *testing.T) {
+	ctx := context.Background()
+	cache, err := NewFileSignatureBlobCache(t.TempDir(), 20)
+	if err != nil {
+		t.Fatalf("NewFileSignatureBlobCache() error = %v", err)
+	}
+	blobs := [][]byte{[]byte("signature1"), []byte("signature2"), []byte("signature3")}
+	descs := make([]ocispec.Descriptor, len(blobs))
+	for i, blob := range blobs {
+		descs[i] = content.NewDescriptorFromBytes(joseTag, blob)
+	}
+	for _, i := range []int{0, 1} {
+		if err := cache.Set(ctx, descs[i], blobs[i]); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		// make the blobs distinguishable by modification time
+		path, _ := cache.path(descs[i])
+		modTime := time.Now().Add(time.Duration(i-2) * time.Minute)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("failed to set modification time: %v", err)
+		}
+	}
+
+	// using the first blob makes the second one the least recently used
+	if _, err := cache.Get(ctx, descs[0]); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if err := cache.Set(ctx, descs[2], blobs[2]); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	for i, wantCached := range []bool{true, false, true} {
+		_, err := cache.Get(ctx, descs[i])
+		if cached := err == nil; cached != wantCached {
+			t.Fatalf("blob %d cached = %v, want %v", i, cached, wantCached)
+		}
+	}
+
+	t.Run("blob larger than the cache", func(t *testing.T) {
+		blob := bytes.Repeat([]byte("a"), 21)
+		desc := content.NewDescriptorFromBytes(joseTag, blob)
+		if err := cache.Set(ctx, desc, blob); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		if _, err := cache.Get(ctx, desc); !errors.Is(err, ErrCacheMiss) {
+			t.Fatalf("expected ErrCacheMiss, got %v", err)
+		}
+	})
+}
+
+func TestFetchSignatureBlobCached(t *testing.T) {
+	ctx := context.Background()
+	store := &countingStore{Store: memory.New()}
+	subject, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageManifest, []byte("{}"))
+	if err != nil {
+		t.Fatalf("failed to push subject: %v", err)
+	}
+	cache, err := NewFileSignatureBlobCache(t.TempDir(), 1024)
+	if err != nil {
+		t.Fatalf("NewFileSignatureBlobCache() error = %v", err)
+	}
+	repo := NewRepositoryWithOptions(store, RepositoryOptions{SignatureBlobCache: cache})
+	blob := []byte("signature")
+	blobDesc, manifestDesc, err := repo.PushSignature(ctx, joseTag, blob, subject, nil)
+	if err != nil {
+		t.Fatalf("failed to push signature: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		got, _, err := repo.FetchSignatureBlob(ctx, manifestDesc)
+		if err != nil {
+			t.Fatalf("FetchSignatureBlob() error = %v", err)
+		}
+		if !bytes.Equal(got, blob) {
+			t.Fatalf("FetchSignatureBlob() = %q, want %q", got, blob)
+		}
+	}
+	if got := store.fetches[blobDesc.Digest.String()]; got != 1 {
+		t.Fatalf("expected the signature blob to be fetched once, got %d", got)
+	}
+}
+
+// countingStore is a memory.Store counting the fetches of each content.
+type countingStore struct {
+	*memory.Store
+	fetches map[string]int
+}
+
+func (s *countingStore) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	if s.fetches == nil {
+		s.fetches = make(map[string]int)
+	}
+	s.fetches[target.Digest.String()]++
+	return s.Store.Fetch(ctx, target)
+}
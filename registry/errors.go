@@ -0,0 +1,70 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// ErrorTagSignature is used when the repository failed to tag a signature
+// manifest
+type ErrorTagSignature struct {
+	Msg        string
+	InnerError error
+}
+
+func (e ErrorTagSignature) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	if e.InnerError != nil {
+		return e.InnerError.Error()
+	}
+	return "failed to tag the signature manifest"
+}
+
+func (e ErrorTagSignature) Unwrap() error {
+	return e.InnerError
+}
+
+// ErrorMalformedSignatureManifest is used when a signature manifest cannot be
+// parsed or does not reference exactly one signature envelope blob
+type ErrorMalformedSignatureManifest struct {
+	Msg        string
+	InnerError error
+
+	// Digest is the digest of the signature manifest.
+	Digest digest.Digest
+
+	// BlobCount is the number of blobs referenced by the signature manifest.
+	// It is meaningful only if the manifest was parsed, i.e. if InnerError
+	// is nil.
+	BlobCount int
+}
+
+func (e ErrorMalformedSignatureManifest) Error() string {
+	if e.Msg != "" {
+		return e.Msg
+	}
+	if e.InnerError != nil {
+		return fmt.Sprintf("malformed signature manifest %s: %v", e.Digest, e.InnerError)
+	}
+	return fmt.Sprintf("signature manifest %s requires exactly one signature envelope blob, got %d", e.Digest, e.BlobCount)
+}
+
+func (e ErrorMalformedSignatureManifest) Unwrap() error {
+	return e.InnerError
+}
@@ -0,0 +1,63 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorTagSignature(t *testing.T) {
+	innerErr := errors.New("inner error")
+	tests := []struct {
+		name string
+		err  ErrorTagSignature
+		want string
+	}{
+		{name: "with message", err: ErrorTagSignature{Msg: "test message", InnerError: innerErr}, want: "test message"},
+		{name: "with inner error", err: ErrorTagSignature{InnerError: innerErr}, want: "inner error"},
+		{name: "default", err: ErrorTagSignature{}, want: "failed to tag the signature manifest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorMalformedSignatureManifest(t *testing.T) {
+	innerErr := errors.New("inner error")
+	const manifestDigest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	tests := []struct {
+		name string
+		err  ErrorMalformedSignatureManifest
+		want string
+	}{
+		{name: "with message", err: ErrorMalformedSignatureManifest{Msg: "test message", InnerError: innerErr}, want: "test message"},
+		{name: "with inner error", err: ErrorMalformedSignatureManifest{InnerError: innerErr, Digest: manifestDigest}, want: "malformed signature manifest " + manifestDigest + ": inner error"},
+		{name: "with blob count", err: ErrorMalformedSignatureManifest{Digest: manifestDigest, BlobCount: 2}, want: "signature manifest " + manifestDigest + " requires exactly one signature envelope blob, got 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if err := (ErrorMalformedSignatureManifest{InnerError: innerErr}); !errors.Is(err, innerErr) {
+		t.Errorf("expected %v to wrap %v", err, innerErr)
+	}
+}
@@ -16,6 +16,7 @@ package registry
 
 import (
 	"context"
+	"io"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
@@ -38,3 +39,78 @@ type Repository interface {
 	// linked signature envelope blob.
 	PushSignature(ctx context.Context, mediaType string, blob []byte, subject ocispec.Descriptor, annotations map[string]string) (blobDesc, manifestDesc ocispec.Descriptor, err error)
 }
+
+// SignatureBlobStreamFetcher is an optional interface implemented by a
+// [Repository] to fetch a signature envelope blob as a stream, without
+// buffering it in memory.
+type SignatureBlobStreamFetcher interface {
+	// FetchSignatureBlobStream returns a reader of the signature envelope blob
+	// and its descriptor for given signature manifest descriptor. The caller
+	// must close the reader.
+	FetchSignatureBlobStream(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, ocispec.Descriptor, error)
+}
+
+// IndexFetcher is an optional interface implemented by a [Repository] to
+// fetch image indexes, e.g. to verify the platform manifests of a
+// multi-architecture image.
+type IndexFetcher interface {
+	// FetchIndex returns the image index described by desc. Both OCI image
+	// indexes and Docker manifest lists are supported.
+	FetchIndex(ctx context.Context, desc ocispec.Descriptor) (ocispec.Index, error)
+}
+
+// ReferrersSupportChecker is an optional interface implemented by a
+// [Repository] to report the Referrers API support of the registry.
+type ReferrersSupportChecker interface {
+	// SupportsReferrers reports whether the registry supports the Referrers
+	// API.
+	SupportsReferrers(ctx context.Context) (bool, error)
+}
+
+// SignatureTagger is an optional interface implemented by a [Repository] to
+// tag signature manifests, e.g. for promotion or archival workflows.
+type SignatureTagger interface {
+	// TagSignature tags the signature manifest described by sigManifestDesc
+	// with tag.
+	TagSignature(ctx context.Context, sigManifestDesc ocispec.Descriptor, tag string) error
+}
+
+// SignatureSubjectFetcher is an optional interface implemented by a
+// [Repository] to map a signature back to the artifact it signs, e.g. for
+// tooling discovering signatures in a registry.
+type SignatureSubjectFetcher interface {
+	// SignatureSubject returns the descriptor of the artifact signed by the
+	// signature described by sigManifestDesc, i.e. the subject of the
+	// signature manifest. An error is returned if the signature manifest has
+	// no subject.
+	SignatureSubject(ctx context.Context, sigManifestDesc ocispec.Descriptor) (ocispec.Descriptor, error)
+}
+
+// ResumableSignaturePusher is an optional interface implemented by a
+// [Repository] to push signatures idempotently, so that a push interrupted
+// between uploading the signature envelope blob and the signature manifest
+// can be retried without leaving orphaned content in the registry.
+type ResumableSignaturePusher interface {
+	// PushSignatureResumable pushes the signature as
+	// [Repository.PushSignature] does, skipping the content that already
+	// exists in the registry.
+	PushSignatureResumable(ctx context.Context, mediaType string, blob []byte, subject ocispec.Descriptor, annotations map[string]string) (PushSignatureResult, error)
+}
+
+// PushSignatureResult is the result of
+// [ResumableSignaturePusher.PushSignatureResumable].
+type PushSignatureResult struct {
+	// BlobDesc is the descriptor of the signature envelope blob.
+	BlobDesc ocispec.Descriptor
+
+	// ManifestDesc is the descriptor of the signature manifest.
+	ManifestDesc ocispec.Descriptor
+
+	// BlobExisted reports whether the signature envelope blob already
+	// existed in the registry, and thus was not pushed.
+	BlobExisted bool
+
+	// ManifestExisted reports whether the signature manifest already
+	// existed in the registry, and thus was not pushed.
+	ManifestExisted bool
+}
@@ -13,6 +13,30 @@
 
 package registry
 
+import (
+	"fmt"
+	"regexp"
+)
+
 // ArtifactTypeNotation specifies the artifact type for a notation object.
 // spec: https://github.com/notaryproject/notaryproject/blob/efc828223710f99ab9639d2d0f72d59036a8e80c/specs/signature-specification.md#storage
 const ArtifactTypeNotation = "application/vnd.cncf.notary.signature"
+
+// MediaTypeSuffixGzip is appended to the media type of signature envelope
+// blobs compressed with gzip, e.g. "application/cose+gzip".
+// See [RepositoryOptions.CompressSignatureBlobs].
+const MediaTypeSuffixGzip = "+gzip"
+
+// mediaTypeRegexp checks the format of media types, following the naming
+// requirements of RFC 6838 section 4.2 required by the OCI image
+// specification.
+// reference: https://www.rfc-editor.org/rfc/rfc6838#section-4.2
+var mediaTypeRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}/[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}$`)
+
+// validateMediaType checks that mediaType conforms to RFC 6838.
+func validateMediaType(mediaType string) error {
+	if !mediaTypeRegexp.MatchString(mediaType) {
+		return fmt.Errorf("%q is not a valid media type as defined by RFC 6838", mediaType)
+	}
+	return nil
+}
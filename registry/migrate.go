@@ -0,0 +1,98 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/notaryproject/notation-go/registry/internal/artifactspec"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/registry"
+)
+
+// MigrateSignatureFormat re-pushes the signatures of the artifact referenced
+// by artifactRef that are stored as OCI artifact manifests, which are no
+// longer supported by some registries, as OCI image manifests. The migrated
+// signatures have the same signature envelope blob, subject and annotations
+// as the original ones, and the number of signatures migrated is returned.
+//
+// Signatures whose envelope is already stored in an OCI image manifest are
+// not migrated again, so MigrateSignatureFormat can be retried after a
+// failure. The original artifact manifests are not deleted.
+func MigrateSignatureFormat(ctx context.Context, repo Repository, artifactRef string) (int, error) {
+	if repo == nil {
+		return 0, errors.New("repo cannot be nil")
+	}
+	if ref, err := registry.ParseReference(artifactRef); err == nil {
+		// artifactRef is a valid full reference
+		artifactRef = ref.Reference
+	}
+	subject, err := repo.Resolve(ctx, artifactRef)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve reference: %w", err)
+	}
+
+	var artifactManifests []ocispec.Descriptor
+	if err := repo.ListSignatures(ctx, subject, func(signatureManifests []ocispec.Descriptor) error {
+		for _, sigManifestDesc := range signatureManifests {
+			if sigManifestDesc.MediaType == artifactspec.MediaTypeArtifactManifest {
+				artifactManifests = append(artifactManifests, sigManifestDesc)
+			}
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	if len(artifactManifests) == 0 {
+		return 0, nil
+	}
+
+	// envelopes already stored in OCI image manifests
+	migrated := make(map[digest.Digest]bool)
+	if err := repo.ListSignatures(ctx, subject, func(signatureManifests []ocispec.Descriptor) error {
+		for _, sigManifestDesc := range signatureManifests {
+			if sigManifestDesc.MediaType != ocispec.MediaTypeImageManifest {
+				continue
+			}
+			_, sigDesc, err := repo.FetchSignatureBlob(ctx, sigManifestDesc)
+			if err != nil {
+				return fmt.Errorf("unable to retrieve digital signature with digest %q: %w", sigManifestDesc.Digest, err)
+			}
+			migrated[sigDesc.Digest] = true
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, sigManifestDesc := range artifactManifests {
+		sigBlob, sigDesc, err := repo.FetchSignatureBlob(ctx, sigManifestDesc)
+		if err != nil {
+			return count, fmt.Errorf("unable to retrieve digital signature with digest %q: %w", sigManifestDesc.Digest, err)
+		}
+		if migrated[sigDesc.Digest] {
+			continue
+		}
+		if _, _, err := repo.PushSignature(ctx, sigDesc.MediaType, sigBlob, subject, sigManifestDesc.Annotations); err != nil {
+			return count, fmt.Errorf("failed to migrate signature with digest %q: %w", sigManifestDesc.Digest, err)
+		}
+		migrated[sigDesc.Digest] = true
+		count++
+	}
+	return count, nil
+}
@@ -0,0 +1,109 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/notaryproject/notation-go/registry/internal/artifactspec"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestMigrateSignatureFormat(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	subject, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageManifest, []byte("{}"))
+	if err != nil {
+		t.Fatalf("failed to push subject: %v", err)
+	}
+	if err := store.Tag(ctx, subject, "v1"); err != nil {
+		t.Fatalf("failed to tag subject: %v", err)
+	}
+	signature := []byte("signature envelope")
+	blobDesc, err := oras.PushBytes(ctx, store, joseTag, signature)
+	if err != nil {
+		t.Fatalf("failed to push signature blob: %v", err)
+	}
+	annotations := map[string]string{"io.cncf.notary.x509chain.thumbprint#S256": `["thumbprint"]`}
+	artifactJSON, err := json.Marshal(artifactspec.Artifact{
+		MediaType:    artifactspec.MediaTypeArtifactManifest,
+		ArtifactType: ArtifactTypeNotation,
+		Blobs:        []ocispec.Descriptor{blobDesc},
+		Subject:      &subject,
+		Annotations:  annotations,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := oras.PushBytes(ctx, store, artifactspec.MediaTypeArtifactManifest, artifactJSON); err != nil {
+		t.Fatalf("failed to push artifact manifest: %v", err)
+	}
+	repo := NewRepository(store)
+
+	count, err := MigrateSignatureFormat(ctx, repo, "localhost:5000/test:v1")
+	if err != nil {
+		t.Fatalf("MigrateSignatureFormat() error = %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 signature migrated, got %d", count)
+	}
+	var imageManifests []ocispec.Descriptor
+	if err := repo.ListSignatures(ctx, subject, func(signatureManifests []ocispec.Descriptor) error {
+		for _, sigManifestDesc := range signatureManifests {
+			if sigManifestDesc.MediaType == ocispec.MediaTypeImageManifest {
+				imageManifests = append(imageManifests, sigManifestDesc)
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to list signatures: %v", err)
+	}
+	if len(imageManifests) != 1 {
+		t.Fatalf("expected 1 signature stored as image manifest, got %d", len(imageManifests))
+	}
+	if got := imageManifests[0].Annotations["io.cncf.notary.x509chain.thumbprint#S256"]; got != `["thumbprint"]` {
+		t.Fatalf("expected annotations %v to be migrated, got %v", annotations, imageManifests[0].Annotations)
+	}
+	sigBlob, sigDesc, err := repo.FetchSignatureBlob(ctx, imageManifests[0])
+	if err != nil {
+		t.Fatalf("failed to fetch migrated signature blob: %v", err)
+	}
+	if !bytes.Equal(sigBlob, signature) || sigDesc.MediaType != joseTag {
+		t.Fatalf("expected migrated signature blob %q of media type %q, got %q of media type %q", signature, joseTag, sigBlob, sigDesc.MediaType)
+	}
+
+	// signatures already migrated are skipped
+	count, err = MigrateSignatureFormat(ctx, repo, "v1")
+	if err != nil {
+		t.Fatalf("MigrateSignatureFormat() error = %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("expected no signature migrated, got %d", count)
+	}
+}
+
+func TestMigrateSignatureFormatError(t *testing.T) {
+	if _, err := MigrateSignatureFormat(context.Background(), nil, "v1"); err == nil || err.Error() != "repo cannot be nil" {
+		t.Fatalf("expected nil repo error, got %v", err)
+	}
+	_, err := MigrateSignatureFormat(context.Background(), NewRepository(memory.New()), "v1")
+	if err == nil || !bytes.HasPrefix([]byte(err.Error()), []byte("failed to resolve reference")) {
+		t.Fatalf("expected resolve error, got %v", err)
+	}
+}
@@ -15,26 +15,50 @@ package registry
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/notaryproject/notation-core-go/signature/jws"
+	"github.com/notaryproject/notation-go/internal/envelope"
+	"github.com/notaryproject/notation-go/log"
 	"github.com/notaryproject/notation-go/registry/internal/artifactspec"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/oci"
 	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+	"oras.land/oras-go/v2/registry/remote/retry"
 )
 
 const (
 	maxBlobSizeLimit     = 32 * 1024 * 1024 // 32 MiB
 	maxManifestSizeLimit = 4 * 1024 * 1024  // 4 MiB
+	// estimatedSubjectSize is the size of the subject image manifest assumed
+	// by EstimateSignatureSize.
+	estimatedSubjectSize = 1024
 )
 
+// mediaTypeDockerManifestList is the media type of a Docker manifest list,
+// the Docker counterpart of an OCI image index.
+const mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+
 var (
 	// notationEmptyConfigDesc is the descriptor of an empty notation manifest
 	// config
@@ -49,13 +73,76 @@ var (
 )
 
 // RepositoryOptions provides user options when creating a [Repository]
-// it is kept for future extensibility
-type RepositoryOptions struct{}
+type RepositoryOptions struct {
+	// ArtifactType is the artifact type of the signature manifests pushed
+	// and listed by the [Repository]. If empty, [ArtifactTypeNotation] is
+	// used.
+	ArtifactType string
+
+	// CompressSignatureBlobs compresses the signature envelope blobs pushed
+	// by the [Repository] with gzip, and appends [MediaTypeSuffixGzip] to
+	// their media type.
+	// Compressed signature envelope blobs are always decompressed on fetch,
+	// regardless of this option. Verifiers using an older version of this
+	// package cannot fetch compressed signature envelope blobs.
+	CompressSignatureBlobs bool
+
+	// RootCAs is the set of root certificate authorities used by
+	// [NewRemoteRepository] to verify the TLS certificate of the registry,
+	// e.g. for a registry served with a certificate issued by a private CA.
+	// It is independent of the trust stores used to verify signatures.
+	// It only applies to the client created by NewRemoteRepository, and
+	// cannot be set along with a custom client.
+	// If both RootCAs and RootCAFile are empty, the system roots are used.
+	RootCAs *x509.CertPool
+
+	// RootCAFile is the path of a PEM encoded bundle of root certificate
+	// authorities, added to RootCAs to verify the TLS certificate of the
+	// registry.
+	RootCAFile string
+
+	// SignatureBlobCache caches the signature envelope blobs fetched by the
+	// [Repository] by digest, so that verifying the same signatures
+	// repeatedly, e.g. in an admission controller, does not download them
+	// again. The signature manifests are still fetched from the registry.
+	// If nil, signature envelope blobs are not cached.
+	SignatureBlobCache SignatureBlobCache
+
+	// ConfigMediaType is the media type of the empty config of the signature
+	// manifests pushed by the [Repository], e.g. [ocispec.MediaTypeEmptyJSON]
+	// for registries expecting the OCI image manifest guidance for
+	// artifacts. If it differs from the artifact type, the artifactType field
+	// of the signature manifests is set to the artifact type, so that they
+	// are still listed as signatures. The media type must conform to RFC 6838.
+	// If empty, the artifact type is used as the config media type, as
+	// required by the Notary Project signature specification.
+	ConfigMediaType string
+}
+
+// artifactType returns the artifact type of the signature manifests.
+func (opts RepositoryOptions) artifactType() string {
+	if opts.ArtifactType == "" {
+		return ArtifactTypeNotation
+	}
+	return opts.ArtifactType
+}
+
+// configMediaType returns the media type of the signature manifest configs.
+func (opts RepositoryOptions) configMediaType() string {
+	if opts.ConfigMediaType == "" {
+		return opts.artifactType()
+	}
+	return opts.ConfigMediaType
+}
 
 // repositoryClient implements [Repository]
 type repositoryClient struct {
 	oras.GraphTarget
 	RepositoryOptions
+
+	// referrersSupported caches the result of SupportsReferrers
+	referrersSupported *bool
+	referrersLock      sync.Mutex
 }
 
 // NewRepository returns a new [Repository].
@@ -77,6 +164,148 @@ func NewRepositoryWithOptions(target oras.GraphTarget, opts RepositoryOptions) R
 	}
 }
 
+// NewRemoteRepository returns a new [Repository] backed by a
+// [remote.Repository] for the registry repository named by `reference`
+// (e.g. "registry.example.com/repo"). The tag or digest of `reference`, if
+// any, is ignored.
+//
+// All registry requests are sent through `client`, which allows callers to
+// configure TLS settings, proxies and timeouts. `client` is responsible for
+// authentication as well: to authenticate against the registry, pass an
+// [auth.Client] whose Client field is set to the customized [http.Client].
+// A plain [http.Client] sends requests anonymously. If `client` is nil,
+// [auth.DefaultClient] is used, or an anonymous client trusting the root
+// certificate authorities of [RepositoryOptions.RootCAs] and
+// [RepositoryOptions.RootCAFile] if set.
+//
+// The returned repository uses HTTPS. To talk to a registry over plain HTTP,
+// create a [remote.Repository] with PlainHTTP set and use
+// [NewRepositoryWithOptions] instead.
+func NewRemoteRepository(reference string, client remote.Client, opts RepositoryOptions) (Repository, error) {
+	repo, err := remote.NewRepository(reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create remote repository: %w", err)
+	}
+	if opts.RootCAs != nil || opts.RootCAFile != "" {
+		if client != nil {
+			return nil, errors.New("repositoryOptions.RootCAs and repositoryOptions.RootCAFile cannot be set along with a custom client, configure the TLS settings of the client instead")
+		}
+		httpClient, err := opts.httpClient()
+		if err != nil {
+			return nil, err
+		}
+		client = &auth.Client{
+			Client: httpClient,
+			Cache:  auth.NewCache(),
+		}
+	}
+	if client != nil {
+		repo.Client = client
+	}
+	return NewRepositoryWithOptions(repo, opts), nil
+}
+
+// RepositoryAuthOptions provides user options when creating a [Repository]
+// with [NewRepositoryFromReference].
+type RepositoryAuthOptions struct {
+	RepositoryOptions
+
+	// Credential is the credential used to authenticate against the
+	// registry. If set, CredentialStore is ignored.
+	Credential auth.Credential
+
+	// CredentialStore is the store to look up the credential of the registry
+	// from, e.g. a store created by [credentials.NewStore] for a Docker-style
+	// config file. If both Credential and CredentialStore are empty, the
+	// store of the default Docker config file is used, as returned by
+	// [credentials.NewStoreFromDocker].
+	CredentialStore credentials.Store
+
+	// PlainHTTP talks to the registry over plain HTTP instead of HTTPS.
+	PlainHTTP bool
+}
+
+// NewRepositoryFromReference returns a new [Repository] backed by a
+// [remote.Repository] for the registry repository named by `reference`
+// (e.g. "registry.example.com/repo:v1"), authenticating against the registry
+// with the credential of `opts`. The tag or digest of `reference`, if any,
+// is ignored.
+//
+// The credential is resolved lazily, when the registry requests
+// authentication.
+func NewRepositoryFromReference(ctx context.Context, reference string, opts RepositoryAuthOptions) (Repository, error) {
+	repo, err := remote.NewRepository(reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create remote repository: %w", err)
+	}
+	credential, err := opts.credential(ctx, repo.Reference.Registry)
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := opts.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	repo.Client = &auth.Client{
+		Client:     httpClient,
+		Cache:      auth.NewCache(),
+		Credential: credential,
+	}
+	repo.PlainHTTP = opts.PlainHTTP
+	return NewRepositoryWithOptions(repo, opts.RepositoryOptions), nil
+}
+
+// credential returns the function resolving the credential of registry.
+func (opts RepositoryAuthOptions) credential(ctx context.Context, registry string) (auth.CredentialFunc, error) {
+	if opts.Credential != auth.EmptyCredential {
+		return auth.StaticCredential(registry, opts.Credential), nil
+	}
+	store := opts.CredentialStore
+	if store == nil {
+		log.GetLogger(ctx).Debug("Using the credential store of the default Docker config file")
+		dockerStore, err := credentials.NewStoreFromDocker(credentials.StoreOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the Docker credential store: %w", err)
+		}
+		store = dockerStore
+	}
+	return credentials.Credential(store), nil
+}
+
+// httpClient returns the HTTP client used to talk to the registry, trusting
+// the root certificate authorities of RootCAs and RootCAFile if set.
+func (opts RepositoryOptions) httpClient() (*http.Client, error) {
+	if opts.RootCAs == nil && opts.RootCAFile == "" {
+		return retry.DefaultClient, nil
+	}
+	rootCAs, err := opts.rootCAs()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+	return &http.Client{Transport: retry.NewTransport(transport)}, nil
+}
+
+// rootCAs returns RootCAs with the certificates of RootCAFile added.
+func (opts RepositoryOptions) rootCAs() (*x509.CertPool, error) {
+	if opts.RootCAFile == "" {
+		return opts.RootCAs, nil
+	}
+	pool := x509.NewCertPool()
+	if opts.RootCAs != nil {
+		pool = opts.RootCAs.Clone()
+	}
+	bundle, err := os.ReadFile(opts.RootCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read root CA file: %w", err)
+	}
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("root CA file %s contains no PEM encoded certificate", opts.RootCAFile)
+	}
+	return pool, nil
+}
+
 // NewOCIRepository returns a new [Repository] with oci.Store as
 // its oras.GraphTarget. `path` denotes directory path to the target OCI layout.
 func NewOCIRepository(path string, opts RepositoryOptions) (Repository, error) {
@@ -103,22 +332,151 @@ func (c *repositoryClient) Resolve(ctx context.Context, reference string) (ocisp
 }
 
 // ListSignatures returns signature manifests filtered by fn given the
-// target artifact's manifest descriptor
+// target artifact's manifest descriptor.
+// Referrers of other artifact types sharing the subject, such as SBOMs or
+// attestations, and referrers that are not manifests are skipped.
 func (c *repositoryClient) ListSignatures(ctx context.Context, desc ocispec.Descriptor, fn func(signatureManifests []ocispec.Descriptor) error) error {
+	artifactType := c.artifactType()
 	if repo, ok := c.GraphTarget.(registry.ReferrerLister); ok {
-		return repo.Referrers(ctx, desc, ArtifactTypeNotation, fn)
+		return repo.Referrers(ctx, desc, artifactType, func(referrers []ocispec.Descriptor) error {
+			return fn(filterSignatureManifests(referrers, artifactType))
+		})
 	}
 
-	signatureManifests, err := signatureReferrers(ctx, c.GraphTarget, desc)
+	signatureManifests, err := signatureReferrers(ctx, c.GraphTarget, desc, artifactType)
 	if err != nil {
 		return fmt.Errorf("failed to get referrers during ListSignatures due to %w", err)
 	}
 	return fn(signatureManifests)
 }
 
+// SupportsReferrers reports whether the remote registry supports the
+// Referrers API, or ListSignatures falls back to the referrers tag schema.
+// The registry is probed once, and the answer is cached.
+// For targets other than a remote repository, such as an OCI layout, it
+// returns false since signatures are listed from the local graph.
+func (c *repositoryClient) SupportsReferrers(ctx context.Context) (bool, error) {
+	repo, ok := c.GraphTarget.(*remote.Repository)
+	if !ok {
+		return false, nil
+	}
+
+	c.referrersLock.Lock()
+	defer c.referrersLock.Unlock()
+	if c.referrersSupported != nil {
+		return *c.referrersSupported, nil
+	}
+	supported, err := probeReferrers(ctx, repo)
+	if err != nil {
+		return false, err
+	}
+	c.referrersSupported = &supported
+	// let oras skip its own detection; the capability may already be set
+	_ = repo.SetReferrersCapability(supported)
+	return supported, nil
+}
+
+// probeReferrers queries the Referrers API of repo with an arbitrary digest,
+// and inspects the response.
+// reference: https://github.com/opencontainers/distribution-spec/blob/v1.1.0/spec.md#listing-referrers
+func probeReferrers(ctx context.Context, repo *remote.Repository) (bool, error) {
+	scheme := "https"
+	if repo.PlainHTTP {
+		scheme = "http"
+	}
+	ref := repo.Reference
+	url := fmt.Sprintf("%s://%s/v2/%s/referrers/%s", scheme, ref.Host(), ref.Repository, ocispec.DescriptorEmptyJSON.Digest)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	client := repo.Client
+	if client == nil {
+		client = auth.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to query referrers API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Header.Get("Content-Type") == ocispec.MediaTypeImageIndex, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to query referrers API: %s %q: unexpected status code %d", req.Method, req.URL, resp.StatusCode)
+	}
+}
+
+// TagSignature tags the signature manifest described by sigManifestDesc with
+// tag. tag must be a valid tag, and a [ErrorTagSignature] is returned if the
+// repository rejects it.
+func (c *repositoryClient) TagSignature(ctx context.Context, sigManifestDesc ocispec.Descriptor, tag string) error {
+	if err := sigManifestDesc.Digest.Validate(); err != nil {
+		return fmt.Errorf("invalid signature manifest descriptor: %w", err)
+	}
+	if err := (registry.Reference{Reference: tag}).ValidateReferenceAsTag(); err != nil {
+		return fmt.Errorf("invalid tag %q: %w", tag, err)
+	}
+	if err := c.GraphTarget.Tag(ctx, sigManifestDesc, tag); err != nil {
+		return ErrorTagSignature{
+			Msg:        fmt.Sprintf("failed to tag signature manifest %s with %q: %v", sigManifestDesc.Digest, tag, err),
+			InnerError: err,
+		}
+	}
+	return nil
+}
+
+// FetchIndex returns the image index described by desc.
+func (c *repositoryClient) FetchIndex(ctx context.Context, desc ocispec.Descriptor) (ocispec.Index, error) {
+	if desc.MediaType != ocispec.MediaTypeImageIndex && desc.MediaType != mediaTypeDockerManifestList {
+		return ocispec.Index{}, fmt.Errorf("desc.MediaType requires %q or %q, got %q", ocispec.MediaTypeImageIndex, mediaTypeDockerManifestList, desc.MediaType)
+	}
+	if desc.Size > maxManifestSizeLimit {
+		return ocispec.Index{}, fmt.Errorf("image index too large: %d bytes", desc.Size)
+	}
+	var fetcher content.Fetcher = c.GraphTarget
+	if repo, ok := c.GraphTarget.(registry.Repository); ok {
+		fetcher = repo.Manifests()
+	}
+	indexJSON, err := content.FetchAll(ctx, fetcher, desc)
+	if err != nil {
+		return ocispec.Index{}, err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(indexJSON, &index); err != nil {
+		return ocispec.Index{}, fmt.Errorf("failed to unmarshal image index %s: %w", desc.Digest, err)
+	}
+	return index, nil
+}
+
 // FetchSignatureBlob returns signature envelope blob and descriptor given
 // signature manifest descriptor
 func (c *repositoryClient) FetchSignatureBlob(ctx context.Context, desc ocispec.Descriptor) ([]byte, ocispec.Descriptor, error) {
+	rc, sigBlobDesc, err := c.FetchSignatureBlobStream(ctx, desc)
+	if err != nil {
+		return nil, ocispec.Descriptor{}, err
+	}
+	defer rc.Close()
+
+	// the stream is capped to the size of the signature blob
+	sigBlob, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, ocispec.Descriptor{}, err
+	}
+	return sigBlob, sigBlobDesc, nil
+}
+
+// FetchSignatureBlobStream returns a reader of the signature envelope blob
+// and its descriptor given signature manifest descriptor.
+// The reader reads at most the size of the signature blob, and returns an
+// error at the end of the stream if the content does not match the
+// descriptor. The caller must close the reader.
+// A signature envelope blob compressed with gzip is decompressed, up to
+// 32 MiB, and the returned descriptor describes the decompressed envelope.
+func (c *repositoryClient) FetchSignatureBlobStream(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, ocispec.Descriptor, error) {
 	sigBlobDesc, err := c.getSignatureBlobDesc(ctx, desc)
 	if err != nil {
 		return nil, ocispec.Descriptor{}, err
@@ -127,44 +485,282 @@ func (c *repositoryClient) FetchSignatureBlob(ctx context.Context, desc ocispec.
 		return nil, ocispec.Descriptor{}, fmt.Errorf("signature blob too large: %d bytes", sigBlobDesc.Size)
 	}
 
+	var rc io.ReadCloser
+	if c.SignatureBlobCache != nil {
+		sigBlob, err := c.fetchCachedBlob(ctx, sigBlobDesc)
+		if err != nil {
+			return nil, ocispec.Descriptor{}, err
+		}
+		rc = io.NopCloser(bytes.NewReader(sigBlob))
+	} else {
+		rc, err = c.fetchBlob(ctx, sigBlobDesc)
+		if err != nil {
+			return nil, ocispec.Descriptor{}, err
+		}
+	}
+	mediaType, compressed := strings.CutSuffix(sigBlobDesc.MediaType, MediaTypeSuffixGzip)
+	if !compressed {
+		return rc, sigBlobDesc, nil
+	}
+	defer rc.Close()
+	sigBlob, err := decompressSignatureBlob(rc)
+	if err != nil {
+		return nil, ocispec.Descriptor{}, err
+	}
+	return io.NopCloser(bytes.NewReader(sigBlob)), content.NewDescriptorFromBytes(mediaType, sigBlob), nil
+}
+
+// fetchBlob returns a reader of the blob described by desc, verifying its
+// content against desc.
+func (c *repositoryClient) fetchBlob(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
 	var fetcher content.Fetcher = c.GraphTarget
 	if repo, ok := c.GraphTarget.(registry.Repository); ok {
 		fetcher = repo.Blobs()
 	}
-	sigBlob, err := content.FetchAll(ctx, fetcher, sigBlobDesc)
+	rc, err := fetcher.Fetch(ctx, desc)
 	if err != nil {
-		return nil, ocispec.Descriptor{}, err
+		return nil, err
 	}
-	return sigBlob, sigBlobDesc, nil
+	return &verifyReadCloser{
+		VerifyReader: content.NewVerifyReader(rc, desc),
+		Closer:       rc,
+	}, nil
+}
+
+// fetchCachedBlob returns the blob described by desc from the signature blob
+// cache, or fetches it and stores it in the cache on cache miss. Failing to
+// use the cache does not fail the fetch.
+func (c *repositoryClient) fetchCachedBlob(ctx context.Context, desc ocispec.Descriptor) ([]byte, error) {
+	logger := log.GetLogger(ctx)
+	blob, err := c.SignatureBlobCache.Get(ctx, desc)
+	if err == nil {
+		return blob, nil
+	}
+	if !errors.Is(err, ErrCacheMiss) {
+		logger.Warnf("Failed to get signature blob %v from cache: %v", desc.Digest, err)
+	}
+
+	rc, err := c.fetchBlob(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	blob, err = io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.SignatureBlobCache.Set(ctx, desc, blob); err != nil {
+		logger.Warnf("Failed to store signature blob %v in cache: %v", desc.Digest, err)
+	}
+	return blob, nil
+}
+
+// compressSignatureBlob compresses blob with gzip.
+func compressSignatureBlob(blob []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(blob); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressSignatureBlob decompresses the gzip compressed signature blob
+// read from r. The decompressed size is limited to maxBlobSizeLimit to
+// prevent decompression bombs.
+func decompressSignatureBlob(r io.Reader) ([]byte, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress signature blob: %w", err)
+	}
+	defer zr.Close()
+	sigBlob, err := io.ReadAll(io.LimitReader(zr, maxBlobSizeLimit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress signature blob: %w", err)
+	}
+	if len(sigBlob) > maxBlobSizeLimit {
+		return nil, fmt.Errorf("decompressed signature blob too large: exceeds %d bytes", maxBlobSizeLimit)
+	}
+	// read to the end of the compressed blob so that its content is verified
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return nil, err
+	}
+	return sigBlob, nil
+}
+
+// verifyReadCloser verifies the content read against its descriptor upon
+// reaching the end of the stream.
+type verifyReadCloser struct {
+	*content.VerifyReader
+	io.Closer
+}
+
+// Read reads up to len(p) bytes into p. At the end of the stream, it returns
+// the verification error instead of io.EOF if the verification fails.
+func (r *verifyReadCloser) Read(p []byte) (int, error) {
+	n, err := r.VerifyReader.Read(p)
+	if err == io.EOF {
+		if verifyErr := r.Verify(); verifyErr != nil {
+			return n, verifyErr
+		}
+	}
+	return n, err
 }
 
 // PushSignature creates and uploads an signature manifest along with its
 // linked signature envelope blob. Upon successful, PushSignature returns
 // signature envelope blob and manifest descriptors.
+// Content that already exists in the repository is not pushed again, see
+// [ResumableSignaturePusher].
 func (c *repositoryClient) PushSignature(ctx context.Context, mediaType string, blob []byte, subject ocispec.Descriptor, annotations map[string]string) (blobDesc, manifestDesc ocispec.Descriptor, err error) {
-	var pusher content.Pusher = c.GraphTarget
+	result, err := c.PushSignatureResumable(ctx, mediaType, blob, subject, annotations)
+	if err != nil {
+		return ocispec.Descriptor{}, ocispec.Descriptor{}, err
+	}
+	return result.BlobDesc, result.ManifestDesc, nil
+}
+
+// PushSignatureResumable pushes the signature as PushSignature does, skipping the signature envelope blob and the signature manifest if
+// they already exist in the repository, e.g. pushed by an interrupted
+// attempt.
+// The signature manifest can only be reused if annotations contains the
+// "org.opencontainers.image.created" annotation, as its digest otherwise
+// depends on the time of the push.
+func (c *repositoryClient) PushSignatureResumable(ctx context.Context, mediaType string, blob []byte, subject ocispec.Descriptor, annotations map[string]string) (PushSignatureResult, error) {
+	var blobs content.Storage = c.GraphTarget
 	if repo, ok := c.GraphTarget.(registry.Repository); ok {
-		pusher = repo.Blobs()
+		blobs = repo.Blobs()
+	}
+	if c.CompressSignatureBlobs {
+		var err error
+		if blob, err = compressSignatureBlob(blob); err != nil {
+			return PushSignatureResult{}, fmt.Errorf("failed to compress signature blob: %w", err)
+		}
+		mediaType += MediaTypeSuffixGzip
 	}
-	blobDesc, err = oras.PushBytes(ctx, pusher, mediaType, blob)
+	var result PushSignatureResult
+	var err error
+	result.BlobDesc = content.NewDescriptorFromBytes(mediaType, blob)
+	result.BlobExisted, err = pushIfNotExist(ctx, blobs, result.BlobDesc, blob)
 	if err != nil {
-		return ocispec.Descriptor{}, ocispec.Descriptor{}, err
+		return PushSignatureResult{}, err
 	}
-	manifestDesc, err = c.uploadSignatureManifest(ctx, subject, blobDesc, annotations)
+	result.ManifestDesc, result.ManifestExisted, err = c.uploadSignatureManifest(ctx, subject, result.BlobDesc, annotations)
 	if err != nil {
-		return ocispec.Descriptor{}, ocispec.Descriptor{}, err
+		return PushSignatureResult{}, err
+	}
+	return result, nil
+}
+
+// PredictSignatureManifestDigest returns the digest of the signature manifest
+// that [Repository.PushSignature] pushes for the signature blob of the given
+// media type and the given subject and annotations, without pushing anything.
+// It reproduces the OCI image manifest packed by PushSignature for a
+// [Repository] with the default [ArtifactTypeNotation] artifact type and
+// without [RepositoryOptions.CompressSignatureBlobs].
+//
+// Since the manifest records its creation time, annotations must contain the
+// [ocispec.AnnotationCreated] annotation in RFC 3339 format, and the same
+// annotations must be passed to PushSignature.
+func PredictSignatureManifestDigest(subject ocispec.Descriptor, blob []byte, mediaType string, annotations map[string]string) (digest.Digest, error) {
+	created, ok := annotations[ocispec.AnnotationCreated]
+	if !ok {
+		return "", fmt.Errorf("annotation %q is required to predict the signature manifest digest", ocispec.AnnotationCreated)
+	}
+	if _, err := time.Parse(time.RFC3339, created); err != nil {
+		return "", fmt.Errorf("annotation %q is not in RFC 3339 format: %w", ocispec.AnnotationCreated, err)
 	}
-	return blobDesc, manifestDesc, nil
+	manifestJSON, err := json.Marshal(newSignatureManifest(subject, content.NewDescriptorFromBytes(mediaType, blob), notationEmptyConfigDesc, "", annotations))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal signature manifest: %w", err)
+	}
+	return digest.FromBytes(manifestJSON), nil
+}
+
+// EstimateSignatureSize returns the sizes in bytes of the signature envelope
+// blob and of the signature manifest that [Repository.PushSignature] pushes
+// for blob and annotations, without pushing anything, e.g. to estimate the
+// storage used by signatures in a registry.
+// If ociImageManifest is true, the size of the OCI image manifest pushed by
+// this package is estimated. Otherwise, the size of the OCI artifact manifest
+// pushed by earlier versions is estimated.
+//
+// As the subject of the signature is not known, a subject image manifest
+// with a SHA-256 digest is assumed, so the actual manifest size may differ by
+// a few bytes. [RepositoryOptions.CompressSignatureBlobs] is not taken into
+// account.
+func EstimateSignatureSize(blob []byte, annotations map[string]string, ociImageManifest bool) (blobSize, manifestSize int64) {
+	mediaType := envelope.DetectMediaType(blob)
+	if mediaType == "" {
+		mediaType = jws.MediaTypeEnvelope
+	}
+	blobDesc := content.NewDescriptorFromBytes(mediaType, blob)
+	subject := ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(nil),
+		Size:      estimatedSubjectSize,
+	}
+	if withCreated, err := withAnnotationCreated(annotations); err == nil {
+		// annotations with an invalid creation time are estimated as is
+		annotations = withCreated
+	}
+
+	var manifest any
+	if ociImageManifest {
+		manifest = newSignatureManifest(subject, blobDesc, notationEmptyConfigDesc, "", annotations)
+	} else {
+		manifest = artifactspec.Artifact{
+			MediaType:    artifactspec.MediaTypeArtifactManifest,
+			ArtifactType: ArtifactTypeNotation,
+			Blobs:        []ocispec.Descriptor{blobDesc},
+			Subject:      &subject,
+			Annotations:  annotations,
+		}
+	}
+	// marshaling manifests of descriptors and string maps cannot fail
+	manifestJSON, _ := json.Marshal(manifest)
+	return blobDesc.Size, int64(len(manifestJSON))
 }
 
 // getSignatureBlobDesc returns signature blob descriptor from
 // signature manifest blobs or layers given signature manifest descriptor
 func (c *repositoryClient) getSignatureBlobDesc(ctx context.Context, sigManifestDesc ocispec.Descriptor) (ocispec.Descriptor, error) {
+	signatureBlobs, _, err := c.fetchSignatureManifest(ctx, sigManifestDesc)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if len(signatureBlobs) != 1 {
+		return ocispec.Descriptor{}, ErrorMalformedSignatureManifest{Digest: sigManifestDesc.Digest, BlobCount: len(signatureBlobs)}
+	}
+
+	return signatureBlobs[0], nil
+}
+
+// SignatureSubject returns the descriptor of the artifact signed by the
+// signature, read from the subject field of the signature manifest described
+// by sigManifestDesc.
+func (c *repositoryClient) SignatureSubject(ctx context.Context, sigManifestDesc ocispec.Descriptor) (ocispec.Descriptor, error) {
+	_, subject, err := c.fetchSignatureManifest(ctx, sigManifestDesc)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if subject == nil {
+		return ocispec.Descriptor{}, fmt.Errorf("signature manifest %s has no subject", sigManifestDesc.Digest)
+	}
+	return *subject, nil
+}
+
+// fetchSignatureManifest fetches the signature manifest described by
+// sigManifestDesc, and returns its signature blobs or layers and its subject.
+func (c *repositoryClient) fetchSignatureManifest(ctx context.Context, sigManifestDesc ocispec.Descriptor) ([]ocispec.Descriptor, *ocispec.Descriptor, error) {
 	if sigManifestDesc.MediaType != artifactspec.MediaTypeArtifactManifest && sigManifestDesc.MediaType != ocispec.MediaTypeImageManifest {
-		return ocispec.Descriptor{}, fmt.Errorf("sigManifestDesc.MediaType requires %q or %q, got %q", artifactspec.MediaTypeArtifactManifest, ocispec.MediaTypeImageManifest, sigManifestDesc.MediaType)
+		return nil, nil, fmt.Errorf("sigManifestDesc.MediaType requires %q or %q, got %q", artifactspec.MediaTypeArtifactManifest, ocispec.MediaTypeImageManifest, sigManifestDesc.MediaType)
 	}
 	if sigManifestDesc.Size > maxManifestSizeLimit {
-		return ocispec.Descriptor{}, fmt.Errorf("signature manifest too large: %d bytes", sigManifestDesc.Size)
+		return nil, nil, fmt.Errorf("signature manifest too large: %d bytes", sigManifestDesc.Size)
 	}
 
 	// get the signature manifest from sigManifestDesc
@@ -174,55 +770,129 @@ func (c *repositoryClient) getSignatureBlobDesc(ctx context.Context, sigManifest
 	}
 	manifestJSON, err := content.FetchAll(ctx, fetcher, sigManifestDesc)
 	if err != nil {
-		return ocispec.Descriptor{}, err
+		return nil, nil, err
 	}
 
-	// get the signature blob descriptor from signature manifest
-	var signatureBlobs []ocispec.Descriptor
 	// OCI image manifest
 	if sigManifestDesc.MediaType == ocispec.MediaTypeImageManifest {
 		var sigManifest ocispec.Manifest
 		if err := json.Unmarshal(manifestJSON, &sigManifest); err != nil {
-			return ocispec.Descriptor{}, err
-		}
-		signatureBlobs = sigManifest.Layers
-	} else { // OCI artifact manifest
-		var sigManifest artifactspec.Artifact
-		if err := json.Unmarshal(manifestJSON, &sigManifest); err != nil {
-			return ocispec.Descriptor{}, err
+			return nil, nil, ErrorMalformedSignatureManifest{InnerError: err, Digest: sigManifestDesc.Digest}
 		}
-		signatureBlobs = sigManifest.Blobs
+		return sigManifest.Layers, sigManifest.Subject, nil
+	}
+	// OCI artifact manifest
+	var sigManifest artifactspec.Artifact
+	if err := json.Unmarshal(manifestJSON, &sigManifest); err != nil {
+		return nil, nil, ErrorMalformedSignatureManifest{InnerError: err, Digest: sigManifestDesc.Digest}
 	}
+	return sigManifest.Blobs, sigManifest.Subject, nil
+}
 
-	if len(signatureBlobs) != 1 {
-		return ocispec.Descriptor{}, fmt.Errorf("signature manifest requries exactly one signature envelope blob, got %d", len(signatureBlobs))
+// uploadSignatureManifest uploads the signature manifest to the registry,
+// and reports whether it already existed.
+func (c *repositoryClient) uploadSignatureManifest(ctx context.Context, subject, blobDesc ocispec.Descriptor, annotations map[string]string) (ocispec.Descriptor, bool, error) {
+	configMediaType := c.configMediaType()
+	var artifactType string
+	if configMediaType != c.artifactType() {
+		// the artifact type is no longer conveyed by the config media type
+		artifactType = c.artifactType()
+		if err := validateMediaType(configMediaType); err != nil {
+			return ocispec.Descriptor{}, false, fmt.Errorf("invalid signature manifest config media type: %w", err)
+		}
+		if err := validateMediaType(artifactType); err != nil {
+			return ocispec.Descriptor{}, false, fmt.Errorf("invalid signature manifest artifact type: %w", err)
+		}
+	}
+	manifestAnnotations, err := withAnnotationCreated(annotations)
+	if err != nil {
+		return ocispec.Descriptor{}, false, err
+	}
+	configDesc, err := pushNotationManifestConfig(ctx, c.GraphTarget, configMediaType)
+	if err != nil {
+		return ocispec.Descriptor{}, false, fmt.Errorf("failed to push notation manifest config: %w", err)
 	}
 
-	return signatureBlobs[0], nil
+	// the manifest is packed as oras.PackManifest does, so that its digest
+	// is known before pushing
+	manifestJSON, err := json.Marshal(newSignatureManifest(subject, blobDesc, configDesc, artifactType, manifestAnnotations))
+	if err != nil {
+		return ocispec.Descriptor{}, false, fmt.Errorf("failed to marshal signature manifest: %w", err)
+	}
+	manifestDesc := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, manifestJSON)
+	manifestDesc.ArtifactType = artifactType
+	manifestDesc.Annotations = manifestAnnotations
+	existed, err := pushIfNotExist(ctx, c.GraphTarget, manifestDesc, manifestJSON)
+	if err != nil {
+		return ocispec.Descriptor{}, false, fmt.Errorf("failed to push manifest: %w", err)
+	}
+	return manifestDesc, existed, nil
 }
 
-// uploadSignatureManifest uploads the signature manifest to the registry
-func (c *repositoryClient) uploadSignatureManifest(ctx context.Context, subject, blobDesc ocispec.Descriptor, annotations map[string]string) (ocispec.Descriptor, error) {
-	configDesc, err := pushNotationManifestConfig(ctx, c.GraphTarget)
-	if err != nil {
-		return ocispec.Descriptor{}, fmt.Errorf("failed to push notation manifest config: %w", err)
+// newSignatureManifest returns the OCI image manifest of a signature, as
+// packed by oras.PackManifest with oras.PackManifestVersion1_1. The
+// artifactType field is omitted if artifactType is empty.
+func newSignatureManifest(subject, blobDesc, configDesc ocispec.Descriptor, artifactType string, annotations map[string]string) ocispec.Manifest {
+	return ocispec.Manifest{
+		Versioned: specs.Versioned{
+			SchemaVersion: 2,
+		},
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: artifactType,
+		Config:       configDesc,
+		Layers:       []ocispec.Descriptor{blobDesc},
+		Subject:      &subject,
+		Annotations:  annotations,
 	}
+}
 
-	opts := oras.PackManifestOptions{
-		Subject:             &subject,
-		ManifestAnnotations: annotations,
-		Layers:              []ocispec.Descriptor{blobDesc},
-		ConfigDescriptor:    &configDesc,
+// withAnnotationCreated returns a copy of annotations with the
+// [ocispec.AnnotationCreated] annotation set to the current time, unless it
+// is already present, as oras.PackManifest does. An
+// [oras.ErrInvalidDateTimeFormat] error is returned if the present annotation
+// is not in RFC 3339 format.
+func withAnnotationCreated(annotations map[string]string) (map[string]string, error) {
+	if created, ok := annotations[ocispec.AnnotationCreated]; ok {
+		if _, err := time.Parse(time.RFC3339, created); err != nil {
+			return nil, fmt.Errorf("%w: %v", oras.ErrInvalidDateTimeFormat, err)
+		}
 	}
+	result := make(map[string]string, len(annotations)+1)
+	for k, v := range annotations {
+		result[k] = v
+	}
+	if _, ok := result[ocispec.AnnotationCreated]; !ok {
+		result[ocispec.AnnotationCreated] = time.Now().UTC().Format(time.RFC3339)
+	}
+	return result, nil
+}
 
-	return oras.PackManifest(ctx, c.GraphTarget, oras.PackManifestVersion1_1, "", opts)
+// pushIfNotExist pushes data described by desc to storage if it does not
+// exist, and reports whether it already existed.
+func pushIfNotExist(ctx context.Context, storage content.Storage, desc ocispec.Descriptor, data []byte) (bool, error) {
+	exists, err := storage.Exists(ctx, desc)
+	if err != nil {
+		return false, fmt.Errorf("unable to verify existence: %s: %s. Details: %w", desc.Digest.String(), desc.MediaType, err)
+	}
+	if exists {
+		return true, nil
+	}
+	if err := storage.Push(ctx, desc, bytes.NewReader(data)); err != nil {
+		if errors.Is(err, errdef.ErrAlreadyExists) {
+			return true, nil
+		}
+		return false, err
+	}
+	return false, nil
 }
 
-// pushNotationManifestConfig pushes an empty notation manifest config, if it
-// doesn't exist.
+// pushNotationManifestConfig pushes an empty notation manifest config of
+// media type mediaType, if it doesn't exist.
 //
 // if the config exists, it returns the descriptor of the config without error.
-func pushNotationManifestConfig(ctx context.Context, pusher content.Storage) (ocispec.Descriptor, error) {
+func pushNotationManifestConfig(ctx context.Context, pusher content.Storage, mediaType string) (ocispec.Descriptor, error) {
+	notationEmptyConfigDesc := notationEmptyConfigDesc
+	notationEmptyConfigDesc.MediaType = mediaType
 	// check if the config exists
 	exists, err := pusher.Exists(ctx, notationEmptyConfigDesc)
 	if err != nil {
@@ -239,9 +909,26 @@ func pushNotationManifestConfig(ctx context.Context, pusher content.Storage) (oc
 	return notationEmptyConfigDesc, nil
 }
 
+// filterSignatureManifests returns the referrers that are signature manifests
+// of artifactType. The artifact type is checked again in case a registry
+// claims to have filtered the referrers without doing so.
+func filterSignatureManifests(referrers []ocispec.Descriptor, artifactType string) []ocispec.Descriptor {
+	signatureManifests := make([]ocispec.Descriptor, 0, len(referrers))
+	for _, referrer := range referrers {
+		if referrer.ArtifactType != artifactType {
+			continue
+		}
+		if referrer.MediaType != ocispec.MediaTypeImageManifest && referrer.MediaType != artifactspec.MediaTypeArtifactManifest {
+			continue
+		}
+		signatureManifests = append(signatureManifests, referrer)
+	}
+	return signatureManifests
+}
+
 // signatureReferrers returns referrer nodes of desc in target filtered by
-// the "application/vnd.cncf.notary.signature" artifact type
-func signatureReferrers(ctx context.Context, target content.ReadOnlyGraphStorage, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+// artifactType, e.g. "application/vnd.cncf.notary.signature"
+func signatureReferrers(ctx context.Context, target content.ReadOnlyGraphStorage, desc ocispec.Descriptor, artifactType string) ([]ocispec.Descriptor, error) {
 	var results []ocispec.Descriptor
 	predecessors, err := target.Predecessors(ctx, desc)
 	if err != nil {
@@ -281,13 +968,18 @@ func signatureReferrers(ctx context.Context, target content.ReadOnlyGraphStorage
 			if image.Subject == nil || !content.Equal(*image.Subject, desc) {
 				continue
 			}
-			node.ArtifactType = image.Config.MediaType
+			// the artifact type of an image manifest defaults to its
+			// config media type
+			node.ArtifactType = image.ArtifactType
+			if node.ArtifactType == "" {
+				node.ArtifactType = image.Config.MediaType
+			}
 			node.Annotations = image.Annotations
 		default:
 			continue
 		}
-		// only keep nodes of "application/vnd.cncf.notary.signature"
-		if node.ArtifactType == ArtifactTypeNotation {
+		// only keep nodes of artifactType
+		if node.ArtifactType == artifactType {
 			results = append(results, node)
 		}
 	}
@@ -16,9 +16,15 @@ package registry
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -32,12 +38,17 @@ import (
 	"github.com/notaryproject/notation-go/internal/slices"
 	"github.com/notaryproject/notation-go/registry/internal/artifactspec"
 	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry"
 	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
 )
 
 const (
@@ -208,6 +219,13 @@ func (c mockRemoteClient) Do(req *http.Request) (*http.Response, error) {
 			Body:       io.NopCloser(bytes.NewReader([]byte(msg))),
 		}, nil
 	default:
+		if req.Method == http.MethodHead {
+			// content to be pushed does not exist yet
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(bytes.NewReader([]byte{})),
+			}, nil
+		}
 		_, digest, found := strings.Cut(req.URL.Path, "/v2/test/manifests/")
 		if found && !slices.Contains(validDigestWithAlgoSlice, digest) {
 			resp := &http.Response{
@@ -367,6 +385,116 @@ func TestListSignatures(t *testing.T) {
 	}
 }
 
+// mixedReferrersClient serves a referrers page with notation signatures
+// mixed with referrers of other types, and claims that the artifact type
+// filter has been applied.
+type mixedReferrersClient struct{}
+
+func (c mixedReferrersClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || !strings.HasPrefix(req.URL.Path, "/v2/test/referrers/") {
+		return nil, fmt.Errorf("unexpected request: %s %s", req.Method, req.URL)
+	}
+	index := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{
+			{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("signature"), Size: 1, ArtifactType: ArtifactTypeNotation},
+			{MediaType: artifactspec.MediaTypeArtifactManifest, Digest: digest.FromString("legacy signature"), Size: 1, ArtifactType: ArtifactTypeNotation},
+			{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.FromString("sbom"), Size: 1, ArtifactType: "application/spdx+json"},
+			{MediaType: ocispec.MediaTypeImageIndex, Digest: digest.FromString("index"), Size: 1, ArtifactType: ArtifactTypeNotation},
+			{MediaType: "application/octet-stream", Digest: digest.FromString("blob"), Size: 1, ArtifactType: ArtifactTypeNotation},
+		},
+	}
+	indexJSON, err := json.Marshal(index)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type":        []string{ocispec.MediaTypeImageIndex},
+			"Oci-Filters-Applied": []string{"artifactType"},
+		},
+		Body:    io.NopCloser(bytes.NewReader(indexJSON)),
+		Request: req,
+	}, nil
+}
+
+func TestListSignaturesMixedReferrers(t *testing.T) {
+	wantDigests := []digest.Digest{digest.FromString("signature"), digest.FromString("legacy signature")}
+	listDigests := func(t *testing.T, repo Repository, subject ocispec.Descriptor) []digest.Digest {
+		var digests []digest.Digest
+		if err := repo.ListSignatures(context.Background(), subject, func(signatureManifests []ocispec.Descriptor) error {
+			for _, sigManifestDesc := range signatureManifests {
+				digests = append(digests, sigManifestDesc.Digest)
+			}
+			return nil
+		}); err != nil {
+			t.Fatalf("ListSignatures() error = %v", err)
+		}
+		return digests
+	}
+
+	t.Run("remote repository", func(t *testing.T) {
+		ref, err := registry.ParseReference(validReference)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client := newRepositoryClient(mixedReferrersClient{}, ref, false)
+		subject := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: validDigestWithAlgo, Size: 481}
+		if got := listDigests(t, client, subject); !reflect.DeepEqual(got, wantDigests) {
+			t.Fatalf("expected signature manifests %v, got %v", wantDigests, got)
+		}
+	})
+
+	t.Run("local graph", func(t *testing.T) {
+		ctx := context.Background()
+		store := memory.New()
+		subject, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageManifest, []byte("{}"))
+		if err != nil {
+			t.Fatalf("failed to push subject: %v", err)
+		}
+		repo := NewRepository(store)
+		_, sigManifestDesc, err := repo.PushSignature(ctx, joseTag, []byte("signature"), subject, nil)
+		if err != nil {
+			t.Fatalf("failed to push signature: %v", err)
+		}
+		// an SBOM sharing the subject
+		sbomDesc, err := oras.PushBytes(ctx, store, "application/spdx+json", []byte("sbom"))
+		if err != nil {
+			t.Fatalf("failed to push sbom: %v", err)
+		}
+		if _, err := oras.PackManifest(ctx, store, oras.PackManifestVersion1_1, "application/spdx+json", oras.PackManifestOptions{
+			Subject: &subject,
+			Layers:  []ocispec.Descriptor{sbomDesc},
+		}); err != nil {
+			t.Fatalf("failed to push sbom manifest: %v", err)
+		}
+		// an image index of the notation artifact type sharing the subject
+		indexJSON, err := json.Marshal(ocispec.Index{
+			Versioned:    specs.Versioned{SchemaVersion: 2},
+			MediaType:    ocispec.MediaTypeImageIndex,
+			ArtifactType: ArtifactTypeNotation,
+			Manifests:    []ocispec.Descriptor{sigManifestDesc},
+			Subject:      &subject,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageIndex, indexJSON); err != nil {
+			t.Fatalf("failed to push index: %v", err)
+		}
+
+		want := []digest.Digest{sigManifestDesc.Digest}
+		if got := listDigests(t, repo, subject); !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected signature manifests %v, got %v", want, got)
+		}
+		if _, _, err := repo.FetchSignatureBlob(ctx, sigManifestDesc); err != nil {
+			t.Fatalf("FetchSignatureBlob() error = %v", err)
+		}
+	})
+}
+
 func TestPushSignature(t *testing.T) {
 	signature, err := os.ReadFile(signaturePath)
 	if err != nil {
@@ -443,6 +571,19 @@ func TestPushSignatureImageManifest(t *testing.T) {
 	}
 }
 
+func TestPushSignatureInvalidCreatedAnnotation(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	subject, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageManifest, []byte("{}"))
+	if err != nil {
+		t.Fatalf("failed to push subject: %v", err)
+	}
+	_, _, err = NewRepository(store).PushSignature(ctx, joseTag, []byte("signature"), subject, map[string]string{ocispec.AnnotationCreated: "yesterday"})
+	if !errors.Is(err, oras.ErrInvalidDateTimeFormat) {
+		t.Fatalf("expected error %v, got %v", oras.ErrInvalidDateTimeFormat, err)
+	}
+}
+
 // newRepositoryClient creates a new repository client
 func newRepositoryClient(client remote.Client, ref registry.Reference, plainHTTP bool) *repositoryClient {
 	repo := remote.Repository{
@@ -546,13 +687,28 @@ func TestOciLayoutRepositoryPushAndFetch(t *testing.T) {
 				if !content.Equal(sigManifestDesc, expectedSignatureManifestDesc) {
 					continue
 				}
-				_, sigDesc, err := repo.FetchSignatureBlob(context.Background(), sigManifestDesc)
+				sigBlob, sigDesc, err := repo.FetchSignatureBlob(context.Background(), sigManifestDesc)
 				if err != nil {
 					return fmt.Errorf("failed to fetch blob: %w", err)
 				}
 				if !content.Equal(expectedSignatureBlobDesc, sigDesc) {
 					return fmt.Errorf("expected to get signature blob desc: %v, got: %v", expectedSignatureBlobDesc, sigDesc)
 				}
+				rc, sigDesc, err := repo.(SignatureBlobStreamFetcher).FetchSignatureBlobStream(context.Background(), sigManifestDesc)
+				if err != nil {
+					return fmt.Errorf("failed to fetch blob stream: %w", err)
+				}
+				defer rc.Close()
+				if !content.Equal(expectedSignatureBlobDesc, sigDesc) {
+					return fmt.Errorf("expected to get signature blob desc: %v, got: %v", expectedSignatureBlobDesc, sigDesc)
+				}
+				streamedBlob, err := io.ReadAll(rc)
+				if err != nil {
+					return fmt.Errorf("failed to read blob stream: %w", err)
+				}
+				if !bytes.Equal(sigBlob, streamedBlob) {
+					return fmt.Errorf("expected streamed blob to equal fetched blob")
+				}
 				found = true
 			}
 			if !found {
@@ -566,6 +722,33 @@ func TestOciLayoutRepositoryPushAndFetch(t *testing.T) {
 	})
 }
 
+func TestVerifyReadCloser(t *testing.T) {
+	blob := []byte("signature envelope")
+	desc := ocispec.Descriptor{
+		Digest: digest.FromBytes(blob),
+		Size:   int64(len(blob)),
+	}
+	newReader := func(data []byte) io.ReadCloser {
+		return &verifyReadCloser{
+			VerifyReader: content.NewVerifyReader(bytes.NewReader(data), desc),
+			Closer:       io.NopCloser(nil),
+		}
+	}
+
+	if got, err := io.ReadAll(newReader(blob)); err != nil || !bytes.Equal(got, blob) {
+		t.Fatalf("expected %q, got %q with error %v", blob, got, err)
+	}
+	if _, err := io.ReadAll(newReader([]byte("signature envelopf"))); !errors.Is(err, content.ErrMismatchedDigest) {
+		t.Fatalf("expected error %v, got %v", content.ErrMismatchedDigest, err)
+	}
+	if _, err := io.ReadAll(newReader(append(blob, 'x'))); !errors.Is(err, content.ErrTrailingData) {
+		t.Fatalf("expected error %v, got %v", content.ErrTrailingData, err)
+	}
+	if _, err := io.ReadAll(newReader(blob[:4])); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected error %v, got %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
 func TestNewRepository(t *testing.T) {
 	target, err := oci.New(t.TempDir())
 	if err != nil {
@@ -656,13 +839,305 @@ func (s *testStorage) Predecessors(ctx context.Context, node ocispec.Descriptor)
 	return s.PredecessorsDesc, nil
 }
 
+func TestRepositoryArtifactType(t *testing.T) {
+	const artifactType = "application/vnd.example.signature"
+	store := memory.New()
+	subject, err := oras.PushBytes(context.Background(), store, ocispec.MediaTypeImageManifest, []byte("{}"))
+	if err != nil {
+		t.Fatalf("failed to push subject: %v", err)
+	}
+	repo := NewRepositoryWithOptions(store, RepositoryOptions{ArtifactType: artifactType})
+	if _, _, err := repo.PushSignature(context.Background(), joseTag, []byte("signature"), subject, nil); err != nil {
+		t.Fatalf("failed to push signature: %v", err)
+	}
+
+	countSignatures := func(repo Repository) int {
+		var count int
+		if err := repo.ListSignatures(context.Background(), subject, func(signatureManifests []ocispec.Descriptor) error {
+			count += len(signatureManifests)
+			return nil
+		}); err != nil {
+			t.Fatalf("failed to list signatures: %v", err)
+		}
+		return count
+	}
+	if got := countSignatures(repo); got != 1 {
+		t.Fatalf("expected 1 signature of artifact type %q, got %d", artifactType, got)
+	}
+	if got := countSignatures(NewRepository(store)); got != 0 {
+		t.Fatalf("expected no signature of artifact type %q, got %d", ArtifactTypeNotation, got)
+	}
+}
+
+func TestRepositoryConfigMediaType(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	subject, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageManifest, []byte("{}"))
+	if err != nil {
+		t.Fatalf("failed to push subject: %v", err)
+	}
+	repo := NewRepositoryWithOptions(store, RepositoryOptions{ConfigMediaType: ocispec.MediaTypeEmptyJSON})
+	_, manifestDesc, err := repo.PushSignature(ctx, joseTag, []byte("signature"), subject, nil)
+	if err != nil {
+		t.Fatalf("failed to push signature: %v", err)
+	}
+	manifestJSON, err := content.FetchAll(ctx, store, manifestDesc)
+	if err != nil {
+		t.Fatalf("failed to fetch signature manifest: %v", err)
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		t.Fatalf("failed to unmarshal signature manifest: %v", err)
+	}
+	if manifest.Config.MediaType != ocispec.MediaTypeEmptyJSON {
+		t.Fatalf("expected config media type %q, got %q", ocispec.MediaTypeEmptyJSON, manifest.Config.MediaType)
+	}
+	if manifest.ArtifactType != ArtifactTypeNotation {
+		t.Fatalf("expected artifact type %q, got %q", ArtifactTypeNotation, manifest.ArtifactType)
+	}
+	if manifestDesc.ArtifactType != ArtifactTypeNotation {
+		t.Fatalf("expected manifest descriptor artifact type %q, got %q", ArtifactTypeNotation, manifestDesc.ArtifactType)
+	}
+
+	var count int
+	if err := NewRepository(store).ListSignatures(ctx, subject, func(signatureManifests []ocispec.Descriptor) error {
+		count += len(signatureManifests)
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to list signatures: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 signature, got %d", count)
+	}
+
+	t.Run("default", func(t *testing.T) {
+		_, manifestDesc, err := NewRepository(store).PushSignature(ctx, joseTag, []byte("default signature"), subject, nil)
+		if err != nil {
+			t.Fatalf("failed to push signature: %v", err)
+		}
+		manifestJSON, err := content.FetchAll(ctx, store, manifestDesc)
+		if err != nil {
+			t.Fatalf("failed to fetch signature manifest: %v", err)
+		}
+		var manifest ocispec.Manifest
+		if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+			t.Fatalf("failed to unmarshal signature manifest: %v", err)
+		}
+		if manifest.Config.MediaType != ArtifactTypeNotation || manifest.ArtifactType != "" {
+			t.Fatalf("expected config media type %q without artifact type, got %q and %q", ArtifactTypeNotation, manifest.Config.MediaType, manifest.ArtifactType)
+		}
+		if manifestDesc.ArtifactType != "" {
+			t.Fatalf("expected manifest descriptor without artifact type, got %q", manifestDesc.ArtifactType)
+		}
+	})
+
+	t.Run("invalid media type", func(t *testing.T) {
+		repo := NewRepositoryWithOptions(store, RepositoryOptions{ConfigMediaType: "invalid"})
+		expectedErrMsg := `invalid signature manifest config media type: "invalid" is not a valid media type as defined by RFC 6838`
+		if _, _, err := repo.PushSignature(ctx, joseTag, []byte("signature"), subject, nil); err == nil || !strings.Contains(err.Error(), expectedErrMsg) {
+			t.Fatalf("expected error containing %q, got %v", expectedErrMsg, err)
+		}
+	})
+}
+
+func TestFetchSignatureBlobMalformedManifest(t *testing.T) {
+	layer := ocispec.Descriptor{
+		MediaType: joseTag,
+		Digest:    digest.FromString("signature"),
+		Size:      int64(len("signature")),
+	}
+	manifestJSON := func(layers ...ocispec.Descriptor) []byte {
+		b, err := json.Marshal(ocispec.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    ocispec.DescriptorEmptyJSON,
+			Layers:    layers,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	tests := []struct {
+		name          string
+		manifest      []byte
+		wantBlobCount int
+	}{
+		{
+			name:     "no blob",
+			manifest: manifestJSON(),
+		},
+		{
+			name:          "multiple blobs",
+			manifest:      manifestJSON(layer, layer),
+			wantBlobCount: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.New()
+			desc, err := oras.PushBytes(context.Background(), store, ocispec.MediaTypeImageManifest, tt.manifest)
+			if err != nil {
+				t.Fatalf("failed to push manifest: %v", err)
+			}
+			_, _, err = NewRepository(store).FetchSignatureBlob(context.Background(), desc)
+			var malformedErr ErrorMalformedSignatureManifest
+			if !errors.As(err, &malformedErr) {
+				t.Fatalf("expected ErrorMalformedSignatureManifest, got %v", err)
+			}
+			if malformedErr.Digest != desc.Digest {
+				t.Fatalf("expected digest %v, got %v", desc.Digest, malformedErr.Digest)
+			}
+			if malformedErr.BlobCount != tt.wantBlobCount {
+				t.Fatalf("expected blob count %d, got %d", tt.wantBlobCount, malformedErr.BlobCount)
+			}
+			expectedErrMsg := fmt.Sprintf("signature manifest %s requires exactly one signature envelope blob, got %d", desc.Digest, tt.wantBlobCount)
+			if err.Error() != expectedErrMsg {
+				t.Fatalf("expected error %q, got %q", expectedErrMsg, err)
+			}
+		})
+	}
+}
+
+func TestFetchIndex(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	amd64, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageManifest, []byte("{}"))
+	if err != nil {
+		t.Fatalf("failed to push manifest: %v", err)
+	}
+	amd64.Platform = &ocispec.Platform{OS: "linux", Architecture: "amd64"}
+	indexJSON, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: []ocispec.Descriptor{amd64},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexDesc, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageIndex, indexJSON)
+	if err != nil {
+		t.Fatalf("failed to push index: %v", err)
+	}
+	repo := NewRepository(store).(IndexFetcher)
+
+	t.Run("image index", func(t *testing.T) {
+		index, err := repo.FetchIndex(ctx, indexDesc)
+		if err != nil {
+			t.Fatalf("FetchIndex() error = %v", err)
+		}
+		if len(index.Manifests) != 1 || !content.Equal(index.Manifests[0], amd64) || !reflect.DeepEqual(index.Manifests[0].Platform, amd64.Platform) {
+			t.Fatalf("expected manifests %+v, got %+v", []ocispec.Descriptor{amd64}, index.Manifests)
+		}
+	})
+
+	t.Run("not an image index", func(t *testing.T) {
+		expectedErrMsg := fmt.Sprintf("desc.MediaType requires %q or %q, got %q", ocispec.MediaTypeImageIndex, mediaTypeDockerManifestList, ocispec.MediaTypeImageManifest)
+		if _, err := repo.FetchIndex(ctx, amd64); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, got %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("image index too large", func(t *testing.T) {
+		desc := indexDesc
+		desc.Size = maxManifestSizeLimit + 1
+		expectedErrMsg := fmt.Sprintf("image index too large: %d bytes", desc.Size)
+		if _, err := repo.FetchIndex(ctx, desc); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, got %v", expectedErrMsg, err)
+		}
+	})
+}
+
+func TestCompressSignatureBlobs(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	subject, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageManifest, []byte("{}"))
+	if err != nil {
+		t.Fatalf("failed to push subject: %v", err)
+	}
+	signature := bytes.Repeat([]byte("signature envelope"), 100)
+	repo := NewRepositoryWithOptions(store, RepositoryOptions{CompressSignatureBlobs: true})
+	blobDesc, manifestDesc, err := repo.PushSignature(ctx, joseTag, signature, subject, nil)
+	if err != nil {
+		t.Fatalf("failed to push signature: %v", err)
+	}
+	if blobDesc.MediaType != joseTag+MediaTypeSuffixGzip {
+		t.Fatalf("expected blob media type %q, got %q", joseTag+MediaTypeSuffixGzip, blobDesc.MediaType)
+	}
+	if blobDesc.Size >= int64(len(signature)) {
+		t.Fatalf("expected compressed blob to be smaller than %d bytes, got %d", len(signature), blobDesc.Size)
+	}
+
+	// compressed blobs are decompressed with or without the option
+	for _, repo := range []Repository{repo, NewRepository(store)} {
+		sigBlob, sigDesc, err := repo.FetchSignatureBlob(ctx, manifestDesc)
+		if err != nil {
+			t.Fatalf("failed to fetch signature blob: %v", err)
+		}
+		if !bytes.Equal(sigBlob, signature) {
+			t.Fatal("expected fetched signature blob to equal the pushed signature")
+		}
+		if expectedDesc := content.NewDescriptorFromBytes(joseTag, signature); !content.Equal(sigDesc, expectedDesc) {
+			t.Fatalf("expected signature blob desc: %v, got: %v", expectedDesc, sigDesc)
+		}
+	}
+}
+
+func TestFetchCompressedSignatureBlobError(t *testing.T) {
+	ctx := context.Background()
+	compress := func(b []byte) []byte {
+		compressed, err := compressSignatureBlob(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return compressed
+	}
+	tests := []struct {
+		name    string
+		blob    []byte
+		wantErr string
+	}{
+		{
+			name:    "decompression bomb",
+			blob:    compress(make([]byte, maxBlobSizeLimit+1)),
+			wantErr: fmt.Sprintf("decompressed signature blob too large: exceeds %d bytes", maxBlobSizeLimit),
+		},
+		{
+			name:    "not compressed",
+			blob:    []byte("signature envelope"),
+			wantErr: "failed to decompress signature blob: gzip: invalid header",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.New()
+			subject, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageManifest, []byte("{}"))
+			if err != nil {
+				t.Fatalf("failed to push subject: %v", err)
+			}
+			layer, err := oras.PushBytes(ctx, store, joseTag+MediaTypeSuffixGzip, tt.blob)
+			if err != nil {
+				t.Fatalf("failed to push blob: %v", err)
+			}
+			manifestDesc, _, err := NewRepository(store).(*repositoryClient).uploadSignatureManifest(ctx, subject, layer, nil)
+			if err != nil {
+				t.Fatalf("failed to push manifest: %v", err)
+			}
+			_, _, err = NewRepository(store).FetchSignatureBlob(ctx, manifestDesc)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestSignatureReferrers(t *testing.T) {
 	t.Run("get predecessors failed", func(t *testing.T) {
 		store := &testStorage{
 			store:             &memory.Store{},
 			PredecessorsError: fmt.Errorf("failed to get predecessors"),
 		}
-		_, err := signatureReferrers(context.Background(), store, ocispec.Descriptor{})
+		_, err := signatureReferrers(context.Background(), store, ocispec.Descriptor{}, ArtifactTypeNotation)
 		if err == nil {
 			t.Fatalf("expected to fail with getting predecessors")
 		}
@@ -681,7 +1156,7 @@ func TestSignatureReferrers(t *testing.T) {
 		}
 		_, err := signatureReferrers(context.Background(), store, ocispec.Descriptor{
 			Digest: validDigestWithAlgo2,
-		})
+		}, ArtifactTypeNotation)
 		if err == nil {
 			t.Fatalf("expected to fail with artifact manifest exceds max blob size")
 		}
@@ -700,7 +1175,7 @@ func TestSignatureReferrers(t *testing.T) {
 		}
 		_, err := signatureReferrers(context.Background(), store, ocispec.Descriptor{
 			Digest: validDigestWithAlgo2,
-		})
+		}, ArtifactTypeNotation)
 		if err == nil {
 			t.Fatalf("expected to fail with image manifest exceds max blob size")
 		}
@@ -720,7 +1195,7 @@ func TestSignatureReferrers(t *testing.T) {
 		}
 		_, err := signatureReferrers(context.Background(), store, ocispec.Descriptor{
 			Digest: validDigestWithAlgo,
-		})
+		}, ArtifactTypeNotation)
 		if err == nil {
 			t.Fatalf("expected to fail with fetchAll failed")
 		}
@@ -740,7 +1215,7 @@ func TestSignatureReferrers(t *testing.T) {
 		}
 		_, err := signatureReferrers(context.Background(), store, ocispec.Descriptor{
 			Digest: validDigestWithAlgo,
-		})
+		}, ArtifactTypeNotation)
 		if err == nil {
 			t.Fatalf("expected to fail with fetchAll failed")
 		}
@@ -760,7 +1235,7 @@ func TestSignatureReferrers(t *testing.T) {
 		}
 		_, err := signatureReferrers(context.Background(), store, ocispec.Descriptor{
 			Digest: "sha256:24aafc739daae02bcd33471a1b28bcfaaef0bb5e530ef44cd4e5d2445e606690",
-		})
+		}, ArtifactTypeNotation)
 		if err == nil {
 			t.Fatalf("expected to fail with marshal failed")
 		}
@@ -780,7 +1255,7 @@ func TestSignatureReferrers(t *testing.T) {
 		}
 		_, err := signatureReferrers(context.Background(), store, ocispec.Descriptor{
 			Digest: "sha256:24aafc739daae02bcd33471a1b28bcfaaef0bb5e530ef44cd4e5d2445e606690",
-		})
+		}, ArtifactTypeNotation)
 		if err == nil {
 			t.Fatalf("expected to fail with marshal failed")
 		}
@@ -800,7 +1275,7 @@ func TestSignatureReferrers(t *testing.T) {
 		}
 		descriptors, err := signatureReferrers(context.Background(), store, ocispec.Descriptor{
 			Digest: "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
-		})
+		}, ArtifactTypeNotation)
 
 		if err != nil {
 			t.Fatalf("failed to get referrers: %v", err)
@@ -824,7 +1299,7 @@ func TestSignatureReferrers(t *testing.T) {
 		}
 		descriptors, err := signatureReferrers(context.Background(), store, ocispec.Descriptor{
 			Digest: "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
-		})
+		}, ArtifactTypeNotation)
 
 		if err != nil {
 			t.Fatalf("failed to get referrers: %v", err)
@@ -834,3 +1309,578 @@ func TestSignatureReferrers(t *testing.T) {
 		}
 	})
 }
+
+type referrersProbeClient struct {
+	statusCode  int
+	contentType string
+	calls       int
+}
+
+func (c *referrersProbeClient) Do(req *http.Request) (*http.Response, error) {
+	c.calls++
+	if req.Method != http.MethodGet || !strings.HasPrefix(req.URL.Path, "/v2/test/referrers/") {
+		return nil, fmt.Errorf("unexpected request: %s %s", req.Method, req.URL)
+	}
+	header := http.Header{}
+	if c.contentType != "" {
+		header.Set("Content-Type", c.contentType)
+	}
+	return &http.Response{
+		StatusCode: c.statusCode,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader([]byte(`{"manifests":[]}`))),
+		Request:    req,
+	}, nil
+}
+
+func TestSupportsReferrers(t *testing.T) {
+	ref := registry.Reference{
+		Registry:   validRegistry,
+		Repository: "test",
+	}
+	tests := []struct {
+		name        string
+		statusCode  int
+		contentType string
+		want        bool
+		wantErr     bool
+	}{
+		{
+			name:        "supported",
+			statusCode:  http.StatusOK,
+			contentType: ocispec.MediaTypeImageIndex,
+			want:        true,
+		},
+		{
+			name:        "unexpected content type",
+			statusCode:  http.StatusOK,
+			contentType: "text/html",
+		},
+		{
+			name:       "not found",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "unexpected status code",
+			statusCode: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &referrersProbeClient{
+				statusCode:  tt.statusCode,
+				contentType: tt.contentType,
+			}
+			repo := newRepositoryClient(client, ref, false)
+			for i := 0; i < 2; i++ {
+				got, err := repo.SupportsReferrers(context.Background())
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("SupportsReferrers() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if got != tt.want {
+					t.Fatalf("SupportsReferrers() = %v, want %v", got, tt.want)
+				}
+			}
+			wantCalls := 1
+			if tt.wantErr {
+				wantCalls = 2
+			}
+			if client.calls != wantCalls {
+				t.Fatalf("expected %d probe requests, got %d", wantCalls, client.calls)
+			}
+		})
+	}
+
+	t.Run("local target", func(t *testing.T) {
+		repo := NewRepository(memory.New())
+		checker, ok := repo.(ReferrersSupportChecker)
+		if !ok {
+			t.Fatal("expected repository to implement ReferrersSupportChecker")
+		}
+		got, err := checker.SupportsReferrers(context.Background())
+		if err != nil || got {
+			t.Fatalf("SupportsReferrers() = %v, %v, want false, nil", got, err)
+		}
+	})
+}
+
+func TestNewRemoteRepository(t *testing.T) {
+	client := &referrersProbeClient{
+		statusCode:  http.StatusOK,
+		contentType: ocispec.MediaTypeImageIndex,
+	}
+	repo, err := NewRemoteRepository(validRegistry+"/test:v1", client, RepositoryOptions{})
+	if err != nil {
+		t.Fatalf("NewRemoteRepository() error = %v", err)
+	}
+	remoteRepo, ok := repo.(*repositoryClient).GraphTarget.(*remote.Repository)
+	if !ok {
+		t.Fatal("expected the repository to be backed by remote.Repository")
+	}
+	if remoteRepo.Client != client {
+		t.Fatal("expected the custom client to be used")
+	}
+	if remoteRepo.PlainHTTP {
+		t.Fatal("expected HTTPS to be used")
+	}
+	supported, err := repo.(ReferrersSupportChecker).SupportsReferrers(context.Background())
+	if err != nil || !supported {
+		t.Fatalf("SupportsReferrers() = %v, %v, want true, nil", supported, err)
+	}
+	if client.calls != 1 {
+		t.Fatalf("expected requests to go through the custom client, got %d calls", client.calls)
+	}
+
+	t.Run("default client", func(t *testing.T) {
+		repo, err := NewRemoteRepository(validRegistry+"/test", nil, RepositoryOptions{})
+		if err != nil {
+			t.Fatalf("NewRemoteRepository() error = %v", err)
+		}
+		if remoteRepo := repo.(*repositoryClient).GraphTarget.(*remote.Repository); remoteRepo.Client != nil {
+			t.Fatal("expected the default client to be used")
+		}
+	})
+
+	t.Run("invalid reference", func(t *testing.T) {
+		if _, err := NewRemoteRepository("invalid reference", nil, RepositoryOptions{}); err == nil {
+			t.Fatal("expected error for invalid reference")
+		}
+	})
+}
+
+func TestNewRemoteRepositoryRootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	reference := strings.TrimPrefix(server.URL, "https://") + "/test"
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+	rootCAFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(rootCAFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600); err != nil {
+		t.Fatalf("failed to write root CA file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		opts    RepositoryOptions
+		wantErr bool
+	}{
+		{
+			name:    "system roots",
+			wantErr: true,
+		},
+		{
+			name: "root CAs",
+			opts: RepositoryOptions{RootCAs: rootCAs},
+		},
+		{
+			name: "root CA file",
+			opts: RepositoryOptions{RootCAFile: rootCAFile},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := NewRemoteRepository(reference, nil, tt.opts)
+			if err != nil {
+				t.Fatalf("NewRemoteRepository() error = %v", err)
+			}
+			_, err = repo.(ReferrersSupportChecker).SupportsReferrers(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SupportsReferrers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("custom client", func(t *testing.T) {
+		_, err := NewRemoteRepository(reference, &http.Client{}, RepositoryOptions{RootCAs: rootCAs})
+		if err == nil {
+			t.Fatal("expected error for root CAs along with a custom client")
+		}
+	})
+
+	t.Run("invalid root CA file", func(t *testing.T) {
+		invalidFile := filepath.Join(t.TempDir(), "invalid.pem")
+		if err := os.WriteFile(invalidFile, []byte("invalid"), 0600); err != nil {
+			t.Fatalf("failed to write root CA file: %v", err)
+		}
+		expectedErrMsg := fmt.Sprintf("root CA file %s contains no PEM encoded certificate", invalidFile)
+		if _, err := NewRemoteRepository(reference, nil, RepositoryOptions{RootCAFile: invalidFile}); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, got %v", expectedErrMsg, err)
+		}
+		if _, err := NewRemoteRepository(reference, nil, RepositoryOptions{RootCAFile: filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+			t.Fatal("expected error for missing root CA file")
+		}
+	})
+}
+
+func TestNewRepositoryFromReference(t *testing.T) {
+	var gotUsername, gotPassword string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		gotUsername, gotPassword = username, password
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	reference := host + "/test:v1"
+	cred := auth.Credential{Username: "username", Password: "password"}
+
+	credStore := credentials.NewMemoryStore()
+	if err := credStore.Put(context.Background(), host, cred); err != nil {
+		t.Fatalf("failed to put credential: %v", err)
+	}
+	dockerConfigDir := t.TempDir()
+	dockerConfig := fmt.Sprintf(`{"auths":{%q:{"auth":%q}}}`, host, base64.StdEncoding.EncodeToString([]byte("username:password")))
+	if err := os.WriteFile(filepath.Join(dockerConfigDir, "config.json"), []byte(dockerConfig), 0600); err != nil {
+		t.Fatalf("failed to write docker config: %v", err)
+	}
+	t.Setenv("DOCKER_CONFIG", dockerConfigDir)
+
+	tests := []struct {
+		name string
+		opts RepositoryAuthOptions
+	}{
+		{
+			name: "static credential",
+			opts: RepositoryAuthOptions{Credential: cred, CredentialStore: credentials.NewMemoryStore()},
+		},
+		{
+			name: "credential store",
+			opts: RepositoryAuthOptions{CredentialStore: credStore},
+		},
+		{
+			name: "docker config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUsername, gotPassword = "", ""
+			tt.opts.PlainHTTP = true
+			repo, err := NewRepositoryFromReference(context.Background(), reference, tt.opts)
+			if err != nil {
+				t.Fatalf("NewRepositoryFromReference() error = %v", err)
+			}
+			if _, err := repo.Resolve(context.Background(), "v1"); !errors.Is(err, errdef.ErrNotFound) {
+				t.Fatalf("expected ErrNotFound, got %v", err)
+			}
+			if gotUsername != cred.Username || gotPassword != cred.Password {
+				t.Fatalf("expected credential %s:%s, got %s:%s", cred.Username, cred.Password, gotUsername, gotPassword)
+			}
+		})
+	}
+
+	t.Run("invalid reference", func(t *testing.T) {
+		if _, err := NewRepositoryFromReference(context.Background(), "invalid reference", RepositoryAuthOptions{}); err == nil {
+			t.Fatal("expected error for invalid reference")
+		}
+	})
+
+	t.Run("invalid docker config", func(t *testing.T) {
+		invalidConfigDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(invalidConfigDir, "config.json"), []byte("invalid"), 0600); err != nil {
+			t.Fatalf("failed to write docker config: %v", err)
+		}
+		t.Setenv("DOCKER_CONFIG", invalidConfigDir)
+		if _, err := NewRepositoryFromReference(context.Background(), reference, RepositoryAuthOptions{}); err == nil {
+			t.Fatal("expected error for invalid docker config")
+		}
+	})
+}
+
+func TestTagSignature(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	subject, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageManifest, []byte("{}"))
+	if err != nil {
+		t.Fatalf("failed to push subject: %v", err)
+	}
+	repo := NewRepository(store)
+	_, sigManifestDesc, err := repo.PushSignature(ctx, joseTag, []byte("signature"), subject, nil)
+	if err != nil {
+		t.Fatalf("failed to push signature: %v", err)
+	}
+	tagger, ok := repo.(SignatureTagger)
+	if !ok {
+		t.Fatal("expected repository to implement SignatureTagger")
+	}
+
+	t.Run("valid tag", func(t *testing.T) {
+		if err := tagger.TagSignature(ctx, sigManifestDesc, "signature-v1"); err != nil {
+			t.Fatalf("TagSignature() error = %v", err)
+		}
+		desc, err := repo.Resolve(ctx, "signature-v1")
+		if err != nil {
+			t.Fatalf("failed to resolve tag: %v", err)
+		}
+		if desc.Digest != sigManifestDesc.Digest {
+			t.Fatalf("tag resolved to %v, want %v", desc.Digest, sigManifestDesc.Digest)
+		}
+	})
+
+	t.Run("invalid tag", func(t *testing.T) {
+		err := tagger.TagSignature(ctx, sigManifestDesc, "invalid:tag")
+		if err == nil || !strings.HasPrefix(err.Error(), `invalid tag "invalid:tag"`) {
+			t.Fatalf("expected invalid tag error, got %v", err)
+		}
+	})
+
+	t.Run("invalid descriptor", func(t *testing.T) {
+		if err := tagger.TagSignature(ctx, ocispec.Descriptor{}, "signature-v1"); err == nil {
+			t.Fatal("expected error for invalid descriptor")
+		}
+	})
+
+	t.Run("tag rejected", func(t *testing.T) {
+		missing := ocispec.Descriptor{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Digest:    digest.FromString("missing"),
+			Size:      7,
+		}
+		err := tagger.TagSignature(ctx, missing, "signature-v1")
+		var tagErr ErrorTagSignature
+		if !errors.As(err, &tagErr) {
+			t.Fatalf("expected ErrorTagSignature, got %v", err)
+		}
+		if tagErr.Unwrap() == nil {
+			t.Fatal("expected inner error to be set")
+		}
+	})
+}
+
+func TestPushSignatureResumable(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	subject, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageManifest, []byte("{}"))
+	if err != nil {
+		t.Fatalf("failed to push subject: %v", err)
+	}
+	repo := NewRepository(store)
+	pusher, ok := repo.(ResumableSignaturePusher)
+	if !ok {
+		t.Fatal("expected repository to implement ResumableSignaturePusher")
+	}
+	signature := []byte("signature")
+	annotations := map[string]string{ocispec.AnnotationCreated: validTimestamp}
+
+	// simulate a push interrupted after uploading the blob
+	if _, err := oras.PushBytes(ctx, store, joseTag, signature); err != nil {
+		t.Fatalf("failed to push signature blob: %v", err)
+	}
+	result, err := pusher.PushSignatureResumable(ctx, joseTag, signature, subject, annotations)
+	if err != nil {
+		t.Fatalf("PushSignatureResumable() error = %v", err)
+	}
+	if !result.BlobExisted || result.ManifestExisted {
+		t.Fatalf("expected the blob to exist and the manifest to be created, got %+v", result)
+	}
+	wantDigest, err := PredictSignatureManifestDigest(subject, signature, joseTag, annotations)
+	if err != nil {
+		t.Fatalf("PredictSignatureManifestDigest() error = %v", err)
+	}
+	if result.ManifestDesc.Digest != wantDigest {
+		t.Fatalf("expected manifest digest %v, got %v", wantDigest, result.ManifestDesc.Digest)
+	}
+
+	// retry the completed push
+	retried, err := pusher.PushSignatureResumable(ctx, joseTag, signature, subject, annotations)
+	if err != nil {
+		t.Fatalf("PushSignatureResumable() error = %v", err)
+	}
+	if !retried.BlobExisted || !retried.ManifestExisted {
+		t.Fatalf("expected both the blob and the manifest to exist, got %+v", retried)
+	}
+	if !content.Equal(retried.ManifestDesc, result.ManifestDesc) {
+		t.Fatalf("expected manifest %v, got %v", result.ManifestDesc, retried.ManifestDesc)
+	}
+
+	// the created annotation of the caller is not modified
+	withoutCreated := map[string]string{"key": "value"}
+	if _, err := pusher.PushSignatureResumable(ctx, joseTag, signature, subject, withoutCreated); err != nil {
+		t.Fatalf("PushSignatureResumable() error = %v", err)
+	}
+	if _, ok := withoutCreated[ocispec.AnnotationCreated]; ok {
+		t.Fatal("expected annotations of the caller to be left unmodified")
+	}
+}
+
+func TestEstimateSignatureSize(t *testing.T) {
+	signature, err := os.ReadFile(signaturePath)
+	if err != nil {
+		t.Fatalf("failed to read signature: %v", err)
+	}
+	subject := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, bytes.Repeat([]byte("a"), estimatedSubjectSize))
+
+	t.Run("image manifest", func(t *testing.T) {
+		sigAnnotations := map[string]string{"foo": "bar"}
+		blobSize, manifestSize := EstimateSignatureSize(signature, sigAnnotations, true)
+		blobDesc, manifestDesc, err := NewRepository(memory.New()).PushSignature(context.Background(), joseTag, signature, subject, sigAnnotations)
+		if err != nil {
+			t.Fatalf("PushSignature() error = %v", err)
+		}
+		if blobSize != blobDesc.Size {
+			t.Errorf("expected blob size %d, got %d", blobDesc.Size, blobSize)
+		}
+		if manifestSize != manifestDesc.Size {
+			t.Errorf("expected manifest size %d, got %d", manifestDesc.Size, manifestSize)
+		}
+	})
+
+	t.Run("artifact manifest", func(t *testing.T) {
+		sigAnnotations := map[string]string{ocispec.AnnotationCreated: validTimestamp}
+		blobSize, manifestSize := EstimateSignatureSize(signature, sigAnnotations, false)
+		manifestJSON, err := json.Marshal(artifactspec.Artifact{
+			MediaType:    artifactspec.MediaTypeArtifactManifest,
+			ArtifactType: ArtifactTypeNotation,
+			Blobs:        []ocispec.Descriptor{content.NewDescriptorFromBytes(joseTag, signature)},
+			Subject:      &subject,
+			Annotations:  sigAnnotations,
+		})
+		if err != nil {
+			t.Fatalf("failed to marshal artifact manifest: %v", err)
+		}
+		if blobSize != int64(len(signature)) {
+			t.Errorf("expected blob size %d, got %d", len(signature), blobSize)
+		}
+		if manifestSize != int64(len(manifestJSON)) {
+			t.Errorf("expected manifest size %d, got %d", len(manifestJSON), manifestSize)
+		}
+	})
+}
+
+func TestSignatureSubject(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New()
+	subject, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageManifest, []byte("{}"))
+	if err != nil {
+		t.Fatalf("failed to push subject: %v", err)
+	}
+	repo := NewRepository(store)
+	fetcher, ok := repo.(SignatureSubjectFetcher)
+	if !ok {
+		t.Fatal("expected repository to implement SignatureSubjectFetcher")
+	}
+
+	t.Run("image manifest", func(t *testing.T) {
+		_, sigManifestDesc, err := repo.PushSignature(ctx, joseTag, []byte("signature"), subject, nil)
+		if err != nil {
+			t.Fatalf("failed to push signature: %v", err)
+		}
+		got, err := fetcher.SignatureSubject(ctx, sigManifestDesc)
+		if err != nil {
+			t.Fatalf("SignatureSubject() error = %v", err)
+		}
+		if !content.Equal(got, subject) {
+			t.Fatalf("SignatureSubject() = %v, want %v", got, subject)
+		}
+	})
+
+	t.Run("artifact manifest", func(t *testing.T) {
+		blobDesc, err := oras.PushBytes(ctx, store, joseTag, []byte("artifact signature"))
+		if err != nil {
+			t.Fatalf("failed to push signature blob: %v", err)
+		}
+		artifactJSON, err := json.Marshal(artifactspec.Artifact{
+			MediaType:    artifactspec.MediaTypeArtifactManifest,
+			ArtifactType: ArtifactTypeNotation,
+			Blobs:        []ocispec.Descriptor{blobDesc},
+			Subject:      &subject,
+		})
+		if err != nil {
+			t.Fatalf("failed to marshal artifact manifest: %v", err)
+		}
+		sigManifestDesc, err := oras.PushBytes(ctx, store, artifactspec.MediaTypeArtifactManifest, artifactJSON)
+		if err != nil {
+			t.Fatalf("failed to push artifact manifest: %v", err)
+		}
+		got, err := fetcher.SignatureSubject(ctx, sigManifestDesc)
+		if err != nil {
+			t.Fatalf("SignatureSubject() error = %v", err)
+		}
+		if !content.Equal(got, subject) {
+			t.Fatalf("SignatureSubject() = %v, want %v", got, subject)
+		}
+	})
+
+	t.Run("no subject", func(t *testing.T) {
+		manifestJSON, err := json.Marshal(ocispec.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    ocispec.DescriptorEmptyJSON,
+			Layers:    []ocispec.Descriptor{},
+		})
+		if err != nil {
+			t.Fatalf("failed to marshal image manifest: %v", err)
+		}
+		manifestDesc, err := oras.PushBytes(ctx, store, ocispec.MediaTypeImageManifest, manifestJSON)
+		if err != nil {
+			t.Fatalf("failed to push image manifest: %v", err)
+		}
+		expectedErrMsg := fmt.Sprintf("signature manifest %s has no subject", manifestDesc.Digest)
+		if _, err := fetcher.SignatureSubject(ctx, manifestDesc); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, got %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("unsupported media type", func(t *testing.T) {
+		if _, err := fetcher.SignatureSubject(ctx, ocispec.Descriptor{MediaType: ocispec.MediaTypeImageIndex}); err == nil {
+			t.Fatal("expected error for unsupported media type")
+		}
+	})
+}
+
+func TestPredictSignatureManifestDigest(t *testing.T) {
+	signature, err := os.ReadFile(signaturePath)
+	if err != nil {
+		t.Fatalf("failed to read signature: %v", err)
+	}
+
+	t.Run("matches oci layout push", func(t *testing.T) {
+		got, err := PredictSignatureManifestDigest(expectedTargetDesc, signature, joseTag, annotations)
+		if err != nil {
+			t.Fatalf("PredictSignatureManifestDigest() error = %v", err)
+		}
+		if got != expectedSignatureManifestDesc.Digest {
+			t.Fatalf("PredictSignatureManifestDigest() = %v, want %v", got, expectedSignatureManifestDesc.Digest)
+		}
+	})
+
+	t.Run("matches push", func(t *testing.T) {
+		subject := content.NewDescriptorFromBytes(ocispec.MediaTypeImageManifest, []byte(`{"layers":[]}`))
+		sigAnnotations := map[string]string{
+			"foo":                     "bar",
+			ocispec.AnnotationCreated: "2024-05-01T10:00:00Z",
+		}
+		want, err := PredictSignatureManifestDigest(subject, signature, joseTag, sigAnnotations)
+		if err != nil {
+			t.Fatalf("PredictSignatureManifestDigest() error = %v", err)
+		}
+		repo := NewRepository(memory.New())
+		_, manifestDesc, err := repo.PushSignature(context.Background(), joseTag, signature, subject, sigAnnotations)
+		if err != nil {
+			t.Fatalf("PushSignature() error = %v", err)
+		}
+		if manifestDesc.Digest != want {
+			t.Fatalf("PushSignature() pushed manifest %v, but predicted %v", manifestDesc.Digest, want)
+		}
+	})
+
+	t.Run("missing created annotation", func(t *testing.T) {
+		_, err := PredictSignatureManifestDigest(expectedTargetDesc, signature, joseTag, map[string]string{"foo": "bar"})
+		expectedErr := `annotation "org.opencontainers.image.created" is required to predict the signature manifest digest`
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("expected error %q, got %v", expectedErr, err)
+		}
+	})
+
+	t.Run("invalid created annotation", func(t *testing.T) {
+		_, err := PredictSignatureManifestDigest(expectedTargetDesc, signature, joseTag, map[string]string{ocispec.AnnotationCreated: "yesterday"})
+		if err == nil || !strings.HasPrefix(err.Error(), `annotation "org.opencontainers.image.created" is not in RFC 3339 format`) {
+			t.Fatalf("expected RFC 3339 format error, got %v", err)
+		}
+	})
+}
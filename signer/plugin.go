@@ -28,6 +28,7 @@ import (
 	"github.com/notaryproject/notation-go"
 	"github.com/notaryproject/notation-go/internal/envelope"
 	"github.com/notaryproject/notation-go/log"
+	notationplugin "github.com/notaryproject/notation-go/plugin"
 	"github.com/notaryproject/notation-go/plugin/proto"
 	"github.com/notaryproject/notation-plugin-framework-go/plugin"
 	"github.com/opencontainers/go-digest"
@@ -42,8 +43,27 @@ type PluginSigner struct {
 	keyID               string
 	pluginConfig        map[string]string
 	manifestAnnotations map[string]string
+
+	// Env contains additional environment variables for the plugin process,
+	// e.g. credentials or region settings of a cloud KMS. It only applies to
+	// CLI plugins. Env is merged into the environment inherited from the
+	// current process, and is never logged.
+	Env map[string]string
 }
 
+// AnnotationPluginName and AnnotationPluginVersion are the signature manifest
+// annotations recording the name and the version of the plugin that produced
+// the signature, as reported by its get-plugin-metadata command, e.g. for
+// incident response. They are added to the annotations returned by
+// [PluginSigner.PluginAnnotations], and listed along with the signature
+// manifests by the ListSignatures method of registry.Repository.
+// They are not covered by the signature, as manifest annotations can be
+// altered in the registry.
+const (
+	AnnotationPluginName    = "org.notaryproject.notation.plugin.name"
+	AnnotationPluginVersion = "org.notaryproject.notation.plugin.version"
+)
+
 var algorithms = map[crypto.Hash]digest.Algorithm{
 	crypto.SHA256: digest.SHA256,
 	crypto.SHA384: digest.SHA384,
@@ -77,6 +97,14 @@ func NewPluginSigner(plugin plugin.SignPlugin, keyID string, pluginConfig map[st
 	}, nil
 }
 
+// withEnv returns ctx carrying s.Env for the plugin process, if any.
+func (s *PluginSigner) withEnv(ctx context.Context) context.Context {
+	if len(s.Env) == 0 {
+		return ctx
+	}
+	return notationplugin.WithEnv(ctx, s.Env)
+}
+
 // PluginAnnotations returns signature manifest annotations returned from plugin
 func (s *PluginSigner) PluginAnnotations() map[string]string {
 	return s.manifestAnnotations
@@ -85,10 +113,10 @@ func (s *PluginSigner) PluginAnnotations() map[string]string {
 // Sign signs the artifact described by its descriptor and returns the
 // signature and SignerInfo.
 func (s *PluginSigner) Sign(ctx context.Context, desc ocispec.Descriptor, opts notation.SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
+	ctx = s.withEnv(ctx)
 	logger := log.GetLogger(ctx)
 	mergedConfig := s.mergeConfig(opts.PluginConfig)
-	logger.Debug("Invoking plugin's get-plugin-metadata command")
-	metadata, err := s.plugin.GetMetadata(ctx, &plugin.GetMetadataRequest{PluginConfig: mergedConfig})
+	metadata, err := s.getMetadata(ctx, mergedConfig)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -102,24 +130,39 @@ func (s *PluginSigner) Sign(ctx context.Context, desc ocispec.Descriptor, opts n
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to sign with the plugin %s: %w", metadata.Name, err)
 		}
+		s.manifestAnnotations = withPluginMetadata(nil, metadata)
 		return sig, signerInfo, nil
 	} else if metadata.HasCapability(plugin.CapabilityEnvelopeGenerator) {
 		sig, signerInfo, err := s.generateSignatureEnvelope(ctx, desc, opts)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to sign with the plugin %s: %w", metadata.Name, err)
 		}
+		s.manifestAnnotations = withPluginMetadata(s.manifestAnnotations, metadata)
 		return sig, signerInfo, nil
 	}
 	return nil, nil, fmt.Errorf("plugin does not have signing capabilities")
 }
 
+// withPluginMetadata returns a copy of annotations with the name and the
+// version of the plugin described by metadata recorded. They take precedence
+// over the annotations of the same keys returned by the plugin.
+func withPluginMetadata(annotations map[string]string, metadata *plugin.GetMetadataResponse) map[string]string {
+	result := make(map[string]string, len(annotations)+2)
+	for k, v := range annotations {
+		result[k] = v
+	}
+	result[AnnotationPluginName] = metadata.Name
+	result[AnnotationPluginVersion] = metadata.Version
+	return result
+}
+
 // SignBlob signs the descriptor returned by genDesc, and returns the
 // signature and SignerInfo.
 func (s *PluginSigner) SignBlob(ctx context.Context, descGenFunc notation.BlobDescriptorGenerator, opts notation.SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
+	ctx = s.withEnv(ctx)
 	logger := log.GetLogger(ctx)
 	mergedConfig := s.mergeConfig(opts.PluginConfig)
-	logger.Debug("Invoking plugin's get-plugin-metadata command")
-	metadata, err := s.plugin.GetMetadata(ctx, &plugin.GetMetadataRequest{PluginConfig: mergedConfig})
+	metadata, err := s.getMetadata(ctx, mergedConfig)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -143,6 +186,35 @@ func (s *PluginSigner) SignBlob(ctx context.Context, descGenFunc notation.BlobDe
 	return nil, nil, fmt.Errorf("plugin does not have signing capabilities")
 }
 
+// configSchemaGetter is implemented by plugins declaring the plugin config
+// keys they accept, such as [notationplugin.CLIPlugin].
+type configSchemaGetter interface {
+	GetMetadataWithConfigSchema(ctx context.Context, req *plugin.GetMetadataRequest) (*plugin.GetMetadataResponse, *notationplugin.ConfigSchema, error)
+}
+
+// getMetadata returns the plugin metadata. If the plugin declares the config
+// keys it accepts, config is validated against them before any signing
+// command is invoked.
+func (s *PluginSigner) getMetadata(ctx context.Context, config map[string]string) (*plugin.GetMetadataResponse, error) {
+	logger := log.GetLogger(ctx)
+	logger.Debug("Invoking plugin's get-plugin-metadata command")
+	req := &plugin.GetMetadataRequest{PluginConfig: config}
+	getter, ok := s.plugin.(configSchemaGetter)
+	if !ok {
+		return s.plugin.GetMetadata(ctx, req)
+	}
+	metadata, schema, err := getter.GetMetadataWithConfigSchema(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if schema != nil {
+		if err := schema.Validate(config); err != nil {
+			return nil, fmt.Errorf("invalid plugin config for plugin %s: %w", metadata.Name, err)
+		}
+	}
+	return metadata, nil
+}
+
 func (s *PluginSigner) getKeySpec(ctx context.Context, config map[string]string) (signature.KeySpec, error) {
 	logger := log.GetLogger(ctx)
 	logger.Debug("Invoking plugin's describe-key command")
@@ -175,6 +247,12 @@ func (s *PluginSigner) generateSignature(ctx context.Context, desc ocispec.Descr
 func (s *PluginSigner) generateSignatureEnvelope(ctx context.Context, desc ocispec.Descriptor, opts notation.SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
 	logger := log.GetLogger(ctx)
 	logger.Debug("Generating signature envelope by plugin")
+	if opts.PayloadPacker != nil {
+		return nil, nil, errors.New("payload packer is not supported by plugins generating signature envelopes")
+	}
+	if opts.SigningTime != nil {
+		return nil, nil, errors.New("signing time is not supported by plugins generating signature envelopes")
+	}
 	payload := envelope.Payload{TargetArtifact: envelope.SanitizeTargetArtifact(desc)}
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
@@ -365,6 +443,9 @@ func (s *pluginPrimitiveSigner) Sign(payload []byte) ([]byte, []*x509.Certificat
 	if certs, err = parseCertChain(resp.CertificateChain); err != nil {
 		return nil, nil, err
 	}
+	if err := ValidateCertChainOrder(certs); err != nil {
+		return nil, nil, fmt.Errorf("invalid certificate chain returned by the plugin: %w", err)
+	}
 	return resp.Signature, certs, nil
 }
 
@@ -69,6 +69,7 @@ type mockPlugin struct {
 	key               crypto.PrivateKey
 	certs             []*x509.Certificate
 	keySpec           signature.KeySpec
+	env               map[string]string
 }
 
 func getDescriptorFunc(throwError bool) func(hashAlgo digest.Algorithm) (ocispec.Descriptor, error) {
@@ -90,6 +91,7 @@ func newMockPlugin(key crypto.PrivateKey, certs []*x509.Certificate, keySpec sig
 }
 
 func (p *mockPlugin) GetMetadata(ctx context.Context, req *proto.GetMetadataRequest) (*proto.GetMetadataResponse, error) {
+	p.env = plugin.GetEnv(ctx)
 	if p.wantEnvelope {
 		return &proto.GetMetadataResponse{
 			Name:                      "testPlugin",
@@ -304,6 +306,15 @@ func TestSigner_Sign_InvalidCertChain(t *testing.T) {
 	}
 }
 
+func TestSigner_Sign_MisorderedCertChain(t *testing.T) {
+	certs := []*x509.Certificate{defaultKeyCert.certs[1], defaultKeyCert.certs[0]}
+	mockPlugin := newMockPlugin(defaultKeyCert.key, certs, defaultKeySpec)
+	signer := PluginSigner{
+		plugin: mockPlugin,
+	}
+	testSignerError(t, signer, "invalid certificate chain returned by the plugin: certificate chain is broken at index 0", notation.SignerSignOptions{SignatureMediaType: "application/jose+json"})
+}
+
 func TestSigner_Sign_InvalidDescriptor(t *testing.T) {
 	for _, envelopeType := range signature.RegisteredEnvelopeTypes() {
 		t.Run(fmt.Sprintf("envelopeType=%v", envelopeType), func(t *testing.T) {
@@ -347,6 +358,72 @@ func TestPluginSigner_Sign_Valid(t *testing.T) {
 	}
 }
 
+func TestPluginSigner_Sign_Env(t *testing.T) {
+	env := map[string]string{"AWS_PROFILE": "test"}
+	for _, wantEnvelope := range []bool{false, true} {
+		mockPlugin := newMockPlugin(defaultKeyCert.key, defaultKeyCert.certs, defaultKeySpec)
+		mockPlugin.wantEnvelope = wantEnvelope
+		pluginSigner := PluginSigner{
+			plugin: mockPlugin,
+			Env:    env,
+		}
+		pluginSigner.Sign(context.Background(), validSignDescriptor, validSignOpts)
+		if !reflect.DeepEqual(mockPlugin.env, env) {
+			t.Fatalf("plugin env = %v, want %v", mockPlugin.env, env)
+		}
+	}
+}
+
+// schemaMockPlugin declares the plugin config keys it accepts
+type schemaMockPlugin struct {
+	*mockPlugin
+	schema *plugin.ConfigSchema
+}
+
+func (p *schemaMockPlugin) GetMetadataWithConfigSchema(ctx context.Context, req *proto.GetMetadataRequest) (*proto.GetMetadataResponse, *plugin.ConfigSchema, error) {
+	metadata, err := p.GetMetadata(ctx, req)
+	return metadata, p.schema, err
+}
+
+func TestPluginSigner_Sign_ConfigSchema(t *testing.T) {
+	schema := &plugin.ConfigSchema{RequiredConfigKeys: []string{"region"}}
+	opts := validSignOpts
+	opts.SignatureMediaType = "application/jose+json"
+
+	t.Run("missing required config key", func(t *testing.T) {
+		pluginSigner := PluginSigner{
+			plugin: &schemaMockPlugin{newMockPlugin(defaultKeyCert.key, defaultKeyCert.certs, defaultKeySpec), schema},
+		}
+		wantErr := `invalid plugin config for plugin testPlugin: missing required plugin config key "region"`
+		if _, _, err := pluginSigner.Sign(context.Background(), validSignDescriptor, opts); err == nil || err.Error() != wantErr {
+			t.Fatalf("Sign() expected error %q, got %v", wantErr, err)
+		}
+		if _, _, err := pluginSigner.SignBlob(context.Background(), getDescriptorFunc(false), opts); err == nil || err.Error() != wantErr {
+			t.Fatalf("SignBlob() expected error %q, got %v", wantErr, err)
+		}
+	})
+
+	t.Run("required config key present", func(t *testing.T) {
+		pluginSigner := PluginSigner{
+			plugin:       &schemaMockPlugin{newMockPlugin(defaultKeyCert.key, defaultKeyCert.certs, defaultKeySpec), schema},
+			pluginConfig: map[string]string{"region": "us"},
+		}
+		if _, _, err := pluginSigner.Sign(context.Background(), validSignDescriptor, opts); err != nil {
+			t.Fatalf("Sign() expected nil error, got %v", err)
+		}
+	})
+	t.Run("unknown config key", func(t *testing.T) {
+		pluginSigner := PluginSigner{
+			plugin:       &schemaMockPlugin{newMockPlugin(defaultKeyCert.key, defaultKeyCert.certs, defaultKeySpec), schema},
+			pluginConfig: map[string]string{"region": "us", "regoin": "eu"},
+		}
+		wantErr := `invalid plugin config for plugin testPlugin: unknown plugin config keys ["regoin"]`
+		if _, _, err := pluginSigner.Sign(context.Background(), validSignDescriptor, opts); err == nil || err.Error() != wantErr {
+			t.Fatalf("Sign() expected error %q, got %v", wantErr, err)
+		}
+	})
+}
+
 func TestPluginSigner_SignBlob_Valid(t *testing.T) {
 	for _, envelopeType := range signature.RegisteredEnvelopeTypes() {
 		for _, keyCert := range keyCertPairCollections {
@@ -378,6 +455,29 @@ func TestPluginSigner_SignEnvelope_RunFailed(t *testing.T) {
 	}
 }
 
+func TestPluginSigner_SignEnvelope_PayloadPacker(t *testing.T) {
+	p := &mockPlugin{
+		wantEnvelope: true,
+	}
+	signer := PluginSigner{
+		plugin: p,
+	}
+	opts := notation.SignerSignOptions{SignatureMediaType: "application/jose+json", PayloadPacker: &testPayloadPacker{}}
+	testSignerError(t, signer, "payload packer is not supported by plugins generating signature envelopes", opts)
+}
+
+func TestPluginSigner_SignEnvelope_SigningTime(t *testing.T) {
+	p := &mockPlugin{
+		wantEnvelope: true,
+	}
+	signer := PluginSigner{
+		plugin: p,
+	}
+	signingTime := time.Now()
+	opts := notation.SignerSignOptions{SignatureMediaType: "application/jose+json", SigningTime: &signingTime}
+	testSignerError(t, signer, "signing time is not supported by plugins generating signature envelopes", opts)
+}
+
 func TestPluginSigner_SignEnvelope_Valid(t *testing.T) {
 	for _, envelopeType := range signature.RegisteredEnvelopeTypes() {
 		for _, keyCert := range keyCertPairCollections {
@@ -401,7 +501,11 @@ func TestPluginSigner_SignWithAnnotations_Valid(t *testing.T) {
 		for _, keyCert := range keyCertPairCollections {
 			t.Run(fmt.Sprintf("external plugin,envelopeType=%v_keySpec=%v", envelopeType, keyCert.keySpecName), func(t *testing.T) {
 				keySpec, _ := proto.DecodeKeySpec(proto.KeySpec(keyCert.keySpecName))
-				annts := map[string]string{"key": "value"}
+				annts := map[string]string{
+					"key":                   "value",
+					AnnotationPluginName:    "testPlugin",
+					AnnotationPluginVersion: "1.0",
+				}
 				pluginSigner := PluginSigner{
 					plugin: &mockPlugin{
 						key:          keyCert.key,
@@ -423,6 +527,25 @@ func TestPluginSigner_SignWithAnnotations_Valid(t *testing.T) {
 	}
 }
 
+func TestPluginSigner_SignRecordsPluginMetadata(t *testing.T) {
+	keyCert := keyCertPairCollections[0]
+	keySpec, _ := proto.DecodeKeySpec(proto.KeySpec(keyCert.keySpecName))
+	pluginSigner := PluginSigner{
+		plugin: newMockPlugin(keyCert.key, keyCert.certs, keySpec),
+	}
+	validSignOpts.SignatureMediaType = signature.RegisteredEnvelopeTypes()[0]
+	if _, _, err := pluginSigner.Sign(context.Background(), validSignDescriptor, validSignOpts); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	want := map[string]string{
+		AnnotationPluginName:    "testPlugin",
+		AnnotationPluginVersion: "1.0",
+	}
+	if got := pluginSigner.PluginAnnotations(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("PluginAnnotations() = %v, want %v", got, want)
+	}
+}
+
 func testSignerError(t *testing.T, signer PluginSigner, wantEr string, opts notation.SignerSignOptions) {
 	t.Helper()
 	_, _, err := signer.Sign(context.Background(), ocispec.Descriptor{}, opts)
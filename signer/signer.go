@@ -17,18 +17,28 @@
 package signer
 
 import (
+	"bytes"
 	"context"
 	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/asn1"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
+	"os"
 	"time"
 
 	"github.com/notaryproject/notation-core-go/signature"
+	nx509 "github.com/notaryproject/notation-core-go/x509"
 	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/internal/clock"
 	"github.com/notaryproject/notation-go/internal/envelope"
+	"github.com/notaryproject/notation-go/internal/pkcs8"
 	"github.com/notaryproject/notation-go/log"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
@@ -36,6 +46,10 @@ import (
 // signingAgent is the unprotected header field used by signature.
 const signingAgent = "notation-go/1.3.0+unreleased"
 
+// maxSigningTimeSkew is the maximum clock skew tolerated for a signing time
+// set by the caller to be in the future.
+const maxSigningTimeSkew = 5 * time.Minute
+
 // GenericSigner implements [notation.Signer] and [notation.BlobSigner].
 // It embeds signature.Signer.
 type GenericSigner struct {
@@ -99,16 +113,200 @@ func NewGenericSignerFromFiles(keyPath, certChainPath string) (*GenericSigner, e
 	return NewGenericSigner(cert.PrivateKey, certs)
 }
 
+// NewGenericSignerFromFilesWithPassphrase returns a builtinSigner given key
+// and certChain paths, where the private key may be encrypted with
+// passphrase.
+//
+// The private key file is PEM encoded in the PKCS #8, PKCS #1 or SEC 1
+// format. A PKCS #8 encrypted private key must use the PBES2 scheme with
+// PBKDF2 and AES-CBC, which is the default of OpenSSL. Legacy PEM encryption,
+// i.e. with a DEK-Info header, is also supported. passphrase is not used if
+// the private key is not encrypted. The PBKDF2 iteration count of an
+// encrypted private key is capped to bound the key derivation time.
+//
+// It is the passphrase aware counterpart of [NewGenericSignerFromFiles], and
+// returns a *GenericSigner rather than a bare Signer so that both file based
+// constructors are interchangeable.
+func NewGenericSignerFromFilesWithPassphrase(keyPath, certChainPath string, passphrase []byte) (*GenericSigner, error) {
+	if keyPath == "" {
+		return nil, errors.New("key path not specified")
+	}
+	if certChainPath == "" {
+		return nil, errors.New("certificate path not specified")
+	}
+
+	// read key
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := parsePrivateKeyPEM(keyPEM, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load private key %q: %w", keyPath, err)
+	}
+
+	// read cert chain
+	certs, err := nx509.ReadCertificateFile(certChainPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("%q does not contain certificate", certChainPath)
+	}
+
+	// create signer
+	return NewGenericSigner(key, certs)
+}
+
+// parsePrivateKeyPEM parses the first PEM block of data as a private key,
+// decrypting it with passphrase if it is encrypted.
+func parsePrivateKeyPEM(data, passphrase []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+	der := block.Bytes
+	var encrypted bool
+	var err error
+	switch {
+	case block.Type == "ENCRYPTED PRIVATE KEY":
+		if len(passphrase) == 0 {
+			return nil, errors.New("private key is encrypted, but no passphrase is provided")
+		}
+		encrypted = true
+		if der, err = pkcs8.Decrypt(der, passphrase); err != nil {
+			return nil, err
+		}
+	case x509.IsEncryptedPEMBlock(block):
+		// legacy PEM encryption is insecure by design, but still produced by
+		// older tools
+		if len(passphrase) == 0 {
+			return nil, errors.New("private key is encrypted, but no passphrase is provided")
+		}
+		encrypted = true
+		if der, err = x509.DecryptPEMBlock(block, passphrase); err != nil {
+			if errors.Is(err, x509.IncorrectPasswordError) {
+				return nil, pkcs8.ErrIncorrectPassphrase
+			}
+			return nil, err
+		}
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	if encrypted {
+		// the padding check of the decryption does not detect all the
+		// incorrect passphrases
+		return nil, pkcs8.ErrIncorrectPassphrase
+	}
+	return nil, fmt.Errorf("unsupported private key format %q", block.Type)
+}
+
+// NewGenericSignerFromCryptoSigner returns a builtinSigner given a
+// [crypto.Signer] and cert chain.
+//
+// It is meant for keys whose private key material cannot be loaded into the
+// process, such as keys held by a hardware security module or a token. Those
+// are typically exposed as a [crypto.Signer] by a PKCS#11 library, so that
+// they can be used for signing without writing a plugin. This package does
+// not load PKCS#11 modules nor manage their sessions: callers bring a
+// PKCS#11-backed [crypto.Signer].
+func NewGenericSignerFromCryptoSigner(key crypto.Signer, certChain []*x509.Certificate) (*GenericSigner, error) {
+	if key == nil {
+		return nil, errors.New("key cannot be nil")
+	}
+	if len(certChain) == 0 {
+		return nil, errors.New("certificate chain cannot be empty")
+	}
+	keySpec, err := signature.ExtractKeySpec(certChain[0])
+	if err != nil {
+		return nil, err
+	}
+	if pub, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !pub.Equal(certChain[0].PublicKey) {
+		return nil, errors.New("key does not match the public key of the signing certificate")
+	}
+	return &GenericSigner{
+		signer: &cryptoSigner{
+			key:       key,
+			keySpec:   keySpec,
+			certChain: certChain,
+		},
+	}, nil
+}
+
+// cryptoSigner implements signature.Signer with a crypto.Signer.
+type cryptoSigner struct {
+	key       crypto.Signer
+	keySpec   signature.KeySpec
+	certChain []*x509.Certificate
+}
+
+// CertificateChain returns the certificate chain of the signing key.
+func (s *cryptoSigner) CertificateChain() ([]*x509.Certificate, error) {
+	return s.certChain, nil
+}
+
+// Sign signs the payload with the underlying crypto.Signer, and returns the
+// raw signature and the certificate chain.
+func (s *cryptoSigner) Sign(payload []byte) ([]byte, []*x509.Certificate, error) {
+	hash := s.keySpec.SignatureAlgorithm().Hash()
+	h := hash.New()
+	h.Write(payload)
+	digest := h.Sum(nil)
+
+	switch s.keySpec.Type {
+	case signature.KeyTypeRSA:
+		sig, err := s.key.Sign(rand.Reader, digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: hash})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to sign with the key: %w", err)
+		}
+		return sig, s.certChain, nil
+	case signature.KeyTypeEC:
+		der, err := s.key.Sign(rand.Reader, digest, hash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to sign with the key: %w", err)
+		}
+		// crypto.Signer returns ASN.1 DER encoded ECDSA signatures, while
+		// the signature envelopes expect the concatenation of r and s.
+		var ecdsaSig struct {
+			R, S *big.Int
+		}
+		n := (s.keySpec.Size + 7) / 8
+		if rest, err := asn1.Unmarshal(der, &ecdsaSig); err != nil || len(rest) != 0 ||
+			ecdsaSig.R.Sign() <= 0 || ecdsaSig.S.Sign() <= 0 ||
+			ecdsaSig.R.BitLen() > 8*n || ecdsaSig.S.BitLen() > 8*n {
+			return nil, nil, errors.New("failed to sign with the key: malformed ECDSA signature")
+		}
+		sig := make([]byte, 2*n)
+		ecdsaSig.R.FillBytes(sig[:n])
+		ecdsaSig.S.FillBytes(sig[n:])
+		return sig, s.certChain, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported key type %v", s.keySpec.Type)
+	}
+}
+
+// KeySpec returns the key specification of the signing certificate.
+func (s *cryptoSigner) KeySpec() (signature.KeySpec, error) {
+	return s.keySpec, nil
+}
+
 // Sign signs the artifact described by its descriptor and returns the
 // signature and SignerInfo.
 func (s *GenericSigner) Sign(ctx context.Context, desc ocispec.Descriptor, opts notation.SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
 	logger := log.GetLogger(ctx)
 	logger.Debugf("Generic signing for %v in signature media type %v", desc.Digest, opts.SignatureMediaType)
 	// Generate payload to be signed.
-	payload := envelope.Payload{TargetArtifact: envelope.SanitizeTargetArtifact(desc)}
-	payloadBytes, err := json.Marshal(payload)
+	payload, err := packPayload(ctx, desc, opts.PayloadPacker)
 	if err != nil {
-		return nil, nil, fmt.Errorf("envelope payload can't be marshalled: %w", err)
+		return nil, nil, err
 	}
 	var signingAgentId string
 	if opts.SigningAgent != "" {
@@ -122,13 +320,14 @@ func (s *GenericSigner) Sign(ctx context.Context, desc ocispec.Descriptor, opts
 	if opts.TSARootCAs != nil && opts.Timestamper == nil {
 		return nil, nil, errors.New("timestamping: got TSARootCAs but nil Timestamper")
 	}
+	signingTime, err := s.signingTime(opts.SigningTime, opts.Clock)
+	if err != nil {
+		return nil, nil, err
+	}
 	signReq := &signature.SignRequest{
-		Payload: signature.Payload{
-			ContentType: envelope.MediaTypePayloadV1,
-			Content:     payloadBytes,
-		},
+		Payload:                payload,
 		Signer:                 s.signer,
-		SigningTime:            time.Now(),
+		SigningTime:            signingTime,
 		SigningScheme:          signature.SigningSchemeX509,
 		SigningAgent:           signingAgentId,
 		Timestamper:            opts.Timestamper,
@@ -170,12 +369,82 @@ func (s *GenericSigner) Sign(ctx context.Context, desc ocispec.Descriptor, opts
 	if err != nil {
 		return nil, nil, fmt.Errorf("generated signature failed verification: %v", err)
 	}
-	if err := envelope.ValidatePayloadContentType(&envContent.Payload); err != nil {
-		return nil, nil, err
+	if opts.PayloadPacker == nil {
+		if err := envelope.ValidatePayloadContentType(&envContent.Payload); err != nil {
+			return nil, nil, err
+		}
+	} else if envContent.Payload.ContentType != payload.ContentType {
+		return nil, nil, fmt.Errorf("payload content type %q of the generated signature does not match %q", envContent.Payload.ContentType, payload.ContentType)
 	}
 	return sig, &envContent.SignerInfo, nil
 }
 
+// signingTime returns the signing time to be used for the signature. The
+// current time of clk is returned if requested is nil.
+func (s *GenericSigner) signingTime(requested *time.Time, clk notation.Clock) (time.Time, error) {
+	now := clock.Now(clk)
+	if requested == nil {
+		return now, nil
+	}
+	signingTime := *requested
+	if signingTime.IsZero() {
+		return time.Time{}, errors.New("signing time cannot be zero")
+	}
+	if signingTime.After(now.Add(maxSigningTimeSkew)) {
+		return time.Time{}, fmt.Errorf("signing time %q is in the future", signingTime.Format(time.RFC3339))
+	}
+	// the certificate chain of signers backed by a plugin is only known
+	// after signing, where it is validated against the signing time by the
+	// signature envelope.
+	if chainGetter, ok := s.signer.(certificateChainGetter); ok {
+		certs, err := chainGetter.CertificateChain()
+		if err != nil {
+			return time.Time{}, err
+		}
+		if len(certs) > 0 {
+			if signingTime.Before(certs[0].NotBefore) {
+				return time.Time{}, fmt.Errorf("signing time %q is before the validity start time %q of the signing certificate", signingTime.Format(time.RFC3339), certs[0].NotBefore.Format(time.RFC3339))
+			}
+			if signingTime.After(certs[0].NotAfter) {
+				return time.Time{}, fmt.Errorf("signing time %q is after the expiry time %q of the signing certificate", signingTime.Format(time.RFC3339), certs[0].NotAfter.Format(time.RFC3339))
+			}
+		}
+	}
+	return signingTime, nil
+}
+
+// certificateChainGetter is implemented by signers whose certificate chain is
+// known before signing, such as [signature.LocalSigner].
+type certificateChainGetter interface {
+	CertificateChain() ([]*x509.Certificate, error)
+}
+
+// packPayload returns the payload to be signed for the artifact described by
+// desc. The notary payload is returned if packer is nil.
+func packPayload(ctx context.Context, desc ocispec.Descriptor, packer notation.PayloadPacker) (signature.Payload, error) {
+	if packer == nil {
+		payloadBytes, err := json.Marshal(envelope.Payload{TargetArtifact: envelope.SanitizeTargetArtifact(desc)})
+		if err != nil {
+			return signature.Payload{}, fmt.Errorf("envelope payload can't be marshalled: %w", err)
+		}
+		return signature.Payload{
+			ContentType: envelope.MediaTypePayloadV1,
+			Content:     payloadBytes,
+		}, nil
+	}
+	payload, err := packer.PackPayload(ctx, desc)
+	if err != nil {
+		return signature.Payload{}, fmt.Errorf("failed to pack payload: %w", err)
+	}
+	if payload.ContentType == "" {
+		return signature.Payload{}, errors.New("failed to pack payload: payload content type cannot be empty")
+	}
+	if len(payload.Content) == 0 {
+		return signature.Payload{}, errors.New("failed to pack payload: payload content cannot be empty")
+	}
+	return payload, nil
+}
+
 // SignBlob signs the descriptor returned by genDesc, and returns the
 // signature and SignerInfo.
 func (s *GenericSigner) SignBlob(ctx context.Context, genDesc notation.BlobDescriptorGenerator, opts notation.SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
@@ -199,3 +468,24 @@ func getDescriptor(ks signature.KeySpec, genDesc notation.BlobDescriptorGenerato
 	}
 	return genDesc(digestAlg)
 }
+
+// ValidateCertChainOrder validates that certs is a complete certificate chain
+// ordered from the signing certificate to the root certificate, i.e. each
+// certificate is issued by the next one and the last one is self-signed.
+// The returned error tells which link of the chain is broken.
+// The validity period and the key usages of the certificates are not checked.
+func ValidateCertChainOrder(certs []*x509.Certificate) error {
+	if len(certs) == 0 {
+		return errors.New("certificate chain cannot be empty")
+	}
+	for i := 0; i < len(certs)-1; i++ {
+		if err := certs[i].CheckSignatureFrom(certs[i+1]); err != nil {
+			return fmt.Errorf("certificate chain is broken at index %d: certificate with subject %q is not issued by the next certificate with subject %q: %w", i, certs[i].Subject, certs[i+1].Subject, err)
+		}
+	}
+	root := certs[len(certs)-1]
+	if !bytes.Equal(root.RawSubject, root.RawIssuer) || root.CheckSignature(root.SignatureAlgorithm, root.RawTBSCertificate, root.Signature) != nil {
+		return fmt.Errorf("certificate chain is incomplete: the last certificate with subject %q is not self-signed, the certificate of its issuer %q is missing", root.Subject, root.Issuer)
+	}
+	return nil
+}
@@ -14,6 +14,7 @@
 package signer
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/ecdsa"
@@ -27,6 +28,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -301,7 +303,7 @@ func TestSignBlobWithCertChain(t *testing.T) {
 			t.Run(fmt.Sprintf("envelopeType=%v_keySpec=%v", envelopeType, keyCert.keySpecName), func(t *testing.T) {
 				s, err := NewGenericSigner(keyCert.key, keyCert.certs)
 				if err != nil {
-					t.Fatalf("NewSigner() error = %v", err)
+					t.Fatalf("NewGenericSigner() error = %v", err)
 				}
 
 				sOpts := notation.SignerSignOptions{
@@ -345,6 +347,273 @@ func TestSignWithoutExpiry(t *testing.T) {
 	}
 }
 
+// testPayloadPacker packs a minimal in-toto statement for the artifact.
+type testPayloadPacker struct {
+	payload *signature.Payload
+	err     error
+}
+
+func (p *testPayloadPacker) PackPayload(_ context.Context, desc ocispec.Descriptor) (signature.Payload, error) {
+	if p.err != nil {
+		return signature.Payload{}, p.err
+	}
+	if p.payload != nil {
+		return *p.payload, nil
+	}
+	return signature.Payload{
+		ContentType: "application/vnd.in-toto+json",
+		Content:     []byte(fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v1","subject":[{"digest":{%q:%q}}]}`, desc.Digest.Algorithm(), desc.Digest.Encoded())),
+	}, nil
+}
+
+func TestSignWithPayloadPacker(t *testing.T) {
+	keyCert := keyCertPairCollections[0]
+	s, err := New(keyCert.key, keyCert.certs)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	for _, envelopeType := range signature.RegisteredEnvelopeTypes() {
+		t.Run(fmt.Sprintf("envelopeType=%v", envelopeType), func(t *testing.T) {
+			packer := &testPayloadPacker{}
+			desc, sOpts := generateSigningContent()
+			sOpts.SignatureMediaType = envelopeType
+			sOpts.PayloadPacker = packer
+			sig, _, err := s.Sign(context.Background(), desc, sOpts)
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+
+			env, err := signature.ParseEnvelope(envelopeType, sig)
+			if err != nil {
+				t.Fatalf("ParseEnvelope() error = %v", err)
+			}
+			envContent, err := env.Verify()
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			want, _ := packer.PackPayload(context.Background(), desc)
+			if envContent.Payload.ContentType != want.ContentType || !bytes.Equal(envContent.Payload.Content, want.Content) {
+				t.Fatalf("expected payload %+v, got %+v", want, envContent.Payload)
+			}
+		})
+	}
+}
+
+func TestSignWithPayloadPackerError(t *testing.T) {
+	keyCert := keyCertPairCollections[0]
+	s, err := New(keyCert.key, keyCert.certs)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		packer  *testPayloadPacker
+		wantErr string
+	}{
+		{
+			name:    "packer error",
+			packer:  &testPayloadPacker{err: errors.New("packer error")},
+			wantErr: "failed to pack payload: packer error",
+		},
+		{
+			name:    "empty content type",
+			packer:  &testPayloadPacker{payload: &signature.Payload{Content: []byte("{}")}},
+			wantErr: "failed to pack payload: payload content type cannot be empty",
+		},
+		{
+			name:    "empty content",
+			packer:  &testPayloadPacker{payload: &signature.Payload{ContentType: "application/vnd.in-toto+json"}},
+			wantErr: "failed to pack payload: payload content cannot be empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc, sOpts := generateSigningContent()
+			sOpts.PayloadPacker = tt.packer
+			_, _, err := s.Sign(context.Background(), desc, sOpts)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSignWithSigningTime(t *testing.T) {
+	keyCert := keyCertPairCollections[0]
+	s, err := New(keyCert.key, keyCert.certs)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	signingTime := keyCert.certs[0].NotBefore.Add(500 * time.Millisecond)
+	for _, envelopeType := range signature.RegisteredEnvelopeTypes() {
+		t.Run(fmt.Sprintf("envelopeType=%v", envelopeType), func(t *testing.T) {
+			desc, sOpts := generateSigningContent()
+			sOpts.SignatureMediaType = envelopeType
+			sOpts.SigningTime = &signingTime
+			_, signerInfo, err := s.Sign(context.Background(), desc, sOpts)
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			want := signingTime.Truncate(time.Second)
+			if got := signerInfo.SignedAttributes.SigningTime; !got.Equal(want) {
+				t.Fatalf("expected signing time %v, got %v", want, got)
+			}
+			if got := signerInfo.SignedAttributes.Expiry; !got.Equal(want.Add(sOpts.ExpiryDuration)) {
+				t.Fatalf("expected expiry %v, got %v", want.Add(sOpts.ExpiryDuration), got)
+			}
+		})
+	}
+}
+
+func TestSignWithClock(t *testing.T) {
+	keyCert := keyCertPairCollections[0]
+	s, err := New(keyCert.key, keyCert.certs)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	now := keyCert.certs[0].NotBefore.Add(time.Hour)
+	desc, sOpts := generateSigningContent()
+	sOpts.SignatureMediaType = signature.RegisteredEnvelopeTypes()[0]
+	sOpts.Clock = fixedClock(now)
+	_, signerInfo, err := s.Sign(context.Background(), desc, sOpts)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	want := now.Truncate(time.Second)
+	if got := signerInfo.SignedAttributes.SigningTime; !got.Equal(want) {
+		t.Fatalf("expected signing time %v, got %v", want, got)
+	}
+
+	// a signing time after the time of the clock is in the future
+	signingTime := now.Add(time.Hour)
+	sOpts.SigningTime = &signingTime
+	wantErr := fmt.Sprintf("signing time %q is in the future", signingTime.Format(time.RFC3339))
+	if _, _, err := s.Sign(context.Background(), desc, sOpts); err == nil || err.Error() != wantErr {
+		t.Fatalf("expected error %q, got %v", wantErr, err)
+	}
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestSignWithSigningTimeError(t *testing.T) {
+	keyCert := keyCertPairCollections[0]
+	s, err := NewGenericSigner(keyCert.key, keyCert.certs)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+	// a copy of the signing certificate expired an hour ago
+	expiredCert := *keyCert.certs[0]
+	expiredCert.NotBefore = time.Now().Add(-24 * time.Hour)
+	expiredCert.NotAfter = time.Now().Add(-time.Hour)
+	expiredSigner := &GenericSigner{
+		signer: &cryptoSigner{
+			key:       keyCert.key.(crypto.Signer),
+			certChain: append([]*x509.Certificate{&expiredCert}, keyCert.certs[1:]...),
+		},
+	}
+	future := time.Now().Add(time.Hour)
+	afterExpiry := expiredCert.NotAfter.Add(time.Second)
+	beforeValidity := keyCert.certs[0].NotBefore.Add(-time.Second)
+	tests := []struct {
+		name        string
+		signer      *GenericSigner
+		signingTime time.Time
+		wantErr     string
+	}{
+		{
+			name:    "zero signing time",
+			signer:  s,
+			wantErr: "signing time cannot be zero",
+		},
+		{
+			name:        "future signing time",
+			signer:      s,
+			signingTime: future,
+			wantErr:     fmt.Sprintf("signing time %q is in the future", future.Format(time.RFC3339)),
+		},
+		{
+			name:        "signing time after certificate expiry",
+			signer:      expiredSigner,
+			signingTime: afterExpiry,
+			wantErr:     fmt.Sprintf("signing time %q is after the expiry time %q of the signing certificate", afterExpiry.Format(time.RFC3339), expiredCert.NotAfter.Format(time.RFC3339)),
+		},
+		{
+			name:        "signing time before certificate validity",
+			signer:      s,
+			signingTime: beforeValidity,
+			wantErr:     fmt.Sprintf("signing time %q is before the validity start time %q of the signing certificate", beforeValidity.Format(time.RFC3339), keyCert.certs[0].NotBefore.Format(time.RFC3339)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc, sOpts := generateSigningContent()
+			sOpts.SignatureMediaType = signature.RegisteredEnvelopeTypes()[0]
+			sOpts.SigningTime = &tt.signingTime
+			_, _, err := tt.signer.Sign(context.Background(), desc, sOpts)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateCertChainOrder(t *testing.T) {
+	rsaRoot := testhelper.GetRSARootCertificate().Cert
+	rsaLeaf := testhelper.GetRSALeafCertificate().Cert
+	ecRoot := testhelper.GetECRootCertificate().Cert
+	tests := []struct {
+		name    string
+		certs   []*x509.Certificate
+		wantErr string
+	}{
+		{
+			name:  "complete chain",
+			certs: []*x509.Certificate{rsaLeaf, rsaRoot},
+		},
+		{
+			name:  "self-signed certificate",
+			certs: []*x509.Certificate{rsaRoot},
+		},
+		{
+			name:    "empty chain",
+			wantErr: "certificate chain cannot be empty",
+		},
+		{
+			name:    "misordered chain",
+			certs:   []*x509.Certificate{rsaRoot, rsaLeaf},
+			wantErr: fmt.Sprintf("certificate chain is broken at index 0: certificate with subject %q is not issued by the next certificate with subject %q", rsaRoot.Subject, rsaLeaf.Subject),
+		},
+		{
+			name:    "wrong issuer",
+			certs:   []*x509.Certificate{rsaLeaf, ecRoot},
+			wantErr: fmt.Sprintf("certificate chain is broken at index 0: certificate with subject %q is not issued by the next certificate with subject %q", rsaLeaf.Subject, ecRoot.Subject),
+		},
+		{
+			name:    "incomplete chain",
+			certs:   []*x509.Certificate{rsaLeaf},
+			wantErr: fmt.Sprintf("certificate chain is incomplete: the last certificate with subject %q is not self-signed, the certificate of its issuer %q is missing", rsaLeaf.Subject, rsaLeaf.Issuer),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCertChainOrder(tt.certs)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateCertChainOrder() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
 func signRSA(digest []byte, hash crypto.Hash, pk *rsa.PrivateKey) ([]byte, error) {
 	return rsa.SignPSS(rand.Reader, pk, hash, digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
 }
@@ -464,3 +733,192 @@ func validateSignWithCerts(t *testing.T, envelopeType string, key crypto.Private
 	// basic verification
 	basicVerification(t, sig, envelopeType, certs[len(certs)-1], nil)
 }
+
+// opaqueSigner hides the private key type of the wrapped crypto.Signer, as
+// for keys held by a hardware security module.
+type opaqueSigner struct {
+	crypto.Signer
+}
+
+func TestNewGenericSignerFromCryptoSigner(t *testing.T) {
+	for _, envelopeType := range signature.RegisteredEnvelopeTypes() {
+		for _, keyCert := range keyCertPairCollections {
+			t.Run(fmt.Sprintf("envelopeType=%v_keySpec=%v", envelopeType, keyCert.keySpecName), func(t *testing.T) {
+				s, err := NewGenericSignerFromCryptoSigner(opaqueSigner{keyCert.key.(crypto.Signer)}, keyCert.certs)
+				if err != nil {
+					t.Fatalf("NewGenericSignerFromCryptoSigner() error = %v", err)
+				}
+				desc, sOpts := generateSigningContent()
+				sOpts.SignatureMediaType = envelopeType
+				sig, _, err := s.Sign(context.Background(), desc, sOpts)
+				if err != nil {
+					t.Fatalf("Sign() error = %v", err)
+				}
+				basicVerification(t, sig, envelopeType, keyCert.certs[len(keyCert.certs)-1], nil)
+			})
+		}
+	}
+}
+
+func TestNewGenericSignerFromCryptoSignerError(t *testing.T) {
+	keyCert := keyCertPairCollections[0]
+	otherKeyCert := keyCertPairCollections[len(keyCertPairCollections)-1]
+	tests := []struct {
+		name    string
+		key     crypto.Signer
+		certs   []*x509.Certificate
+		wantErr string
+	}{
+		{
+			name:    "nil key",
+			certs:   keyCert.certs,
+			wantErr: "key cannot be nil",
+		},
+		{
+			name:    "empty cert chain",
+			key:     opaqueSigner{keyCert.key.(crypto.Signer)},
+			wantErr: "certificate chain cannot be empty",
+		},
+		{
+			name:    "key mismatch",
+			key:     opaqueSigner{otherKeyCert.key.(crypto.Signer)},
+			certs:   keyCert.certs,
+			wantErr: "key does not match the public key of the signing certificate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGenericSignerFromCryptoSigner(tt.key, tt.certs)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("NewGenericSignerFromCryptoSigner() expects error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewGenericSignerFromFilesWithPassphrase(t *testing.T) {
+	passphrase := []byte("notation")
+	tests := []struct {
+		name       string
+		keyFile    string
+		certFile   string
+		passphrase []byte
+	}{
+		{
+			name:       "PKCS #8 encrypted RSA key",
+			keyFile:    "rsa_pkcs8_encrypted.key",
+			certFile:   "rsa.crt",
+			passphrase: passphrase,
+		},
+		{
+			name:       "legacy PEM encrypted RSA key",
+			keyFile:    "rsa_pkcs1_encrypted.key",
+			certFile:   "rsa.crt",
+			passphrase: passphrase,
+		},
+		{
+			name:     "unencrypted PKCS #1 RSA key",
+			keyFile:  "rsa_pkcs1.key",
+			certFile: "rsa.crt",
+		},
+		{
+			name:       "PKCS #8 encrypted EC key",
+			keyFile:    "ec_pkcs8_encrypted.key",
+			certFile:   "ec.crt",
+			passphrase: passphrase,
+		},
+		{
+			name:       "unencrypted PKCS #8 EC key with passphrase",
+			keyFile:    "ec.key",
+			certFile:   "ec.crt",
+			passphrase: passphrase,
+		},
+	}
+	for _, tt := range tests {
+		for _, envelopeType := range signature.RegisteredEnvelopeTypes() {
+			t.Run(fmt.Sprintf("%s_envelopeType=%v", tt.name, envelopeType), func(t *testing.T) {
+				certPath := filepath.Join("testdata", "encryptedkey", tt.certFile)
+				s, err := NewGenericSignerFromFilesWithPassphrase(filepath.Join("testdata", "encryptedkey", tt.keyFile), certPath, tt.passphrase)
+				if err != nil {
+					t.Fatalf("NewGenericSignerFromFilesWithPassphrase() error = %v", err)
+				}
+				desc, sOpts := generateSigningContent()
+				sOpts.SignatureMediaType = envelopeType
+				sig, _, err := s.Sign(context.Background(), desc, sOpts)
+				if err != nil {
+					t.Fatalf("Sign() error = %v", err)
+				}
+				certs, err := nx509.ReadCertificateFile(certPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				basicVerification(t, sig, envelopeType, certs[0], nil)
+			})
+		}
+	}
+}
+
+func TestNewGenericSignerFromFilesWithPassphraseError(t *testing.T) {
+	keyPath := filepath.Join("testdata", "encryptedkey", "rsa_pkcs8_encrypted.key")
+	legacyKeyPath := filepath.Join("testdata", "encryptedkey", "rsa_pkcs1_encrypted.key")
+	certPath := filepath.Join("testdata", "encryptedkey", "rsa.crt")
+	tests := []struct {
+		name       string
+		keyPath    string
+		certPath   string
+		passphrase []byte
+		wantErr    string
+	}{
+		{
+			name:     "empty key path",
+			certPath: certPath,
+			wantErr:  "key path not specified",
+		},
+		{
+			name:    "empty certificate path",
+			keyPath: keyPath,
+			wantErr: "certificate path not specified",
+		},
+		{
+			name:     "missing passphrase",
+			keyPath:  keyPath,
+			certPath: certPath,
+			wantErr:  fmt.Sprintf("failed to load private key %q: private key is encrypted, but no passphrase is provided", keyPath),
+		},
+		{
+			name:       "incorrect passphrase",
+			keyPath:    keyPath,
+			certPath:   certPath,
+			passphrase: []byte("wrong passphrase"),
+			wantErr:    fmt.Sprintf("failed to load private key %q: incorrect passphrase", keyPath),
+		},
+		{
+			name:       "incorrect passphrase for legacy PEM encryption",
+			keyPath:    legacyKeyPath,
+			certPath:   certPath,
+			passphrase: []byte("wrong passphrase"),
+			wantErr:    fmt.Sprintf("failed to load private key %q: incorrect passphrase", legacyKeyPath),
+		},
+		{
+			name:     "not a private key",
+			keyPath:  certPath,
+			certPath: certPath,
+			wantErr:  fmt.Sprintf("failed to load private key %q: unsupported private key format \"CERTIFICATE\"", certPath),
+		},
+		{
+			name:       "key and certificate mismatch",
+			keyPath:    keyPath,
+			certPath:   filepath.Join("testdata", "encryptedkey", "ec.crt"),
+			passphrase: []byte("notation"),
+			wantErr:    "\"key and certs\" param is invalid. Error: key not matches certificate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGenericSignerFromFilesWithPassphrase(tt.keyPath, tt.certPath, tt.passphrase)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("NewGenericSignerFromFilesWithPassphrase() expects error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// sizeLimitedSigner implements [notation.Signer] by rejecting the signature
+// envelopes of its inner signer larger than maxBytes.
+type sizeLimitedSigner struct {
+	inner    notation.Signer
+	maxBytes int
+}
+
+// NewSizeLimitedSigner returns a [notation.Signer] signing with inner, which
+// fails with a [notation.ErrorSignatureTooLarge] if the signature envelope
+// produced by inner is larger than maxBytes, e.g. to guard against plugins
+// embedding huge certificate chains in signatures that registries would
+// reject. maxBytes must be positive.
+//
+// If inner is a [notation.BlobSigner], the returned signer is a
+// [notation.BlobSigner] as well, limiting the size of blob signatures.
+// The signature manifest annotations of a [PluginSigner] are preserved.
+func NewSizeLimitedSigner(inner notation.Signer, maxBytes int) (notation.Signer, error) {
+	if inner == nil {
+		return nil, errors.New("inner signer cannot be nil")
+	}
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("maxBytes expects a positive number, got %d", maxBytes)
+	}
+	s := &sizeLimitedSigner{
+		inner:    inner,
+		maxBytes: maxBytes,
+	}
+	if blobSigner, ok := inner.(notation.BlobSigner); ok {
+		return &sizeLimitedBlobSigner{
+			sizeLimitedSigner: s,
+			blobSigner:        blobSigner,
+		}, nil
+	}
+	return s, nil
+}
+
+// Sign signs the artifact described by its descriptor with the inner signer,
+// and returns the signature and SignerInfo if the signature envelope does
+// not exceed the maximum size.
+func (s *sizeLimitedSigner) Sign(ctx context.Context, desc ocispec.Descriptor, opts notation.SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
+	sig, signerInfo, err := s.inner.Sign(ctx, desc, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.limit(sig, signerInfo)
+}
+
+// limit returns sig and signerInfo if sig does not exceed the maximum size.
+func (s *sizeLimitedSigner) limit(sig []byte, signerInfo *signature.SignerInfo) ([]byte, *signature.SignerInfo, error) {
+	if len(sig) > s.maxBytes {
+		return nil, nil, notation.ErrorSignatureTooLarge{Size: len(sig), MaxSize: s.maxBytes}
+	}
+	return sig, signerInfo, nil
+}
+
+// PluginAnnotations returns the signature manifest annotations of the inner
+// signer, if any.
+func (s *sizeLimitedSigner) PluginAnnotations() map[string]string {
+	if signerAnts, ok := s.inner.(interface{ PluginAnnotations() map[string]string }); ok {
+		return signerAnts.PluginAnnotations()
+	}
+	return nil
+}
+
+// sizeLimitedBlobSigner is a sizeLimitedSigner whose inner signer implements
+// [notation.BlobSigner].
+type sizeLimitedBlobSigner struct {
+	*sizeLimitedSigner
+	blobSigner notation.BlobSigner
+}
+
+// SignBlob signs the descriptor returned by genDesc with the inner signer,
+// and returns the signature and SignerInfo if the signature envelope does
+// not exceed the maximum size.
+func (s *sizeLimitedBlobSigner) SignBlob(ctx context.Context, genDesc notation.BlobDescriptorGenerator, opts notation.SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
+	sig, signerInfo, err := s.blobSigner.SignBlob(ctx, genDesc, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.limit(sig, signerInfo)
+}
@@ -0,0 +1,164 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/plugin/proto"
+)
+
+func TestSizeLimitedSigner(t *testing.T) {
+	keyCert := keyCertPairCollections[0]
+	genericSigner, err := NewGenericSigner(keyCert.key, keyCert.certs)
+	if err != nil {
+		t.Fatalf("NewGenericSigner() error = %v", err)
+	}
+	desc, opts := generateSigningContent()
+	opts.SignatureMediaType = signature.RegisteredEnvelopeTypes()[0]
+	sig, _, err := genericSigner.Sign(context.Background(), desc, opts)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	maxBytes := len(sig) + 1024
+
+	t.Run("within limit", func(t *testing.T) {
+		s, err := NewSizeLimitedSigner(genericSigner, maxBytes)
+		if err != nil {
+			t.Fatalf("NewSizeLimitedSigner() error = %v", err)
+		}
+		sig, signerInfo, err := s.Sign(context.Background(), desc, opts)
+		if err != nil {
+			t.Fatalf("Sign() error = %v", err)
+		}
+		if len(sig) == 0 || signerInfo == nil {
+			t.Fatal("expected signature and signer info")
+		}
+	})
+
+	t.Run("exceeds limit", func(t *testing.T) {
+		s, err := NewSizeLimitedSigner(genericSigner, 16)
+		if err != nil {
+			t.Fatalf("NewSizeLimitedSigner() error = %v", err)
+		}
+		sig, signerInfo, err := s.Sign(context.Background(), desc, opts)
+		var errTooLarge notation.ErrorSignatureTooLarge
+		if !errors.As(err, &errTooLarge) {
+			t.Fatalf("expected ErrorSignatureTooLarge, got %v", err)
+		}
+		if errTooLarge.MaxSize != 16 || errTooLarge.Size <= 16 {
+			t.Fatalf("unexpected error %+v", errTooLarge)
+		}
+		if sig != nil || signerInfo != nil {
+			t.Fatal("expected no signature on error")
+		}
+	})
+
+	t.Run("plugin annotations", func(t *testing.T) {
+		keySpec, _ := proto.DecodeKeySpec(proto.KeySpec(keyCert.keySpecName))
+		pluginSigner := &PluginSigner{
+			plugin: newMockPlugin(keyCert.key, keyCert.certs, keySpec),
+		}
+		s, err := NewSizeLimitedSigner(pluginSigner, maxBytes)
+		if err != nil {
+			t.Fatalf("NewSizeLimitedSigner() error = %v", err)
+		}
+		if _, _, err := s.Sign(context.Background(), desc, opts); err != nil {
+			t.Fatalf("Sign() error = %v", err)
+		}
+		want := map[string]string{
+			AnnotationPluginName:    "testPlugin",
+			AnnotationPluginVersion: "1.0",
+		}
+		got := s.(interface{ PluginAnnotations() map[string]string }).PluginAnnotations()
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("PluginAnnotations() = %v, want %v", got, want)
+		}
+		s, err = NewSizeLimitedSigner(genericSigner, maxBytes)
+		if err != nil {
+			t.Fatalf("NewSizeLimitedSigner() error = %v", err)
+		}
+		if got := s.(interface{ PluginAnnotations() map[string]string }).PluginAnnotations(); got != nil {
+			t.Fatalf("expected no plugin annotations, got %v", got)
+		}
+	})
+
+	t.Run("blob signer", func(t *testing.T) {
+		s, err := NewSizeLimitedSigner(genericSigner, maxBytes)
+		if err != nil {
+			t.Fatalf("NewSizeLimitedSigner() error = %v", err)
+		}
+		blobSigner, ok := s.(notation.BlobSigner)
+		if !ok {
+			t.Fatal("expected a notation.BlobSigner")
+		}
+		if _, _, err := blobSigner.SignBlob(context.Background(), getDescriptorFunc(false), opts); err != nil {
+			t.Fatalf("SignBlob() error = %v", err)
+		}
+
+		s, err = NewSizeLimitedSigner(genericSigner, 16)
+		if err != nil {
+			t.Fatalf("NewSizeLimitedSigner() error = %v", err)
+		}
+		var errTooLarge notation.ErrorSignatureTooLarge
+		if _, _, err := s.(notation.BlobSigner).SignBlob(context.Background(), getDescriptorFunc(false), opts); !errors.As(err, &errTooLarge) {
+			t.Fatalf("expected ErrorSignatureTooLarge, got %v", err)
+		}
+	})
+
+	t.Run("not a blob signer", func(t *testing.T) {
+		s, err := NewSizeLimitedSigner(signOnlySigner{genericSigner}, maxBytes)
+		if err != nil {
+			t.Fatalf("NewSizeLimitedSigner() error = %v", err)
+		}
+		if _, ok := s.(notation.BlobSigner); ok {
+			t.Fatal("expected not a notation.BlobSigner")
+		}
+	})
+}
+
+func TestNewSizeLimitedSignerError(t *testing.T) {
+	keyCert := keyCertPairCollections[0]
+	genericSigner, err := NewGenericSigner(keyCert.key, keyCert.certs)
+	if err != nil {
+		t.Fatalf("NewGenericSigner() error = %v", err)
+	}
+	tests := []struct {
+		name     string
+		inner    notation.Signer
+		maxBytes int
+		wantErr  string
+	}{
+		{name: "nil inner signer", maxBytes: 1024, wantErr: "inner signer cannot be nil"},
+		{name: "zero maxBytes", inner: genericSigner, wantErr: "maxBytes expects a positive number, got 0"},
+		{name: "negative maxBytes", inner: genericSigner, maxBytes: -1, wantErr: "maxBytes expects a positive number, got -1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSizeLimitedSigner(tt.inner, tt.maxBytes); err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// signOnlySigner hides the methods of a signer other than Sign.
+type signOnlySigner struct {
+	notation.Signer
+}
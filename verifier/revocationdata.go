@@ -0,0 +1,204 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
+	"github.com/notaryproject/notation-core-go/revocation/purpose"
+	"github.com/notaryproject/notation-go"
+	"golang.org/x/crypto/ocsp"
+)
+
+// maxOCSPRequestSize is the maximum size of an OCSP request read by
+// offlineOCSPTransport.
+const maxOCSPRequestSize = 64 * 1024
+
+// newOfflineRevocationValidator creates a code signing revocation validator
+// checking certChain against the revocation data supplied by provider instead
+// of fetching it from the network. The freshness of the revocation data is
+// checked at timeOfVerification.
+func newOfflineRevocationValidator(provider notation.RevocationDataProvider, certChain []*x509.Certificate, maxAge time.Duration, timeOfVerification time.Time) (revocation.Validator, error) {
+	return revocation.NewWithOptions(revocation.Options{
+		OCSPHTTPClient: &http.Client{
+			Transport: &offlineOCSPTransport{
+				provider:  provider,
+				certChain: certChain,
+				maxAge:    maxAge,
+				now:       timeOfVerification,
+			},
+		},
+		CRLFetcher: &offlineCRLFetcher{
+			provider:  provider,
+			certChain: certChain,
+			maxAge:    maxAge,
+			now:       timeOfVerification,
+		},
+		CertChainPurpose: purpose.CodeSigning,
+	})
+}
+
+// offlineCRLFetcher implements [corecrl.Fetcher] with the CRLs supplied by a
+// [notation.RevocationDataProvider].
+type offlineCRLFetcher struct {
+	provider  notation.RevocationDataProvider
+	certChain []*x509.Certificate
+	maxAge    time.Duration
+	now       time.Time
+}
+
+// Fetch returns the CRL issued by the issuer of the certificate listing url as
+// a CRL distribution point.
+func (f *offlineCRLFetcher) Fetch(ctx context.Context, url string) (*corecrl.Bundle, error) {
+	var issuer *x509.Certificate
+	for i, cert := range f.certChain {
+		for _, distributionPoint := range cert.CRLDistributionPoints {
+			if distributionPoint == url {
+				issuer = issuerOf(f.certChain, i)
+				break
+			}
+		}
+		if issuer != nil {
+			break
+		}
+	}
+	if issuer == nil {
+		return nil, fmt.Errorf("no certificate in the certificate chain has the CRL distribution point %q", url)
+	}
+
+	crlBytes, err := f.provider.CRL(ctx, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the CRL issued by %q: %w", issuer.Subject, err)
+	}
+	crl, err := x509.ParseRevocationList(crlBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the CRL issued by %q: %w", issuer.Subject, err)
+	}
+	if err := checkRevocationDataFreshness(crl.ThisUpdate, crl.NextUpdate, f.maxAge, f.now); err != nil {
+		return nil, fmt.Errorf("the CRL issued by %q is not fresh: %w", issuer.Subject, err)
+	}
+	return &corecrl.Bundle{BaseCRL: crl}, nil
+}
+
+// offlineOCSPTransport implements [http.RoundTripper] answering the OCSP
+// requests with the OCSP responses supplied by a
+// [notation.RevocationDataProvider].
+type offlineOCSPTransport struct {
+	provider  notation.RevocationDataProvider
+	certChain []*x509.Certificate
+	maxAge    time.Duration
+	now       time.Time
+}
+
+// RoundTrip answers the OCSP request req sent with either the GET or the POST
+// method.
+func (t *offlineOCSPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBytes []byte
+	switch req.Method {
+	case http.MethodGet:
+		encodedReq, err := url.PathUnescape(path.Base(req.URL.EscapedPath()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the OCSP request: %w", err)
+		}
+		reqBytes, err = base64.StdEncoding.DecodeString(encodedReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the OCSP request: %w", err)
+		}
+	case http.MethodPost:
+		if req.Body == nil {
+			return nil, errors.New("failed to read the OCSP request: empty body")
+		}
+		defer req.Body.Close()
+		var err error
+		reqBytes, err = io.ReadAll(io.LimitReader(req.Body, maxOCSPRequestSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the OCSP request: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported OCSP request method %q", req.Method)
+	}
+	ocspReq, err := ocsp.ParseRequest(reqBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the OCSP request: %w", err)
+	}
+
+	var cert, issuer *x509.Certificate
+	for i, c := range t.certChain {
+		if c.SerialNumber.Cmp(ocspReq.SerialNumber) == 0 {
+			cert, issuer = c, issuerOf(t.certChain, i)
+			break
+		}
+	}
+	if cert == nil {
+		return nil, fmt.Errorf("no certificate in the certificate chain has the serial number %s", ocspReq.SerialNumber)
+	}
+
+	respBytes, err := t.provider.OCSPResponse(req.Context(), cert, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the OCSP response for %q: %w", cert.Subject, err)
+	}
+	resp, err := ocsp.ParseResponseForCert(respBytes, cert, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the OCSP response for %q: %w", cert.Subject, err)
+	}
+	if err := checkRevocationDataFreshness(resp.ThisUpdate, resp.NextUpdate, t.maxAge, t.now); err != nil {
+		return nil, fmt.Errorf("the OCSP response for %q is not fresh: %w", cert.Subject, err)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/ocsp-response"}},
+		Body:          io.NopCloser(bytes.NewReader(respBytes)),
+		ContentLength: int64(len(respBytes)),
+		Request:       req,
+	}, nil
+}
+
+// issuerOf returns the issuer of the i-th certificate of certChain. The last
+// certificate of the chain is the root certificate, which is self-signed.
+func issuerOf(certChain []*x509.Certificate, i int) *x509.Certificate {
+	if i+1 < len(certChain) {
+		return certChain[i+1]
+	}
+	return certChain[i]
+}
+
+// checkRevocationDataFreshness checks that the revocation data produced at
+// thisUpdate is not past nextUpdate at now, and not older than maxAge if
+// maxAge is positive. A zero nextUpdate means that newer revocation data is
+// always available.
+func checkRevocationDataFreshness(thisUpdate, nextUpdate time.Time, maxAge time.Duration, now time.Time) error {
+	if !nextUpdate.IsZero() && now.After(nextUpdate) {
+		return fmt.Errorf("expired at %s", nextUpdate.Format(time.RFC3339))
+	}
+	if maxAge > 0 && now.Sub(thisUpdate) > maxAge {
+		return fmt.Errorf("produced at %s, older than the maximum age %v", thisUpdate.Format(time.RFC3339), maxAge)
+	}
+	return nil
+}
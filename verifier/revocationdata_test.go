@@ -0,0 +1,229 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"golang.org/x/crypto/ocsp"
+)
+
+// staticRevocationData implements notation.RevocationDataProvider with
+// revocation data indexed by the subject of the certificates.
+type staticRevocationData struct {
+	crls          map[string][]byte
+	ocspResponses map[string][]byte
+}
+
+func (d *staticRevocationData) CRL(_ context.Context, issuer *x509.Certificate) ([]byte, error) {
+	crl, ok := d.crls[issuer.Subject.String()]
+	if !ok {
+		return nil, errors.New("CRL not found")
+	}
+	return crl, nil
+}
+
+func (d *staticRevocationData) OCSPResponse(_ context.Context, cert, _ *x509.Certificate) ([]byte, error) {
+	resp, ok := d.ocspResponses[cert.Subject.String()]
+	if !ok {
+		return nil, errors.New("OCSP response not found")
+	}
+	return resp, nil
+}
+
+func createOCSPResponse(t *testing.T, tuple testhelper.RSACertTuple, issuer *x509.Certificate, status int, thisUpdate, nextUpdate time.Time) []byte {
+	t.Helper()
+	template := ocsp.Response{
+		Status:       status,
+		SerialNumber: tuple.Cert.SerialNumber,
+		ThisUpdate:   thisUpdate,
+		NextUpdate:   nextUpdate,
+		// id-pkix-ocsp-nocheck, as the response is signed by the certificate
+		ExtraExtensions: []pkix.Extension{{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 5}}},
+	}
+	if status == ocsp.Revoked {
+		template.RevokedAt = thisUpdate
+		template.RevocationReason = ocsp.Unspecified
+	}
+	resp, err := ocsp.CreateResponse(tuple.Cert, issuer, template, tuple.PrivateKey)
+	if err != nil {
+		t.Fatalf("failed to create OCSP response: %v", err)
+	}
+	return resp
+}
+
+func createCRL(t *testing.T, issuer testhelper.RSACertTuple, revoked *x509.Certificate, thisUpdate, nextUpdate time.Time) []byte {
+	t.Helper()
+	template := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: thisUpdate,
+		NextUpdate: nextUpdate,
+	}
+	if revoked != nil {
+		template.RevokedCertificateEntries = []x509.RevocationListEntry{{
+			SerialNumber:   revoked.SerialNumber,
+			RevocationTime: thisUpdate,
+		}}
+	}
+	crl, err := x509.CreateRevocationList(rand.Reader, template, issuer.Cert, issuer.PrivateKey)
+	if err != nil {
+		t.Fatalf("failed to create CRL: %v", err)
+	}
+	return crl
+}
+
+func TestVerifyRevocationWithRevocationData(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	ocspTuples := testhelper.GetRevokableRSAChain(2)
+	ocspChain := []*x509.Certificate{ocspTuples[0].Cert, ocspTuples[1].Cert}
+	leafSubject := ocspChain[0].Subject.String()
+	crlTuples := testhelper.GetRevokableRSAChainWithRevocations(2, false, true)
+	crlChain := []*x509.Certificate{crlTuples[0].Cert, crlTuples[1].Cert}
+	rootSubject := crlChain[1].Subject.String()
+
+	tests := []struct {
+		name      string
+		chain     []*x509.Certificate
+		data      *staticRevocationData
+		maxAge    time.Duration
+		wantError string
+	}{
+		{
+			name:  "OCSP good",
+			chain: ocspChain,
+			data: &staticRevocationData{ocspResponses: map[string][]byte{
+				leafSubject: createOCSPResponse(t, ocspTuples[0], ocspChain[1], ocsp.Good, now.Add(-time.Minute), now.Add(time.Hour)),
+			}},
+			maxAge: time.Hour,
+		},
+		{
+			name:  "OCSP revoked",
+			chain: ocspChain,
+			data: &staticRevocationData{ocspResponses: map[string][]byte{
+				leafSubject: createOCSPResponse(t, ocspTuples[0], ocspChain[1], ocsp.Revoked, now.Add(-time.Minute), now.Add(time.Hour)),
+			}},
+			wantError: "is revoked",
+		},
+		{
+			name:  "OCSP response older than max age",
+			chain: ocspChain,
+			data: &staticRevocationData{ocspResponses: map[string][]byte{
+				leafSubject: createOCSPResponse(t, ocspTuples[0], ocspChain[1], ocsp.Good, now.Add(-2*time.Hour), now.Add(time.Hour)),
+			}},
+			maxAge:    time.Hour,
+			wantError: "revocation status is unknown",
+		},
+		{
+			name:  "expired OCSP response",
+			chain: ocspChain,
+			data: &staticRevocationData{ocspResponses: map[string][]byte{
+				leafSubject: createOCSPResponse(t, ocspTuples[0], ocspChain[1], ocsp.Good, now.Add(-2*time.Hour), now.Add(-time.Hour)),
+			}},
+			wantError: "revocation status is unknown",
+		},
+		{
+			name:      "missing OCSP response",
+			chain:     ocspChain,
+			data:      &staticRevocationData{},
+			wantError: "revocation status is unknown",
+		},
+		{
+			name:  "CRL good",
+			chain: crlChain,
+			data: &staticRevocationData{crls: map[string][]byte{
+				rootSubject: createCRL(t, crlTuples[1], nil, now.Add(-time.Minute), now.Add(time.Hour)),
+			}},
+			maxAge: time.Hour,
+		},
+		{
+			name:  "CRL revoked",
+			chain: crlChain,
+			data: &staticRevocationData{crls: map[string][]byte{
+				rootSubject: createCRL(t, crlTuples[1], crlChain[0], now.Add(-time.Minute), now.Add(time.Hour)),
+			}},
+			wantError: "is revoked",
+		},
+		{
+			name:  "CRL older than max age",
+			chain: crlChain,
+			data: &staticRevocationData{crls: map[string][]byte{
+				rootSubject: createCRL(t, crlTuples[1], nil, now.Add(-2*time.Hour), now.Add(time.Hour)),
+			}},
+			maxAge:    time.Hour,
+			wantError: "revocation status is unknown",
+		},
+		{
+			name:      "missing CRL",
+			chain:     crlChain,
+			data:      &staticRevocationData{},
+			wantError: "revocation status is unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the network is never used with the revocation data provider
+			v := &verifier{}
+			outcome := createMockOutcome(tt.chain, now)
+			result := v.verifyRevocation(ctx, outcome, revocationOptions{
+				requireCheck:       true,
+				dataProvider:       tt.data,
+				maxDataAge:         tt.maxAge,
+				timeOfVerification: now,
+			})
+			if tt.wantError == "" {
+				if result.Error != nil {
+					t.Fatalf("expected verifyRevocation to succeed, but got %v", result.Error)
+				}
+				return
+			}
+			if result.Error == nil || !strings.Contains(result.Error.Error(), tt.wantError) {
+				t.Fatalf("expected error containing %q, but got %v", tt.wantError, result.Error)
+			}
+		})
+	}
+}
+
+func TestCheckRevocationDataFreshness(t *testing.T) {
+	now := time.Now()
+	if err := checkRevocationDataFreshness(now.Add(-time.Minute), time.Time{}, 0, now); err != nil {
+		t.Fatalf("expected revocation data without nextUpdate to be fresh, but got %v", err)
+	}
+	if err := checkRevocationDataFreshness(now.Add(-2*time.Hour), now.Add(-time.Hour), 0, now); err == nil || !strings.HasPrefix(err.Error(), "expired at") {
+		t.Fatalf("expected expired error, but got %v", err)
+	}
+	if err := checkRevocationDataFreshness(now.Add(-2*time.Hour), now.Add(time.Hour), time.Hour, now); err == nil || !strings.Contains(err.Error(), "older than the maximum age 1h0m0s") {
+		t.Fatalf("expected max age error, but got %v", err)
+	}
+
+	// the freshness is checked at the time of verification, not the current
+	// time
+	if err := checkRevocationDataFreshness(now.Add(-2*time.Hour), now.Add(time.Hour), time.Hour, now.Add(-90*time.Minute)); err != nil {
+		t.Fatalf("expected revocation data to be fresh at the time of verification, but got %v", err)
+	}
+	if err := checkRevocationDataFreshness(now.Add(-2*time.Hour), now.Add(-time.Hour), 0, now.Add(-90*time.Minute)); err != nil {
+		t.Fatalf("expected revocation data not to be expired at the time of verification, but got %v", err)
+	}
+}
@@ -61,51 +61,92 @@ func LoadBlobDocument() (*BlobDocument, error) {
 	return &doc, err
 }
 
+// ParseBlobDocument parses and validates the blob trust policy document in
+// JSON format data as [ParseOCIDocument] does.
+func ParseBlobDocument(data []byte) (*BlobDocument, error) {
+	var doc BlobDocument
+	if err := parseDocument(data, &doc); err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
 // Validate validates a blob trust policy document according to its version's
 // rule set.
 // If any rule is violated, returns an error.
 func (policyDoc *BlobDocument) Validate() error {
+	if errs := policyDoc.validationErrors(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// Lint validates a blob trust policy document like [BlobDocument.Validate],
+// but does not stop at the first violated rule. If trustStoreFS is not nil,
+// Lint also verifies that every trust store referenced by the policy
+// statements exists in trustStoreFS.
+// All violations found are returned as a single joined error.
+func (policyDoc *BlobDocument) Lint(trustStoreFS dir.SysFS) error {
+	errs := policyDoc.validationErrors()
+	if policyDoc != nil && trustStoreFS != nil {
+		for _, statement := range policyDoc.TrustPolicies {
+			errs = append(errs, validateTrustStoresExist(trustStoreFS, statement.Name, statement.TrustStores)...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validationErrors returns all the rules violated by the blob trust policy
+// document in the order they are checked.
+func (policyDoc *BlobDocument) validationErrors() []error {
 	// sanity check
 	if policyDoc == nil {
-		return errors.New("blob trust policy document cannot be nil")
+		return []error{errors.New("blob trust policy document cannot be nil")}
 	}
 
+	var errs []error
 	// Validate Version
 	if policyDoc.Version == "" {
-		return errors.New("blob trust policy document has empty version, version must be specified")
-	}
-	if !slices.Contains(supportedBlobPolicyVersions, policyDoc.Version) {
-		return fmt.Errorf("blob trust policy document uses unsupported version %q", policyDoc.Version)
+		errs = append(errs, errors.New("blob trust policy document has empty version, version must be specified"))
+	} else if !slices.Contains(supportedBlobPolicyVersions, policyDoc.Version) {
+		errs = append(errs, fmt.Errorf("blob trust policy document uses unsupported version %q", policyDoc.Version))
 	}
 
 	// Validate the policy according to 1.0 rules
 	if len(policyDoc.TrustPolicies) == 0 {
-		return errors.New("blob trust policy document can not have zero trust policy statements")
+		return append(errs, errors.New("blob trust policy document can not have zero trust policy statements"))
 	}
 	policyNames := set.New[string]()
 	var foundGlobalPolicy bool
 	for _, statement := range policyDoc.TrustPolicies {
 		// Verify unique policy statement names across the policy document
 		if policyNames.Contains(statement.Name) {
-			return fmt.Errorf("multiple blob trust policy statements use the same name %q, statement names must be unique", statement.Name)
+			errs = append(errs, fmt.Errorf("multiple blob trust policy statements use the same name %q, statement names must be unique", statement.Name))
+			continue
 		}
+		policyNames.Add(statement.Name)
 		if err := validatePolicyCore(statement.Name, statement.SignatureVerification, statement.TrustStores, statement.TrustedIdentities); err != nil {
-			return fmt.Errorf("blob trust policy: %w", err)
+			errs = append(errs, fmt.Errorf("blob trust policy: %w", err))
+			continue
 		}
 		if statement.GlobalPolicy {
 			if foundGlobalPolicy {
-				return errors.New("multiple blob trust policy statements have globalPolicy set to true. Only one trust policy statement can be marked as global policy")
+				errs = append(errs, errors.New("multiple blob trust policy statements have globalPolicy set to true. Only one trust policy statement can be marked as global policy"))
+				continue
 			}
 
 			// verificationLevel is skip
 			if reflect.DeepEqual(statement.SignatureVerification.VerificationLevel, LevelSkip) {
-				return errors.New("global blob trust policy statement cannot have verification level set to skip")
+				errs = append(errs, errors.New("global blob trust policy statement cannot have verification level set to skip"))
+				continue
 			}
 			foundGlobalPolicy = true
 		}
-		policyNames.Add(statement.Name)
 	}
-	return nil
+	return errs
 }
 
 // GetApplicableTrustPolicy returns a pointer to the deep copied [BlobTrustPolicy]
@@ -38,6 +38,29 @@ func TestLoadBlobDocument(t *testing.T) {
 	}
 }
 
+func TestParseBlobDocument(t *testing.T) {
+	policyDoc := dummyBlobPolicyDocument()
+	policyJSON, err := json.Marshal(policyDoc)
+	if err != nil {
+		t.Fatalf("failed to marshal policy document: %v", err)
+	}
+	got, err := ParseBlobDocument(policyJSON)
+	if err != nil {
+		t.Fatalf("ParseBlobDocument() error = %v", err)
+	}
+	if !reflect.DeepEqual(*got, policyDoc) {
+		t.Fatalf("ParseBlobDocument() = %+v, want %+v", *got, policyDoc)
+	}
+
+	expectedErr := `malformed trust policy: json: unknown field "globalPolicies"`
+	if _, err := ParseBlobDocument([]byte(`{"version":"1.0","globalPolicies":true}`)); err == nil || err.Error() != expectedErr {
+		t.Fatalf("ParseBlobDocument() error = %v, wantErr %q", err, expectedErr)
+	}
+	if _, err := ParseBlobDocument([]byte(`{"version":"1.0"}`)); err == nil {
+		t.Fatal("expected ParseBlobDocument() to validate the document")
+	}
+}
+
 func TestValidate_BlobDocument(t *testing.T) {
 	policyDoc := dummyBlobPolicyDocument()
 	if err := policyDoc.Validate(); err != nil {
@@ -166,3 +189,29 @@ func validateGetApplicableTrustPolicy(t *testing.T, policyDoc BlobDocument, poli
 		t.Fatalf("GetApplicableTrustPolicy() returned unexpected policy for %s", policyName)
 	}
 }
+
+func TestLintBlobDocument(t *testing.T) {
+	trustStoreRoot := t.TempDir()
+	for _, storeType := range []string{"ca", "signingAuthority"} {
+		if err := os.MkdirAll(filepath.Join(trustStoreRoot, dir.X509TrustStoreDir(storeType, "valid-trust-store")), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	trustStoreFS := dir.NewSysFS(trustStoreRoot)
+
+	policyDoc := dummyBlobPolicyDocument()
+	if err := policyDoc.Lint(trustStoreFS); err != nil {
+		t.Fatalf("Lint() returned error on a good policy document: %v", err)
+	}
+
+	policyDoc.Version = ""
+	policyDoc.TrustPolicies[0].TrustStores = []string{"ca:valid-trust-store", "ca:missing-trust-store"}
+	err := policyDoc.Lint(trustStoreFS)
+	if err == nil {
+		t.Fatal("Lint() should return error")
+	}
+	expectedErr := "blob trust policy document has empty version, version must be specified\ntrust policy statement \"test-statement-name\" references trust store \"ca:missing-trust-store\" which does not exist"
+	if err.Error() != expectedErr {
+		t.Fatalf("expected error %q, got %q", expectedErr, err)
+	}
+}
@@ -48,7 +48,21 @@ type OCITrustPolicy struct {
 	// TrustedIdentities this policy statement pins
 	TrustedIdentities []string `json:"trustedIdentities"`
 
-	// RegistryScopes that this policy statement affects
+	// RegistryScopes that this policy statement affects. A scope is either
+	//   - a repository, e.g. "registry.example.com:5000/team/app", matching
+	//     the artifacts of that repository only,
+	//   - a repository prefix ending with "/*", e.g.
+	//     "registry.example.com:5000/team/*", matching the artifacts of all
+	//     the repositories under that prefix at any depth, or of all the
+	//     repositories of the registry for "registry.example.com:5000/*",
+	//   - or the wildcard "*", matching all the artifacts.
+	// The registry of a scope must match the registry of the artifact as is,
+	// including the port, i.e. "registry.example.com/*" does not match
+	// artifacts in "registry.example.com:5000".
+	// If multiple scopes match an artifact, a repository scope takes
+	// precedence over repository prefix scopes, a longer repository prefix
+	// takes precedence over a shorter one, and the wildcard "*" applies only
+	// if no other scope matches.
 	RegistryScopes []string `json:"registryScopes"`
 }
 
@@ -72,19 +86,141 @@ var LoadDocument = LoadOCIDocument
 
 var supportedOCIPolicyVersions = []string{"1.0"}
 
+// repositoryPrefixSuffix is the suffix of a registry scope matching all the
+// repositories under a prefix, e.g. "domain.com/team/*".
+const repositoryPrefixSuffix = "/*"
+
+var (
+	// Domain and Repository regexes are adapted from distribution
+	// implementation
+	// https://github.com/distribution/distribution/blob/main/reference/regexp.go#L31
+	domainRegexp     = regexp.MustCompile(`^(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(?:(?:\.(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))+)?(?::[0-9]+)?$`)
+	repositoryRegexp = regexp.MustCompile(`^[a-z0-9]+(?:(?:(?:[._]|__|[-]*)[a-z0-9]+)+)?(?:(?:/[a-z0-9]+(?:(?:(?:[._]|__|[-]*)[a-z0-9]+)+)?)+)?$`)
+
+	registryScopeEnsureMessage        = "make sure it is a fully qualified repository without the scheme, protocol or tag. For example domain.com/my/repository or a local scope like local/myOCILayout"
+	registryScopeWildCardErrorMessage = "registry scope %q with wild card(s) is not valid, " + registryScopeEnsureMessage
+)
+
 // LoadOCIDocument retrieves a trust policy document from the local file system.
 // It attempts to read from [dir.PathOCITrustPolicy] first; if not found,
 // it tries [dir.PathTrustPolicy].
 // If both dir.PathOCITrustPolicy and dir.PathTrustPolicy exist,
 // dir.PathOCITrustPolicy will be read.
 func LoadOCIDocument() (*OCIDocument, error) {
+	return loadOCIDocument(dir.ConfigFS())
+}
+
+// ParseOCIDocument parses and validates the trust policy document in JSON
+// format data, e.g. fetched from a remote configuration service, without
+// reading the local file system. Unknown fields are rejected, so that
+// misspelled fields are not silently ignored.
+// The returned document can be passed to the verifier constructors.
+func ParseOCIDocument(data []byte) (*OCIDocument, error) {
+	var doc OCIDocument
+	if err := parseDocument(data, &doc); err != nil {
+		return nil, err
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// LoadMergedOCIDocument retrieves the system level and the user level trust
+// policy documents, as located by [LoadOCIDocument], and merges them into a
+// single document.
+//
+// If only one of the levels has a trust policy document, that document is
+// returned as is. Otherwise, all user level statements are kept and a system
+// level statement is dropped if each of its registry scopes is claimed by a
+// user level statement. A system level statement that is only partially
+// overridden, or that shares its name with a user level statement, can not be
+// merged safely and results in an error naming the conflicting statements.
+func LoadMergedOCIDocument() (*OCIDocument, error) {
+	systemDoc, err := loadOCIDocument(dir.SystemConfigFS())
+	if err != nil && !errors.As(err, &errPolicyNotExist{}) {
+		return nil, fmt.Errorf("failed to load system level trust policy: %w", err)
+	}
+	userDoc, err := loadOCIDocument(dir.ConfigFS())
+	if err != nil && !errors.As(err, &errPolicyNotExist{}) {
+		return nil, fmt.Errorf("failed to load user level trust policy: %w", err)
+	}
+	switch {
+	case systemDoc == nil && userDoc == nil:
+		return nil, errPolicyNotExist{}
+	case systemDoc == nil:
+		return userDoc, nil
+	case userDoc == nil:
+		return systemDoc, nil
+	}
+	return mergeOCIDocuments(systemDoc, userDoc)
+}
+
+// mergeOCIDocuments merges systemDoc into userDoc, with userDoc taking
+// precedence on registry scope conflicts.
+func mergeOCIDocuments(systemDoc, userDoc *OCIDocument) (*OCIDocument, error) {
+	if systemDoc.Version != userDoc.Version {
+		return nil, fmt.Errorf("system level trust policy version %q does not match user level trust policy version %q", systemDoc.Version, userDoc.Version)
+	}
+
+	// map each user level registry scope to the statement claiming it
+	userScopes := make(map[string]string)
+	userNames := make(map[string]bool)
+	for _, statement := range userDoc.TrustPolicies {
+		userNames[statement.Name] = true
+		for _, scope := range statement.RegistryScopes {
+			userScopes[scope] = statement.Name
+		}
+	}
+
+	merged := &OCIDocument{
+		Version:       userDoc.Version,
+		TrustPolicies: make([]OCITrustPolicy, 0, len(userDoc.TrustPolicies)+len(systemDoc.TrustPolicies)),
+	}
+	for _, statement := range userDoc.TrustPolicies {
+		merged.TrustPolicies = append(merged.TrustPolicies, *statement.clone())
+	}
+	var errs []error
+	for _, statement := range systemDoc.TrustPolicies {
+		var overriddenBy []string
+		for _, scope := range statement.RegistryScopes {
+			if name, ok := userScopes[scope]; ok && !slices.Contains(overriddenBy, name) {
+				overriddenBy = append(overriddenBy, name)
+			}
+		}
+		switch {
+		case len(overriddenBy) == 0 && userNames[statement.Name]:
+			errs = append(errs, fmt.Errorf("system level trust policy statement %q conflicts with the user level trust policy statement of the same name", statement.Name))
+		case len(overriddenBy) == 0:
+			merged.TrustPolicies = append(merged.TrustPolicies, *statement.clone())
+		case !scopesCovered(statement.RegistryScopes, userScopes):
+			errs = append(errs, fmt.Errorf("system level trust policy statement %q is partially overridden by user level trust policy statements %q, registry scopes must be either fully or not overridden", statement.Name, overriddenBy))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return merged, nil
+}
+
+// scopesCovered returns true if each scope of scopes exists in coveredScopes.
+func scopesCovered(scopes []string, coveredScopes map[string]string) bool {
+	for _, scope := range scopes {
+		if _, ok := coveredScopes[scope]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func loadOCIDocument(fsys dir.SysFS) (*OCIDocument, error) {
 	var doc OCIDocument
 
 	// attempt to load the document from dir.PathOCITrustPolicy
-	if err := getDocument(dir.PathOCITrustPolicy, &doc); err != nil {
+	if err := getDocumentFromFS(fsys, dir.PathOCITrustPolicy, &doc); err != nil {
 		// if the document is not found at the first path, try the second path
 		if errors.As(err, &errPolicyNotExist{}) {
-			if err := getDocument(dir.PathTrustPolicy, &doc); err != nil {
+			if err := getDocumentFromFS(fsys, dir.PathTrustPolicy, &doc); err != nil {
 				return nil, err
 			}
 			return &doc, nil
@@ -99,40 +235,62 @@ func LoadOCIDocument() (*OCIDocument, error) {
 // Validate validates a policy document according to its version's rule set.
 // if any rule is violated, returns an error
 func (policyDoc *OCIDocument) Validate() error {
+	if errs := policyDoc.validationErrors(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// Lint validates a policy document like [OCIDocument.Validate], but does not
+// stop at the first violated rule. If trustStoreFS is not nil, Lint also
+// verifies that every trust store referenced by the policy statements exists
+// in trustStoreFS.
+// All violations found are returned as a single joined error.
+func (policyDoc *OCIDocument) Lint(trustStoreFS dir.SysFS) error {
+	errs := policyDoc.validationErrors()
+	if policyDoc != nil && trustStoreFS != nil {
+		for _, statement := range policyDoc.TrustPolicies {
+			errs = append(errs, validateTrustStoresExist(trustStoreFS, statement.Name, statement.TrustStores)...)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validationErrors returns all the rules violated by the policy document in
+// the order they are checked.
+func (policyDoc *OCIDocument) validationErrors() []error {
 	// sanity check
 	if policyDoc == nil {
-		return errors.New("oci trust policy document cannot be nil")
+		return []error{errors.New("oci trust policy document cannot be nil")}
 	}
 
+	var errs []error
 	// Validate Version
 	if policyDoc.Version == "" {
-		return errors.New("oci trust policy document has empty version, version must be specified")
-	}
-	if !slices.Contains(supportedOCIPolicyVersions, policyDoc.Version) {
-		return fmt.Errorf("oci trust policy document uses unsupported version %q", policyDoc.Version)
+		errs = append(errs, errors.New("oci trust policy document has empty version, version must be specified"))
+	} else if !slices.Contains(supportedOCIPolicyVersions, policyDoc.Version) {
+		errs = append(errs, fmt.Errorf("oci trust policy document uses unsupported version %q", policyDoc.Version))
 	}
 
 	// Validate the policy according to 1.0 rules
 	if len(policyDoc.TrustPolicies) == 0 {
-		return errors.New("oci trust policy document can not have zero trust policy statements")
+		return append(errs, errors.New("oci trust policy document can not have zero trust policy statements"))
 	}
 	policyNames := set.New[string]()
 	for _, statement := range policyDoc.TrustPolicies {
 		// Verify unique policy statement names across the policy document
 		if policyNames.Contains(statement.Name) {
-			return fmt.Errorf("multiple oci trust policy statements use the same name %q, statement names must be unique", statement.Name)
+			errs = append(errs, fmt.Errorf("multiple oci trust policy statements use the same name %q, statement names must be unique", statement.Name))
+			continue
 		}
 		if err := validatePolicyCore(statement.Name, statement.SignatureVerification, statement.TrustStores, statement.TrustedIdentities); err != nil {
-			return fmt.Errorf("oci trust policy: %w", err)
+			errs = append(errs, fmt.Errorf("oci trust policy: %w", err))
 		}
 		policyNames.Add(statement.Name)
 	}
 
 	// Verify registry scopes are valid
-	if err := validateRegistryScopes(policyDoc); err != nil {
-		return err
-	}
-	return nil
+	return append(errs, registryScopesErrors(policyDoc)...)
 }
 
 // GetApplicableTrustPolicy returns a pointer to the deep copied [OCITrustPolicy]
@@ -147,6 +305,8 @@ func (policyDoc *OCIDocument) GetApplicableTrustPolicy(artifactReference string)
 
 	var wildcardPolicy *OCITrustPolicy
 	var applicablePolicy *OCITrustPolicy
+	var prefixPolicy *OCITrustPolicy
+	var prefixPolicyLen int
 	for _, policyStatement := range policyDoc.TrustPolicies {
 		if slices.Contains(policyStatement.RegistryScopes, trustpolicy.Wildcard) {
 			// we need to deep copy because we can't use the loop variable
@@ -154,12 +314,19 @@ func (policyDoc *OCIDocument) GetApplicableTrustPolicy(artifactReference string)
 			wildcardPolicy = (&policyStatement).clone()
 		} else if slices.Contains(policyStatement.RegistryScopes, artifactPath) {
 			applicablePolicy = (&policyStatement).clone()
+		} else if n := matchRepositoryPrefix(policyStatement.RegistryScopes, artifactPath); n > prefixPolicyLen {
+			prefixPolicy = (&policyStatement).clone()
+			prefixPolicyLen = n
 		}
 	}
 	if applicablePolicy != nil {
 		// a policy with exact match for registry scope takes precedence over
-		// a wildcard (*) policy.
+		// a repository prefix (/*) policy and a wildcard (*) policy.
 		return applicablePolicy, nil
+	} else if prefixPolicy != nil {
+		// a policy with the longest matching repository prefix takes
+		// precedence over a wildcard (*) policy.
+		return prefixPolicy, nil
 	} else if wildcardPolicy != nil {
 		return wildcardPolicy, nil
 	} else {
@@ -167,6 +334,43 @@ func (policyDoc *OCIDocument) GetApplicableTrustPolicy(artifactReference string)
 	}
 }
 
+// ResolveStatement returns a pointer to the deep copied [OCITrustPolicy]
+// statement that the verifier applies to artifactReference, so that policy
+// authors can preview the scope matching without verifying a signature.
+//
+// Unlike [OCIDocument.GetApplicableTrustPolicy], the policy document is
+// validated first, so that an error is returned if the selection is
+// ambiguous, i.e. if a registry scope is used by multiple statements or
+// multiple statements use the wildcard (*) registry scope.
+func (policyDoc *OCIDocument) ResolveStatement(artifactReference string) (*OCITrustPolicy, error) {
+	if err := policyDoc.Validate(); err != nil {
+		return nil, err
+	}
+	return policyDoc.GetApplicableTrustPolicy(artifactReference)
+}
+
+// matchRepositoryPrefix returns the length of the longest repository prefix
+// scope, e.g. "domain.com/team/*", of scopes matching artifactPath, or 0 if
+// none matches.
+func matchRepositoryPrefix(scopes []string, artifactPath string) int {
+	var longest int
+	for _, scope := range scopes {
+		prefix, ok := strings.CutSuffix(scope, repositoryPrefixSuffix)
+		if !ok {
+			continue
+		}
+		// keep the trailing "/" so that a prefix only matches whole path
+		// segments, e.g. "domain.com/team/*" does not match
+		// "domain.com/teamA/app", and "domain.com/*" does not match
+		// "domain.com:5000/app"
+		prefix += "/"
+		if strings.HasPrefix(artifactPath, prefix) && len(prefix) > longest {
+			longest = len(prefix)
+		}
+	}
+	return longest
+}
+
 // clone returns a pointer to the deep copied [OCITrustPolicy]
 func (t *OCITrustPolicy) clone() *OCITrustPolicy {
 	return &OCITrustPolicy{
@@ -178,22 +382,26 @@ func (t *OCITrustPolicy) clone() *OCITrustPolicy {
 	}
 }
 
-// validateRegistryScopes validates if the policy document is following the
-// Notary Project spec rules for registry scopes
-func validateRegistryScopes(policyDoc *OCIDocument) error {
+// registryScopesErrors returns the violations of the Notary Project spec
+// rules for registry scopes found in the policy document
+func registryScopesErrors(policyDoc *OCIDocument) []error {
+	var errs []error
 	registryScopeCount := make(map[string]int)
 	for _, statement := range policyDoc.TrustPolicies {
 		// Verify registry scopes are valid
 		if len(statement.RegistryScopes) == 0 {
-			return fmt.Errorf("oci trust policy statement %q has zero registry scopes, it must specify registry scopes with at least one value", statement.Name)
+			errs = append(errs, fmt.Errorf("oci trust policy statement %q has zero registry scopes, it must specify registry scopes with at least one value", statement.Name))
+			continue
 		}
 		if len(statement.RegistryScopes) > 1 && slices.Contains(statement.RegistryScopes, trustpolicy.Wildcard) {
-			return fmt.Errorf("oci trust policy statement %q uses wildcard registry scope '*', a wildcard scope cannot be used in conjunction with other scope values", statement.Name)
+			errs = append(errs, fmt.Errorf("oci trust policy statement %q uses wildcard registry scope '*', a wildcard scope cannot be used in conjunction with other scope values", statement.Name))
+			continue
 		}
 		for _, scope := range statement.RegistryScopes {
 			if scope != trustpolicy.Wildcard {
-				if err := validateRegistryScopeFormat(scope); err != nil {
-					return err
+				if err := validateRegistryScope(scope); err != nil {
+					errs = append(errs, err)
+					continue
 				}
 			}
 			registryScopeCount[scope]++
@@ -203,12 +411,10 @@ func validateRegistryScopes(policyDoc *OCIDocument) error {
 	// Verify one policy statement per registry scope
 	for key := range registryScopeCount {
 		if registryScopeCount[key] > 1 {
-			return fmt.Errorf("registry scope %q is present in multiple oci trust policy statements, one registry scope value can only be associated with one statement", key)
+			errs = append(errs, fmt.Errorf("registry scope %q is present in multiple oci trust policy statements, one registry scope value can only be associated with one statement", key))
 		}
 	}
-
-	// No error
-	return nil
+	return errs
 }
 
 func getArtifactPathFromReference(artifactReference string) (string, error) {
@@ -225,21 +431,39 @@ func getArtifactPathFromReference(artifactReference string) (string, error) {
 	return artifactPath, nil
 }
 
+// validateRegistryScope validates if a scope of a trust policy statement is
+// a repository following the format defined in distribution spec, or a
+// repository prefix ending with "/*"
+func validateRegistryScope(scope string) error {
+	prefix, ok := strings.CutSuffix(scope, repositoryPrefixSuffix)
+	if !ok {
+		return validateRegistryScopeFormat(scope)
+	}
+	if strings.Contains(prefix, "*") {
+		return fmt.Errorf(registryScopeWildCardErrorMessage, scope)
+	}
+	if domain, repository, found := strings.Cut(prefix, "/"); found {
+		// the prefix is a repository
+		if domain == "" || repository == "" || !domainRegexp.MatchString(domain) || !repositoryRegexp.MatchString(repository) {
+			return fmt.Errorf(registryScopeWildCardErrorMessage, scope)
+		}
+		return nil
+	}
+	// the prefix is a registry
+	if !domainRegexp.MatchString(prefix) {
+		return fmt.Errorf(registryScopeWildCardErrorMessage, scope)
+	}
+	return nil
+}
+
 // validateRegistryScopeFormat validates if a scope is following the format
 // defined in distribution spec
 func validateRegistryScopeFormat(scope string) error {
-	// Domain and Repository regexes are adapted from distribution
-	// implementation
-	// https://github.com/distribution/distribution/blob/main/reference/regexp.go#L31
-	domainRegexp := regexp.MustCompile(`^(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(?:(?:\.(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))+)?(?::[0-9]+)?$`)
-	repositoryRegexp := regexp.MustCompile(`^[a-z0-9]+(?:(?:(?:[._]|__|[-]*)[a-z0-9]+)+)?(?:(?:/[a-z0-9]+(?:(?:(?:[._]|__|[-]*)[a-z0-9]+)+)?)+)?$`)
-	ensureMessage := "make sure it is a fully qualified repository without the scheme, protocol or tag. For example domain.com/my/repository or a local scope like local/myOCILayout"
-	errorMessage := "registry scope %q is not valid, " + ensureMessage
-	errorWildCardMessage := "registry scope %q with wild card(s) is not valid, " + ensureMessage
+	errorMessage := "registry scope %q is not valid, " + registryScopeEnsureMessage
 
 	// Check for presence of * in scope
 	if len(scope) > 1 && strings.Contains(scope, "*") {
-		return fmt.Errorf(errorWildCardMessage, scope)
+		return fmt.Errorf(registryScopeWildCardErrorMessage, scope)
 	}
 	domain, repository, found := strings.Cut(scope, "/")
 	if !found {
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/notaryproject/notation-go/dir"
@@ -61,6 +63,159 @@ func TestLoadOCIDocumentError(t *testing.T) {
 	}
 }
 
+func TestParseOCIDocument(t *testing.T) {
+	policyDoc := dummyOCIPolicyDocument()
+	policyJSON, err := json.Marshal(policyDoc)
+	if err != nil {
+		t.Fatalf("failed to marshal policy document: %v", err)
+	}
+
+	t.Run("valid document", func(t *testing.T) {
+		got, err := ParseOCIDocument(policyJSON)
+		if err != nil {
+			t.Fatalf("ParseOCIDocument() error = %v", err)
+		}
+		if !reflect.DeepEqual(*got, policyDoc) {
+			t.Fatalf("ParseOCIDocument() = %+v, want %+v", *got, policyDoc)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name:    "unknown field",
+			data:    `{"version":"1.0","trustPolicie":[]}`,
+			wantErr: `malformed trust policy: json: unknown field "trustPolicie"`,
+		},
+		{
+			name:    "trailing data",
+			data:    string(policyJSON) + `{}`,
+			wantErr: "malformed trust policy: unexpected data after the trust policy document",
+		},
+		{
+			name:    "invalid json",
+			data:    `{`,
+			wantErr: "malformed trust policy: unexpected EOF",
+		},
+		{
+			name:    "invalid document",
+			data:    `{"version":"2.0","trustPolicies":[]}`,
+			wantErr: `oci trust policy document uses unsupported version "2.0"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseOCIDocument([]byte(tt.data)); err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("ParseOCIDocument() error = %v, wantErr %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadMergedOCIDocument(t *testing.T) {
+	writePolicy := func(t *testing.T, root string, doc *OCIDocument) {
+		if doc == nil {
+			return
+		}
+		policyJson, _ := json.Marshal(doc)
+		if err := os.WriteFile(filepath.Join(root, "trustpolicy.oci.json"), policyJson, 0600); err != nil {
+			t.Fatalf("write policy file failed. Error: %v", err)
+		}
+	}
+	statement := func(name string, scopes ...string) OCITrustPolicy {
+		policy := dummyOCIPolicyDocument().TrustPolicies[0]
+		policy.Name = name
+		policy.RegistryScopes = scopes
+		return policy
+	}
+	document := func(statements ...OCITrustPolicy) *OCIDocument {
+		return &OCIDocument{Version: "1.0", TrustPolicies: statements}
+	}
+
+	tests := []struct {
+		name      string
+		systemDoc *OCIDocument
+		userDoc   *OCIDocument
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			name:    "no policy",
+			wantErr: errPolicyNotExist{}.Error(),
+		},
+		{
+			name:      "system level only",
+			systemDoc: document(statement("system", "registry.io/a")),
+			wantNames: []string{"system"},
+		},
+		{
+			name:      "user level only",
+			userDoc:   document(statement("user", "registry.io/a")),
+			wantNames: []string{"user"},
+		},
+		{
+			name:      "disjoint statements",
+			systemDoc: document(statement("system", "registry.io/a")),
+			userDoc:   document(statement("user", "registry.io/b")),
+			wantNames: []string{"user", "system"},
+		},
+		{
+			name:      "user level overrides system level",
+			systemDoc: document(statement("system", "registry.io/a", "registry.io/b"), statement("system-wildcard", "*")),
+			userDoc:   document(statement("user-a", "registry.io/a"), statement("user-b", "registry.io/b"), statement("user-wildcard", "*")),
+			wantNames: []string{"user-a", "user-b", "user-wildcard"},
+		},
+		{
+			name:      "partially overridden statement",
+			systemDoc: document(statement("system", "registry.io/a", "registry.io/b")),
+			userDoc:   document(statement("user", "registry.io/a")),
+			wantErr:   `system level trust policy statement "system" is partially overridden by user level trust policy statements ["user"], registry scopes must be either fully or not overridden`,
+		},
+		{
+			name:      "statement name conflict",
+			systemDoc: document(statement("shared", "registry.io/a")),
+			userDoc:   document(statement("shared", "registry.io/b")),
+			wantErr:   `system level trust policy statement "shared" conflicts with the user level trust policy statement of the same name`,
+		},
+		{
+			name:      "version mismatch",
+			systemDoc: &OCIDocument{Version: "0.9", TrustPolicies: []OCITrustPolicy{statement("system", "registry.io/a")}},
+			userDoc:   document(statement("user", "registry.io/b")),
+			wantErr:   `system level trust policy version "0.9" does not match user level trust policy version "1.0"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir.SystemConfigDir = t.TempDir()
+			dir.UserConfigDir = t.TempDir()
+			t.Cleanup(func() { dir.SystemConfigDir = "" })
+			writePolicy(t, dir.SystemConfigDir, tt.systemDoc)
+			writePolicy(t, dir.UserConfigDir, tt.userDoc)
+
+			doc, err := LoadMergedOCIDocument()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("LoadMergedOCIDocument() expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadMergedOCIDocument() failed. Error: %v", err)
+			}
+			var gotNames []string
+			for _, policy := range doc.TrustPolicies {
+				gotNames = append(gotNames, policy.Name)
+			}
+			if !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Fatalf("LoadMergedOCIDocument() got statements %v, want %v", gotNames, tt.wantNames)
+			}
+		})
+	}
+}
+
 // TestApplicableTrustPolicy tests filtering policies against registry scopes
 func TestApplicableTrustPolicy(t *testing.T) {
 	policyDoc := dummyOCIPolicyDocument()
@@ -106,6 +261,151 @@ func TestApplicableTrustPolicy(t *testing.T) {
 	}
 }
 
+// TestApplicableTrustPolicyRepositoryPrefix tests filtering policies against
+// repository prefix registry scopes
+func TestApplicableTrustPolicyRepositoryPrefix(t *testing.T) {
+	statement := func(name string, scopes ...string) OCITrustPolicy {
+		return OCITrustPolicy{
+			Name:                  name,
+			SignatureVerification: SignatureVerification{VerificationLevel: "skip"},
+			RegistryScopes:        scopes,
+		}
+	}
+	policyDoc := OCIDocument{
+		Version: "1.0",
+		TrustPolicies: []OCITrustPolicy{
+			statement("registry", "registry.wabbit-networks.io/*"),
+			statement("registry-with-port", "registry.wabbit-networks.io:5000/*"),
+			statement("team", "registry.wabbit-networks.io/software/*", "registry.acme-rockets.io/software/*"),
+			statement("team-nested", "registry.wabbit-networks.io/software/unsigned/*"),
+			statement("exact", "registry.wabbit-networks.io/software/unsigned/net-utils"),
+			statement("global", "*"),
+		},
+	}
+	if err := policyDoc.Validate(); err != nil {
+		t.Fatalf("Validate() returned unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		artifact string
+		want     string
+	}{
+		{artifact: "registry.wabbit-networks.io/net-utils", want: "registry"},
+		{artifact: "registry.wabbit-networks.io:5000/software/net-utils", want: "registry-with-port"},
+		{artifact: "registry.wabbit-networks.io:5001/software/net-utils", want: "global"},
+		{artifact: "registry.wabbit-networks.io/software/net-utils", want: "team"},
+		{artifact: "registry.acme-rockets.io/software/a/b/c/net-utils", want: "team"},
+		{artifact: "registry.wabbit-networks.io/software-a/net-utils", want: "registry"},
+		{artifact: "registry.wabbit-networks.io/software/unsigned/a/net-utils", want: "team-nested"},
+		{artifact: "registry.wabbit-networks.io/software/unsigned/net-utils", want: "exact"},
+		{artifact: "registry.wabbit-networks.io/software/unsigned", want: "team"},
+		{artifact: "registry.acme-rockets.io/net-utils", want: "global"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.artifact, func(t *testing.T) {
+			policy, err := policyDoc.GetApplicableTrustPolicy(tt.artifact + "@sha256:hash")
+			if err != nil {
+				t.Fatalf("GetApplicableTrustPolicy() returned unexpected error: %v", err)
+			}
+			if policy.Name != tt.want {
+				t.Fatalf("GetApplicableTrustPolicy() = %q, want %q", policy.Name, tt.want)
+			}
+		})
+	}
+
+	// without the global statement
+	policyDoc.TrustPolicies = policyDoc.TrustPolicies[:len(policyDoc.TrustPolicies)-1]
+	if _, err := policyDoc.GetApplicableTrustPolicy("registry.acme-rockets.io/net-utils@sha256:hash"); err == nil {
+		t.Fatal("GetApplicableTrustPolicy() should return error for artifact without applicable registry scope")
+	}
+
+	// duplicated repository prefix
+	policyDoc.TrustPolicies = append(policyDoc.TrustPolicies, statement("duplicate", "registry.wabbit-networks.io/software/*"))
+	expectedErr := "registry scope \"registry.wabbit-networks.io/software/*\" is present in multiple oci trust policy statements, one registry scope value can only be associated with one statement"
+	if err := policyDoc.Validate(); err == nil || err.Error() != expectedErr {
+		t.Fatalf("Validate() expected error %q, got %v", expectedErr, err)
+	}
+}
+
+func TestResolveStatement(t *testing.T) {
+	exact := dummyOCIPolicyDocument().TrustPolicies[0]
+	exact.Name = "exact"
+	exact.RegistryScopes = []string{"registry.wabbit-networks.io/software/net-utils"}
+	wildcard := OCITrustPolicy{
+		Name:                  "wildcard",
+		SignatureVerification: SignatureVerification{VerificationLevel: "skip"},
+		RegistryScopes:        []string{"*"},
+	}
+	otherWildcard := wildcard
+	otherWildcard.Name = "other-wildcard"
+	duplicate := exact
+	duplicate.Name = "duplicate"
+
+	tests := []struct {
+		name       string
+		statements []OCITrustPolicy
+		reference  string
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "exact scope",
+			statements: []OCITrustPolicy{wildcard, exact},
+			reference:  "registry.wabbit-networks.io/software/net-utils@sha256:hash",
+			want:       "exact",
+		},
+		{
+			name:       "wildcard scope",
+			statements: []OCITrustPolicy{wildcard, exact},
+			reference:  "registry.wabbit-networks.io/software/net-monitor@sha256:hash",
+			want:       "wildcard",
+		},
+		{
+			name:       "no applicable statement",
+			statements: []OCITrustPolicy{exact},
+			reference:  "registry.wabbit-networks.io/software/net-monitor@sha256:hash",
+			wantErr:    "artifact \"registry.wabbit-networks.io/software/net-monitor@sha256:hash\" has no applicable oci trust policy statement. Trust policy applicability for a given artifact is determined by registryScopes. To create a trust policy, see: https://notaryproject.dev/docs/quickstart/#create-a-trust-policy",
+		},
+		{
+			name:       "ambiguous registry scope",
+			statements: []OCITrustPolicy{exact, duplicate},
+			reference:  "registry.wabbit-networks.io/software/net-utils@sha256:hash",
+			wantErr:    "registry scope \"registry.wabbit-networks.io/software/net-utils\" is present in multiple oci trust policy statements, one registry scope value can only be associated with one statement",
+		},
+		{
+			name:       "ambiguous wildcard scope",
+			statements: []OCITrustPolicy{wildcard, otherWildcard},
+			reference:  "registry.wabbit-networks.io/software/net-utils@sha256:hash",
+			wantErr:    "registry scope \"*\" is present in multiple oci trust policy statements, one registry scope value can only be associated with one statement",
+		},
+		{
+			name:       "invalid reference",
+			statements: []OCITrustPolicy{wildcard},
+			reference:  "invalid reference",
+			wantErr:    "artifact URI \"invalid reference\" could not be parsed, make sure it is the fully qualified oci artifact URI without the scheme/protocol. e.g domain.com:80/my/repository@sha256:digest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policyDoc := dummyOCIPolicyDocument()
+			policyDoc.TrustPolicies = tt.statements
+			got, err := policyDoc.ResolveStatement(tt.reference)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("ResolveStatement() expects error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveStatement() error = %v", err)
+			}
+			if got.Name != tt.want {
+				t.Fatalf("ResolveStatement() = %q, want %q", got.Name, tt.want)
+			}
+		})
+	}
+}
+
 // TestValidatePolicyDocument calls policyDoc.Validate()
 // and tests various validations on policy elements
 func TestValidateInvalidPolicyDocument(t *testing.T) {
@@ -184,6 +484,24 @@ func TestValidateInvalidPolicyDocument(t *testing.T) {
 		t.Fatalf("expected %s, but got %s", expectedErrMsg, err)
 	}
 
+	// Invalid SignatureVerification AllowedSignatureAlgorithms
+	policyDoc = dummyOCIPolicyDocument()
+	policyDoc.TrustPolicies[0].SignatureVerification.AllowedSignatureAlgorithms = []string{"ECDSA-SHA-384", "RSA-SHA-1"}
+	expectedErrMsg = "oci trust policy: trust policy statement \"test-statement-name\" has invalid signatureVerification: allowedSignatureAlgorithms contains unsupported signature algorithm \"RSA-SHA-1\", supported values are [\"RSASSA-PSS-SHA-256\" \"RSASSA-PSS-SHA-384\" \"RSASSA-PSS-SHA-512\" \"ECDSA-SHA-256\" \"ECDSA-SHA-384\" \"ECDSA-SHA-512\"]"
+	err = policyDoc.Validate()
+	if err == nil || err.Error() != expectedErrMsg {
+		t.Fatalf("expected %s, but got %s", expectedErrMsg, err)
+	}
+
+	// Invalid SignatureVerification RequiredExtKeyUsages
+	policyDoc = dummyOCIPolicyDocument()
+	policyDoc.TrustPolicies[0].SignatureVerification.RequiredExtKeyUsages = []string{"1.3.6.1.5.5.7.3.3", "codeSigning"}
+	expectedErrMsg = "oci trust policy: trust policy statement \"test-statement-name\" has invalid signatureVerification: requiredExtKeyUsages contains \"codeSigning\" which is not an object identifier in dotted decimal notation"
+	err = policyDoc.Validate()
+	if err == nil || err.Error() != expectedErrMsg {
+		t.Fatalf("expected %s, but got %s", expectedErrMsg, err)
+	}
+
 	// strict SignatureVerification should have a trust store
 	policyDoc = dummyOCIPolicyDocument()
 	policyDoc.TrustPolicies[0].TrustStores = []string{}
@@ -292,6 +610,8 @@ func TestValidRegistryScopes(t *testing.T) {
 	validScopes := []string{
 		"*", "example.com/rep", "example.com:8080/rep/rep2", "example.com/rep/subrep/subsub",
 		"10.10.10.10:8080/rep/rep2", "domain/rep", "domain:1234/rep",
+		"example.com/*", "example.com:5000/*", "example.com:5000/team/*",
+		"example.com/rep/subrep/*", "10.10.10.10:8080/*", "domain/rep/*",
 	}
 
 	for _, scope := range validScopes {
@@ -321,7 +641,11 @@ func TestInvalidRegistryScopes(t *testing.T) {
 	}
 
 	// Test invalid scope with wild card suffix
-	invalidWildCardScopes := []string{"example.com/*", "*/", "example*/", "ex*test"}
+	invalidWildCardScopes := []string{
+		"*/", "example*/", "ex*test", "/*", "example.com/*/rep", "example.com/rep*",
+		"example.com/*/*", "*.example.com/rep/*", "example.com//*", "example.com/Rep/*",
+		"example.com:port/*",
+	}
 	for _, scope := range invalidWildCardScopes {
 		policyDoc.TrustPolicies[0].RegistryScopes = []string{scope}
 		err := policyDoc.Validate()
@@ -392,3 +716,47 @@ func TestValidateValidPolicyDocument(t *testing.T) {
 		t.Fatalf("validation failed on a good policy document. Error : %q", err)
 	}
 }
+
+func TestLintOCIDocument(t *testing.T) {
+	trustStoreRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(trustStoreRoot, dir.X509TrustStoreDir("ca", "valid-trust-store")), 0700); err != nil {
+		t.Fatal(err)
+	}
+	trustStoreFS := dir.NewSysFS(trustStoreRoot)
+
+	t.Run("valid policy document", func(t *testing.T) {
+		policyDoc := dummyOCIPolicyDocument()
+		policyDoc.TrustPolicies[0].TrustStores = []string{"ca:valid-trust-store"}
+		if err := policyDoc.Lint(trustStoreFS); err != nil {
+			t.Fatalf("Lint() returned error on a good policy document: %v", err)
+		}
+	})
+
+	t.Run("nil trust store fs", func(t *testing.T) {
+		policyDoc := dummyOCIPolicyDocument()
+		if err := policyDoc.Lint(nil); err != nil {
+			t.Fatalf("Lint() should not check trust store existence with nil fs: %v", err)
+		}
+	})
+
+	t.Run("aggregated errors", func(t *testing.T) {
+		policyDoc := dummyOCIPolicyDocument()
+		policyStatement := policyDoc.TrustPolicies[0].clone()
+		policyStatement.SignatureVerification = SignatureVerification{VerificationLevel: "invalid"}
+		policyDoc.TrustPolicies = append(policyDoc.TrustPolicies, *policyStatement)
+		err := policyDoc.Lint(trustStoreFS)
+		if err == nil {
+			t.Fatal("Lint() should return error")
+		}
+		expectedErrs := []string{
+			"multiple oci trust policy statements use the same name \"test-statement-name\", statement names must be unique",
+			"registry scope \"registry.acme-rockets.io/software/net-monitor\" is present in multiple oci trust policy statements, one registry scope value can only be associated with one statement",
+			"trust policy statement \"test-statement-name\" references trust store \"signingAuthority:valid-trust-store\" which does not exist",
+		}
+		for _, expected := range expectedErrs {
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("Lint() error %q should contain %q", err, expected)
+			}
+		}
+	})
+}
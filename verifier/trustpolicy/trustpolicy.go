@@ -16,11 +16,13 @@
 package trustpolicy
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/notaryproject/notation-go/dir"
@@ -143,6 +145,21 @@ var (
 		LevelAudit,
 		LevelSkip,
 	}
+
+	// SignatureAlgorithms are the signature algorithms that can be listed in
+	// SignatureVerification.AllowedSignatureAlgorithms.
+	SignatureAlgorithms = []string{
+		"RSASSA-PSS-SHA-256",
+		"RSASSA-PSS-SHA-384",
+		"RSASSA-PSS-SHA-512",
+		"ECDSA-SHA-256",
+		"ECDSA-SHA-384",
+		"ECDSA-SHA-512",
+	}
+
+	// objectIdentifierRegexp matches an object identifier in dotted decimal
+	// notation, e.g. "1.3.6.1.5.5.7.3.3".
+	objectIdentifierRegexp = regexp.MustCompile(`^[0-2](\.(0|[1-9][0-9]*))+$`)
 )
 
 // SignatureVerification represents verification configuration in a trust policy
@@ -150,6 +167,19 @@ type SignatureVerification struct {
 	VerificationLevel string                              `json:"level"`
 	Override          map[ValidationType]ValidationAction `json:"override,omitempty"`
 	VerifyTimestamp   TimestampOption                     `json:"verifyTimestamp,omitempty"`
+
+	// AllowedSignatureAlgorithms restricts the signature algorithms of the
+	// signatures passing the authenticity check, e.g. to deprecate an
+	// algorithm over time. If empty, all the supported signature algorithms
+	// are allowed.
+	AllowedSignatureAlgorithms []string `json:"allowedSignatureAlgorithms,omitempty"`
+
+	// RequiredExtKeyUsages lists the object identifiers, in dotted decimal
+	// notation, of the extended key usages that the signing certificate must
+	// contain, e.g. an organization specific OID. They are enforced in
+	// addition to the code signing requirements of the Notary Project
+	// certificate profile.
+	RequiredExtKeyUsages []string `json:"requiredExtKeyUsages,omitempty"`
 }
 
 type errPolicyNotExist struct{}
@@ -226,8 +256,27 @@ func (signatureVerification *SignatureVerification) GetVerificationLevel() (*Ver
 	return customVerificationLevel, nil
 }
 
+// parseDocument strictly decodes the trust policy document data into v,
+// rejecting unknown fields, e.g. misspelled ones, and trailing data.
+func parseDocument(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("malformed trust policy: %w", err)
+	}
+	if decoder.More() {
+		return errors.New("malformed trust policy: unexpected data after the trust policy document")
+	}
+	return nil
+}
+
 func getDocument(path string, v any) error {
-	path, err := dir.ConfigFS().SysPath(path)
+	return getDocumentFromFS(dir.ConfigFS(), path, v)
+}
+
+// getDocumentFromFS reads the trust policy document at path of fsys into v.
+func getDocumentFromFS(fsys dir.SysFS, path string, v any) error {
+	path, err := fsys.SysPath(path)
 	if err != nil {
 		return err
 	}
@@ -276,6 +325,16 @@ func validatePolicyCore(name string, signatureVerification SignatureVerification
 		signatureVerification.VerifyTimestamp != OptionAfterCertExpiry {
 		return fmt.Errorf("trust policy statement %q has invalid signatureVerification: verifyTimestamp must be %q or %q, but got %q", name, OptionAlways, OptionAfterCertExpiry, signatureVerification.VerifyTimestamp)
 	}
+	for _, alg := range signatureVerification.AllowedSignatureAlgorithms {
+		if !slices.Contains(SignatureAlgorithms, alg) {
+			return fmt.Errorf("trust policy statement %q has invalid signatureVerification: allowedSignatureAlgorithms contains unsupported signature algorithm %q, supported values are %q", name, alg, SignatureAlgorithms)
+		}
+	}
+	for _, eku := range signatureVerification.RequiredExtKeyUsages {
+		if !objectIdentifierRegexp.MatchString(eku) {
+			return fmt.Errorf("trust policy statement %q has invalid signatureVerification: requiredExtKeyUsages contains %q which is not an object identifier in dotted decimal notation", name, eku)
+		}
+	}
 
 	// Any signature verification other than "skip" needs a trust store and
 	// trusted identities
@@ -301,9 +360,39 @@ func validatePolicyCore(name string, signatureVerification SignatureVerification
 	return nil
 }
 
+// validateTrustStoresExist returns an error for every trust store referenced
+// by the policy statement that does not exist in trustStoreFS
+func validateTrustStoresExist(trustStoreFS dir.SysFS, policyName string, trustStores []string) []error {
+	var errs []error
+	for _, trustStore := range trustStores {
+		storeType, namedStore, found := strings.Cut(trustStore, ":")
+		if !found || !file.IsValidFileName(namedStore) {
+			// malformed trust store values are reported by validateTrustStore
+			continue
+		}
+		fileInfo, err := fs.Stat(trustStoreFS, dir.X509TrustStoreDir(storeType, namedStore))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("trust policy statement %q references trust store %q which does not exist", policyName, trustStore))
+				continue
+			}
+			errs = append(errs, fmt.Errorf("trust policy statement %q references trust store %q which cannot be accessed: %w", policyName, trustStore, err))
+			continue
+		}
+		if !fileInfo.IsDir() {
+			errs = append(errs, fmt.Errorf("trust policy statement %q references trust store %q which is not a directory", policyName, trustStore))
+		}
+	}
+	return errs
+}
+
 // validateTrustStore validates if the policy statement is following the
-// Notary Project spec rules for truststore
+// Notary Project spec rules for truststore.
+// Authenticity is verified against the trust stores of type ca or
+// signingAuthority, and timestamps against the ones of type tsa, so at least
+// one trust store of type ca or signingAuthority is required.
 func validateTrustStore(policyName string, trustStores []string) error {
+	var hasSigningTrustStore bool
 	for _, trustStore := range trustStores {
 		storeType, namedStore, found := strings.Cut(trustStore, ":")
 		if !found {
@@ -315,6 +404,12 @@ func validateTrustStore(policyName string, trustStores []string) error {
 		if !file.IsValidFileName(namedStore) {
 			return fmt.Errorf("trust policy statement %q uses an unsupported trust store name %q in trust store value %q. Named store name needs to follow [a-zA-Z0-9_.-]+ format", policyName, namedStore, trustStore)
 		}
+		if storeType != string(truststore.TypeTSA) {
+			hasSigningTrustStore = true
+		}
+	}
+	if !hasSigningTrustStore {
+		return fmt.Errorf("trust policy statement %q only uses trust stores of type %q, which are used to verify timestamps. A trust store of type %q or %q is required to verify the authenticity of signatures", policyName, truststore.TypeTSA, truststore.TypeCA, truststore.TypeSigningAuthority)
 	}
 	return nil
 }
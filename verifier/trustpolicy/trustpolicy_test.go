@@ -134,6 +134,15 @@ func TestValidateTrustStore(t *testing.T) {
 	if err := validateTrustStore("test-statement-name", []string{"ca:#@$@$"}); err == nil || err.Error() != expectedErr {
 		t.Errorf("expected error '%s' but not found", expectedErr)
 	}
+
+	// tsa trust stores only
+	expectedErr = "trust policy statement \"test-statement-name\" only uses trust stores of type \"tsa\", which are used to verify timestamps. A trust store of type \"ca\" or \"signingAuthority\" is required to verify the authenticity of signatures"
+	if err := validateTrustStore("test-statement-name", []string{"tsa:my-tsa"}); err == nil || err.Error() != expectedErr {
+		t.Errorf("expected error '%s' but not found", expectedErr)
+	}
+	if err := validateTrustStore("test-statement-name", []string{"tsa:my-tsa", "signingAuthority:my-ts"}); err != nil {
+		t.Errorf("validateTrustStore returned error: '%v", err)
+	}
 }
 
 // TestValidateTrustedIdentities tests only valid x509.subjects are accepted
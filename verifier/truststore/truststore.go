@@ -17,16 +17,22 @@ package truststore
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	corex509 "github.com/notaryproject/notation-core-go/x509"
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/internal/file"
+	"github.com/notaryproject/notation-go/internal/pkcs7"
 	"github.com/notaryproject/notation-go/internal/slices"
 )
 
@@ -47,7 +53,13 @@ var (
 	}
 )
 
-// X509TrustStore provides list and get behaviors for the trust store
+// X509TrustStore provides list and get behaviors for the trust store.
+//
+// The trust store holds the trust anchors only. Intermediate certificates are
+// not looked up from the trust store: the certificate chain of a signature,
+// from the signing certificate up to the root certificate, must be complete
+// in the signature envelope, which is enforced by the envelope integrity
+// check as required by the Notary Project signature specification.
 type X509TrustStore interface {
 	// GetCertificates returns certificates under storeType/namedStore
 	GetCertificates(ctx context.Context, storeType Type, namedStore string) ([]*x509.Certificate, error)
@@ -63,7 +75,10 @@ type x509TrustStore struct {
 	trustStorefs dir.SysFS
 }
 
-// GetCertificates returns certificates under storeType/namedStore
+// GetCertificates returns certificates under storeType/namedStore.
+// Once ctx is done, e.g. because reading a slow network file system exceeds
+// the deadline of ctx, the certificate files left are not read, and a
+// TrustStoreError wrapping the context error is returned.
 func (trustStore *x509TrustStore) GetCertificates(ctx context.Context, storeType Type, namedStore string) ([]*x509.Certificate, error) {
 	if !isValidStoreType(storeType) {
 		return nil, TrustStoreError{Msg: fmt.Sprintf("unsupported trust store type: %s", storeType)}
@@ -94,13 +109,19 @@ func (trustStore *x509TrustStore) GetCertificates(ctx context.Context, storeType
 
 	var certificates []*x509.Certificate
 	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, TrustStoreError{InnerError: err, Msg: fmt.Sprintf("interrupted loading the trust store %q of type %q: %v", namedStore, storeType, err)}
+		}
 		certFileName := file.Name()
 		joinedPath := filepath.Join(path, certFileName)
 		if file.IsDir() || file.Type()&fs.ModeSymlink != 0 {
 			return nil, CertificateError{Msg: fmt.Sprintf("trusted certificate %s in trust store %s of type %s is not a regular file (directories or symlinks are not supported)", certFileName, namedStore, storeType)}
 		}
-		certs, err := corex509.ReadCertificateFile(joinedPath)
+		certs, err := readCertificateFile(joinedPath)
 		if err != nil {
+			if errors.Is(err, errUnrecognizedFormat) {
+				return nil, CertificateError{InnerError: err, Msg: fmt.Sprintf("the trusted certificate %s in trust store %s of type %s is not in a recognized format, it must be PEM or DER encoded X.509 certificates or a PKCS #7 certificate bundle", certFileName, namedStore, storeType)}
+			}
 			return nil, CertificateError{InnerError: err, Msg: fmt.Sprintf("failed to read the trusted certificate %s in trust store %s of type %s", certFileName, namedStore, storeType)}
 		}
 		if err := ValidateCertificates(certs); err != nil {
@@ -122,6 +143,158 @@ func (trustStore *x509TrustStore) GetCertificates(ctx context.Context, storeType
 	return certificates, nil
 }
 
+// NewX509TrustStoreFromCertificates returns an [X509TrustStore] serving the
+// given certificates from memory instead of the trust store directory, e.g.
+// for applications distributed with pinned root certificates.
+// stores is keyed by the trust store reference as used in trust policies,
+// i.e. "{type}:{name}" such as "ca:acme-rootcerts". The certificates are
+// validated as the ones read from the trust store directory.
+func NewX509TrustStoreFromCertificates(stores map[string][]*x509.Certificate) (X509TrustStore, error) {
+	trustStore := &certificatesTrustStore{
+		stores: make(map[Type]map[string][]*x509.Certificate),
+	}
+	for reference, certs := range stores {
+		storeType, namedStore, found := strings.Cut(reference, ":")
+		if !found {
+			return nil, TrustStoreError{Msg: fmt.Sprintf("trust store reference %q must be of the format {type}:{name}", reference)}
+		}
+		if !isValidStoreType(Type(storeType)) {
+			return nil, TrustStoreError{Msg: fmt.Sprintf("unsupported trust store type: %s", storeType)}
+		}
+		if !file.IsValidFileName(namedStore) {
+			return nil, TrustStoreError{Msg: fmt.Sprintf("trust store name needs to follow [a-zA-Z0-9_.-]+ format, %s is invalid", namedStore)}
+		}
+		if err := ValidateCertificates(certs); err != nil {
+			return nil, CertificateError{InnerError: err, Msg: fmt.Sprintf("failed to validate the trusted certificates in trust store %s of type %s: %v", namedStore, storeType, err)}
+		}
+		// we require TSA certificates in trust store to be root CA certificates
+		if Type(storeType) == TypeTSA {
+			for _, cert := range certs {
+				if err := isRootCACertificate(cert); err != nil {
+					return nil, CertificateError{InnerError: err, Msg: fmt.Sprintf("trusted certificate in trust store %s of type %s is invalid: %v", namedStore, storeType, err)}
+				}
+			}
+		}
+		if trustStore.stores[Type(storeType)] == nil {
+			trustStore.stores[Type(storeType)] = make(map[string][]*x509.Certificate)
+		}
+		trustStore.stores[Type(storeType)][namedStore] = append([]*x509.Certificate(nil), certs...)
+	}
+	return trustStore, nil
+}
+
+// certificatesTrustStore implements [X509TrustStore] with certificates held
+// in memory.
+type certificatesTrustStore struct {
+	stores map[Type]map[string][]*x509.Certificate
+}
+
+// GetCertificates returns certificates under storeType/namedStore
+func (trustStore *certificatesTrustStore) GetCertificates(_ context.Context, storeType Type, namedStore string) ([]*x509.Certificate, error) {
+	if !isValidStoreType(storeType) {
+		return nil, TrustStoreError{Msg: fmt.Sprintf("unsupported trust store type: %s", storeType)}
+	}
+	certs, ok := trustStore.stores[storeType][namedStore]
+	if !ok {
+		return nil, TrustStoreError{InnerError: fs.ErrNotExist, Msg: fmt.Sprintf("the trust store %q of type %q does not exist", namedStore, storeType)}
+	}
+	return append([]*x509.Certificate(nil), certs...), nil
+}
+
+// LoadX509TrustStores loads all the named trust stores of type storeType in
+// trustStorefs in one pass, and returns their certificates keyed by the trust
+// store name.
+// A trust store that fails to load does not stop the others from being
+// loaded: it is left out of the returned map, and its error is joined into
+// the returned error.
+// If there is no trust store of type storeType, an empty map is returned.
+// Once ctx is done, loading stops and the context error is returned.
+func LoadX509TrustStores(ctx context.Context, trustStorefs dir.SysFS, storeType Type) (map[string][]*x509.Certificate, error) {
+	if !isValidStoreType(storeType) {
+		return nil, TrustStoreError{Msg: fmt.Sprintf("unsupported trust store type: %s", storeType)}
+	}
+	entries, err := fs.ReadDir(trustStorefs, dir.X509TrustStoreDir(string(storeType)))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return map[string][]*x509.Certificate{}, nil
+		}
+		return nil, TrustStoreError{InnerError: err, Msg: fmt.Sprintf("failed to access the trust stores of type %q", storeType)}
+	}
+
+	trustStore := NewX509TrustStore(trustStorefs)
+	stores := make(map[string][]*x509.Certificate)
+	var errs []error
+	for _, entry := range entries {
+		if !entry.IsDir() && entry.Type()&fs.ModeSymlink == 0 {
+			// not a named store
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, TrustStoreError{InnerError: err, Msg: fmt.Sprintf("interrupted loading the trust stores of type %q: %v", storeType, err)}
+		}
+		certs, err := trustStore.GetCertificates(ctx, storeType, entry.Name())
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, TrustStoreError{InnerError: ctxErr, Msg: fmt.Sprintf("interrupted loading the trust stores of type %q: %v", storeType, ctxErr)}
+			}
+			errs = append(errs, err)
+			continue
+		}
+		stores[entry.Name()] = certs
+	}
+	return stores, errors.Join(errs...)
+}
+
+// TrustStoreCertInfo describes a certificate of a trust store for auditing.
+type TrustStoreCertInfo struct {
+	// Subject is the subject of the certificate.
+	Subject string
+
+	// Issuer is the issuer of the certificate.
+	Issuer string
+
+	// SHA256Fingerprint is the lowercase hex encoded SHA-256 digest of the
+	// DER encoded certificate.
+	SHA256Fingerprint string
+
+	// NotBefore is the start of the validity period of the certificate.
+	NotBefore time.Time
+
+	// NotAfter is the end of the validity period of the certificate.
+	NotAfter time.Time
+}
+
+// Describe returns the description of each certificate of the trust store
+// namedStore of type storeType, in the order returned by
+// trustStore.GetCertificates.
+func Describe(ctx context.Context, trustStore X509TrustStore, storeType Type, namedStore string) ([]TrustStoreCertInfo, error) {
+	if trustStore == nil {
+		return nil, errors.New("trust store cannot be nil")
+	}
+	certs, err := trustStore.GetCertificates(ctx, storeType, namedStore)
+	if err != nil {
+		return nil, err
+	}
+	return DescribeCertificates(certs), nil
+}
+
+// DescribeCertificates returns the description of each certificate of certs,
+// in the same order.
+func DescribeCertificates(certs []*x509.Certificate) []TrustStoreCertInfo {
+	infos := make([]TrustStoreCertInfo, 0, len(certs))
+	for _, cert := range certs {
+		fingerprint := sha256.Sum256(cert.Raw)
+		infos = append(infos, TrustStoreCertInfo{
+			Subject:           cert.Subject.String(),
+			Issuer:            cert.Issuer.String(),
+			SHA256Fingerprint: hex.EncodeToString(fingerprint[:]),
+			NotBefore:         cert.NotBefore,
+			NotAfter:          cert.NotAfter,
+		})
+	}
+	return infos
+}
+
 // ValidateCertificates ensures certificates from trust store are
 // CA certificates or self-signed.
 func ValidateCertificates(certs []*x509.Certificate) error {
@@ -141,6 +314,99 @@ func ValidateCertificates(certs []*x509.Certificate) error {
 	return nil
 }
 
+// VerifyCertificateChain validates certChain, ordered from the leaf
+// certificate to the root certificate, and checks that it chains to a trusted
+// certificate of the trust store namedStore of type storeType.
+// The chain is validated as a code signing certificate chain for TypeCA and
+// TypeSigningAuthority, and as a timestamping certificate chain for TypeTSA,
+// the same way the verifier does. The validity period of the certificates is
+// not checked.
+// It is a diagnostic primitive that does not verify any signature envelope.
+func VerifyCertificateChain(ctx context.Context, certChain []*x509.Certificate, trustStore X509TrustStore, storeType Type, namedStore string) error {
+	if len(certChain) < 1 {
+		return errors.New("certificate chain cannot be empty")
+	}
+	if trustStore == nil {
+		return errors.New("trust store cannot be nil")
+	}
+
+	var err error
+	switch storeType {
+	case TypeCA, TypeSigningAuthority:
+		err = corex509.ValidateCodeSigningCertChain(certChain, nil)
+	case TypeTSA:
+		err = corex509.ValidateTimestampingCertChain(certChain)
+	default:
+		return TrustStoreError{Msg: fmt.Sprintf("unsupported trust store type: %s", storeType)}
+	}
+	if err != nil {
+		return CertificateError{InnerError: err, Msg: fmt.Sprintf("invalid certificate chain: %v", err)}
+	}
+
+	trustedCerts, err := trustStore.GetCertificates(ctx, storeType, namedStore)
+	if err != nil {
+		return err
+	}
+	for _, cert := range certChain {
+		for _, trustedCert := range trustedCerts {
+			if trustedCert.Equal(cert) {
+				return nil
+			}
+		}
+	}
+	return CertificateError{Msg: fmt.Sprintf("certificate chain with leaf certificate %q and root certificate %q does not chain to any trusted certificate in trust store %s of type %s", certChain[0].Subject, certChain[len(certChain)-1].Subject, namedStore, storeType)}
+}
+
+// errUnrecognizedFormat is returned by readCertificateFile if the file is
+// neither X.509 certificates nor a PKCS #7 certificate bundle.
+var errUnrecognizedFormat = errors.New("unrecognized certificate file format")
+
+// readCertificateFile reads the certificates of a file in PEM or DER format.
+// In addition to X.509 certificates, PKCS #7 certificate bundles, such as
+// .p7b files, are supported.
+func readCertificateFile(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, rest := pem.Decode(data)
+	if block == nil {
+		// data may be in DER format
+		certs, err := x509.ParseCertificates(data)
+		if err == nil {
+			return certs, nil
+		}
+		if certs, pkcs7Err := pkcs7.ParseCertificates(data); pkcs7Err == nil {
+			return certs, nil
+		}
+		return nil, fmt.Errorf("%w: %w", errUnrecognizedFormat, err)
+	}
+
+	// data is in PEM format
+	var certs []*x509.Certificate
+	for block != nil {
+		switch block.Type {
+		case "PKCS7":
+			bundle, err := pkcs7.ParseCertificates(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			certs = append(certs, bundle...)
+		default:
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				if block.Type != "CERTIFICATE" {
+					return nil, fmt.Errorf("%w: PEM block type %q", errUnrecognizedFormat, block.Type)
+				}
+				return nil, err
+			}
+			certs = append(certs, cert)
+		}
+		block, rest = pem.Decode(rest)
+	}
+	return certs, nil
+}
+
 // isValidStoreType checks if storeType is supported
 func isValidStoreType(storeType Type) bool {
 	return slices.Contains(Types, storeType)
@@ -15,11 +15,17 @@ package truststore
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io/fs"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/notaryproject/notation-core-go/testhelper"
 	corex509 "github.com/notaryproject/notation-core-go/x509"
 	"github.com/notaryproject/notation-go/dir"
 )
@@ -34,6 +40,21 @@ func TestLoadValidTrustStore(t *testing.T) {
 	}
 }
 
+// TestLoadTrustStoreCanceled tests that loading a trust store stops once the
+// context is done
+func TestLoadTrustStoreCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := trustStore.GetCertificates(ctx, "ca", "valid-trust-store")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	var trustStoreErr TrustStoreError
+	if !errors.As(err, &trustStoreErr) {
+		t.Fatalf("expected TrustStoreError, got %T", err)
+	}
+}
+
 // TestLoadValidTrustStoreWithSelfSignedSigningCertificate tests a valid trust store with self-signed signing certificate
 func TestLoadValidTrustStoreWithSelfSignedSigningCertificate(t *testing.T) {
 	certs, err := trustStore.GetCertificates(context.Background(), "ca", "valid-trust-store-self-signed")
@@ -47,13 +68,36 @@ func TestLoadValidTrustStoreWithSelfSignedSigningCertificate(t *testing.T) {
 
 func TestLoadTrustStoreWithInvalidCerts(t *testing.T) {
 	// testing ../testdata/truststore/x509/ca/trust-store-with-invalid-certs/invalid
-	expectedErr := fmt.Errorf("failed to read the trusted certificate %s in trust store %s of type %s", "invalid", "trust-store-with-invalid-certs", "ca")
+	expectedErr := fmt.Errorf("the trusted certificate %s in trust store %s of type %s is not in a recognized format, it must be PEM or DER encoded X.509 certificates or a PKCS #7 certificate bundle", "invalid", "trust-store-with-invalid-certs", "ca")
 	_, err := trustStore.GetCertificates(context.Background(), "ca", "trust-store-with-invalid-certs")
 	if err == nil || err.Error() != expectedErr.Error() {
 		t.Fatalf("invalid certs should return error: %q", expectedErr)
 	}
 }
 
+func TestLoadTrustStoreWithPKCS7Bundles(t *testing.T) {
+	// testing ../testdata/truststore/x509/ca/valid-trust-store-pkcs7
+	certs, err := trustStore.GetCertificates(context.Background(), "ca", "valid-trust-store-pkcs7")
+	if err != nil {
+		t.Fatalf("could not get certificates from trust store. %q", err)
+	}
+	if len(certs) != 3 {
+		t.Fatalf("unexpected number of certificates in the trust store, expected: %d, got: %d", 3, len(certs))
+	}
+}
+
+func TestLoadTrustStoreWithUnrecognizedFormat(t *testing.T) {
+	// testing ../testdata/truststore/x509/ca/trust-store-with-unrecognized-format/key.pem
+	expectedErrMsg := fmt.Sprintf("the trusted certificate %s in trust store %s of type %s is not in a recognized format, it must be PEM or DER encoded X.509 certificates or a PKCS #7 certificate bundle", "key.pem", "trust-store-with-unrecognized-format", "ca")
+	_, err := trustStore.GetCertificates(context.Background(), "ca", "trust-store-with-unrecognized-format")
+	if err == nil || err.Error() != expectedErrMsg {
+		t.Fatalf("unrecognized file in a trust store should return error: %s, got: %v", expectedErrMsg, err)
+	}
+	if !errors.Is(err, errUnrecognizedFormat) {
+		t.Fatalf("expected error to wrap %v, got %v", errUnrecognizedFormat, err)
+	}
+}
+
 func TestLoadTrustStoreWithLeafCerts(t *testing.T) {
 	// testing ../testdata/truststore/x509/ca/trust-store-with-leaf-certs/non-ca.crt
 	expectedErrMsg := fmt.Sprintf("failed to validate the trusted certificate %s in trust store %s of type %s", "non-ca.crt", "trust-store-with-leaf-certs", "ca")
@@ -126,3 +170,311 @@ func TestGetCertFromInvalidTsaTrustStore(t *testing.T) {
 		}
 	})
 }
+
+// memoryTrustStore returns the same certificates for any named store
+type memoryTrustStore []*x509.Certificate
+
+func (m memoryTrustStore) GetCertificates(_ context.Context, _ Type, namedStore string) ([]*x509.Certificate, error) {
+	if namedStore != "test" {
+		return nil, TrustStoreError{Msg: fmt.Sprintf("the trust store %q does not exist", namedStore)}
+	}
+	return m, nil
+}
+
+func TestNewX509TrustStoreFromCertificates(t *testing.T) {
+	caCerts, err := corex509.ReadCertificateFile(filepath.FromSlash("../testdata/truststore/x509/ca/valid-trust-store/GlobalSign.der"))
+	if err != nil {
+		t.Fatalf("failed to read the trusted certificates: %v", err)
+	}
+	leafCerts, err := corex509.ReadCertificateFile(filepath.FromSlash("../testdata/truststore/x509/ca/trust-store-with-leaf-certs/non-ca.crt"))
+	if err != nil {
+		t.Fatalf("failed to read the trusted certificates: %v", err)
+	}
+
+	t.Run("valid trust stores", func(t *testing.T) {
+		stores := map[string][]*x509.Certificate{
+			"ca:acme-rootcerts":  caCerts,
+			"tsa:acme-tsa-roots": caCerts,
+		}
+		ts, err := NewX509TrustStoreFromCertificates(stores)
+		if err != nil {
+			t.Fatalf("expected nil error, but got %v", err)
+		}
+		certs, err := ts.GetCertificates(context.Background(), TypeCA, "acme-rootcerts")
+		if err != nil {
+			t.Fatalf("expected nil error, but got %v", err)
+		}
+		if !reflect.DeepEqual(certs, caCerts) {
+			t.Fatalf("expected certificates %v, but got %v", caCerts, certs)
+		}
+		// the trust store must not be affected by changes to the input map
+		delete(stores, "tsa:acme-tsa-roots")
+		if _, err := ts.GetCertificates(context.Background(), TypeTSA, "acme-tsa-roots"); err != nil {
+			t.Fatalf("expected nil error, but got %v", err)
+		}
+	})
+
+	t.Run("missing trust store", func(t *testing.T) {
+		ts, err := NewX509TrustStoreFromCertificates(map[string][]*x509.Certificate{"ca:acme-rootcerts": caCerts})
+		if err != nil {
+			t.Fatalf("expected nil error, but got %v", err)
+		}
+		expectedErrMsg := `the trust store "acme-rootcerts" of type "signingAuthority" does not exist`
+		_, err = ts.GetCertificates(context.Background(), TypeSigningAuthority, "acme-rootcerts")
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, but got %v", expectedErrMsg, err)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("expected error to wrap %v, but got %v", fs.ErrNotExist, err)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		stores         map[string][]*x509.Certificate
+		expectedErrMsg string
+	}{
+		{
+			name:           "invalid reference",
+			stores:         map[string][]*x509.Certificate{"acme-rootcerts": caCerts},
+			expectedErrMsg: `trust store reference "acme-rootcerts" must be of the format {type}:{name}`,
+		},
+		{
+			name:           "invalid type",
+			stores:         map[string][]*x509.Certificate{"invalid:acme-rootcerts": caCerts},
+			expectedErrMsg: "unsupported trust store type: invalid",
+		},
+		{
+			name:           "invalid name",
+			stores:         map[string][]*x509.Certificate{"ca:acme/rootcerts": caCerts},
+			expectedErrMsg: "trust store name needs to follow [a-zA-Z0-9_.-]+ format, acme/rootcerts is invalid",
+		},
+		{
+			name:           "empty trust store",
+			stores:         map[string][]*x509.Certificate{"ca:acme-rootcerts": nil},
+			expectedErrMsg: "failed to validate the trusted certificates in trust store acme-rootcerts of type ca: input certs cannot be empty",
+		},
+		{
+			name:           "leaf certificate",
+			stores:         map[string][]*x509.Certificate{"ca:acme-rootcerts": leafCerts},
+			expectedErrMsg: `failed to validate the trusted certificates in trust store acme-rootcerts of type ca: certificate with subject "CN=wabbit-networks.io,O=Notary,L=Seattle,ST=WA,C=US" is not a CA certificate or self-signed signing certificate`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewX509TrustStoreFromCertificates(tt.stores)
+			if err == nil || err.Error() != tt.expectedErrMsg {
+				t.Fatalf("expected error %q, but got %v", tt.expectedErrMsg, err)
+			}
+		})
+	}
+}
+
+func TestVerifyCertificateChain(t *testing.T) {
+	root := testhelper.GetRSARootCertificate().Cert
+	leaf := testhelper.GetRSALeafCertificate().Cert
+	otherRoot := testhelper.GetECRootCertificate().Cert
+	chain := []*x509.Certificate{leaf, root}
+
+	tests := []struct {
+		name       string
+		certChain  []*x509.Certificate
+		trustStore X509TrustStore
+		storeType  Type
+		namedStore string
+		wantErr    string
+	}{
+		{
+			name:       "trusted chain",
+			certChain:  chain,
+			trustStore: memoryTrustStore{root},
+			storeType:  TypeCA,
+			namedStore: "test",
+		},
+		{
+			name:       "trusted chain in signing authority store",
+			certChain:  chain,
+			trustStore: memoryTrustStore{root},
+			storeType:  TypeSigningAuthority,
+			namedStore: "test",
+		},
+		{
+			name:       "empty chain",
+			trustStore: memoryTrustStore{root},
+			storeType:  TypeCA,
+			namedStore: "test",
+			wantErr:    "certificate chain cannot be empty",
+		},
+		{
+			name:       "nil trust store",
+			certChain:  chain,
+			storeType:  TypeCA,
+			namedStore: "test",
+			wantErr:    "trust store cannot be nil",
+		},
+		{
+			name:       "unsupported trust store type",
+			certChain:  chain,
+			trustStore: memoryTrustStore{root},
+			storeType:  "invalid",
+			namedStore: "test",
+			wantErr:    "unsupported trust store type: invalid",
+		},
+		{
+			name:       "broken chain",
+			certChain:  []*x509.Certificate{leaf, otherRoot},
+			trustStore: memoryTrustStore{root},
+			storeType:  TypeCA,
+			namedStore: "test",
+			wantErr:    "invalid certificate chain: ",
+		},
+		{
+			name:       "untrusted chain",
+			certChain:  chain,
+			trustStore: memoryTrustStore{otherRoot},
+			storeType:  TypeCA,
+			namedStore: "test",
+			wantErr:    fmt.Sprintf("certificate chain with leaf certificate %q and root certificate %q does not chain to any trusted certificate in trust store test of type ca", leaf.Subject, root.Subject),
+		},
+		{
+			name:       "trust store not found",
+			certChain:  chain,
+			trustStore: memoryTrustStore{root},
+			storeType:  TypeCA,
+			namedStore: "missing",
+			wantErr:    `the trust store "missing" does not exist`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyCertificateChain(context.Background(), tt.certChain, tt.trustStore, tt.storeType, tt.namedStore)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected nil error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error starting with %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadX509TrustStores(t *testing.T) {
+	trustStorefs := dir.NewSysFS(filepath.FromSlash("../testdata/"))
+
+	t.Run("all valid", func(t *testing.T) {
+		stores, err := LoadX509TrustStores(context.Background(), trustStorefs, TypeSigningAuthority)
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		for _, name := range []string{"valid-trust-store", "valid-trust-store-2"} {
+			if len(stores[name]) == 0 {
+				t.Fatalf("expected certificates of trust store %s to be loaded", name)
+			}
+		}
+		if len(stores) != 2 {
+			t.Fatalf("expected 2 trust stores, got %d", len(stores))
+		}
+	})
+
+	t.Run("collect errors", func(t *testing.T) {
+		stores, err := LoadX509TrustStores(context.Background(), trustStorefs, TypeCA)
+		if err == nil {
+			t.Fatal("expected error for invalid trust stores")
+		}
+		var certErr CertificateError
+		if !errors.As(err, &certErr) {
+			t.Fatalf("expected CertificateError, got %T", err)
+		}
+		if !strings.Contains(err.Error(), "trust-store-with-invalid-certs") || !strings.Contains(err.Error(), "trust-store-with-leaf-certs-in-single-file") {
+			t.Fatalf("expected errors of all invalid trust stores, got %v", err)
+		}
+		if len(stores["valid-trust-store"]) == 0 {
+			t.Fatal("expected valid trust stores to be loaded")
+		}
+		if _, ok := stores["trust-store-with-invalid-certs"]; ok {
+			t.Fatal("expected invalid trust store to be left out")
+		}
+	})
+
+	t.Run("no trust store", func(t *testing.T) {
+		stores, err := LoadX509TrustStores(context.Background(), dir.NewSysFS(t.TempDir()), TypeCA)
+		if err != nil || len(stores) != 0 {
+			t.Fatalf("expected empty result, got %v, %v", stores, err)
+		}
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := LoadX509TrustStores(ctx, trustStorefs, TypeSigningAuthority)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("unsupported trust store type", func(t *testing.T) {
+		_, err := LoadX509TrustStores(context.Background(), trustStorefs, "invalid")
+		if err == nil || err.Error() != "unsupported trust store type: invalid" {
+			t.Fatalf("expected unsupported trust store type error, got %v", err)
+		}
+	})
+}
+
+func TestDescribe(t *testing.T) {
+	t.Run("self-signed certificate", func(t *testing.T) {
+		// testing ../testdata/truststore/x509/ca/valid-trust-store-self-signed/openssl-minimum-self-signed.pem
+		infos, err := Describe(context.Background(), trustStore, TypeCA, "valid-trust-store-self-signed")
+		if err != nil {
+			t.Fatalf("expected nil error, got %v", err)
+		}
+		want := []TrustStoreCertInfo{{
+			Subject:           "OU=TestCert,O=Notation Test,L=Seattle,ST=Washington,C=US",
+			Issuer:            "OU=TestCert,O=Notation Test,L=Seattle,ST=Washington,C=US",
+			SHA256Fingerprint: "6b0cdfb0f87e61655c501ddad5ec0f0189af369004f69158604d5bc9210dd7ea",
+			NotBefore:         time.Date(2022, 9, 15, 6, 13, 13, 0, time.UTC),
+			NotAfter:          time.Date(2122, 8, 22, 6, 13, 13, 0, time.UTC),
+		}}
+		if !reflect.DeepEqual(infos, want) {
+			t.Fatalf("expected %+v, got %+v", want, infos)
+		}
+	})
+
+	t.Run("multiple certificates", func(t *testing.T) {
+		certs, err := trustStore.GetCertificates(context.Background(), TypeCA, "valid-trust-store")
+		if err != nil {
+			t.Fatalf("could not get certificates from trust store. %q", err)
+		}
+		infos := DescribeCertificates(certs)
+		if len(infos) != len(certs) {
+			t.Fatalf("expected %d descriptions, got %d", len(certs), len(infos))
+		}
+		for i, cert := range certs {
+			if infos[i].Subject != cert.Subject.String() || !infos[i].NotAfter.Equal(cert.NotAfter) {
+				t.Fatalf("description %d does not match certificate %q", i, cert.Subject)
+			}
+		}
+	})
+
+	t.Run("trust store error", func(t *testing.T) {
+		_, err := Describe(context.Background(), trustStore, TypeCA, "non-existing")
+		if err == nil || err.Error() != "the trust store \"non-existing\" of type \"ca\" does not exist" {
+			t.Fatalf("expected trust store not exist error, got %v", err)
+		}
+	})
+
+	t.Run("nil trust store", func(t *testing.T) {
+		_, err := Describe(context.Background(), nil, TypeCA, "valid-trust-store")
+		if err == nil || err.Error() != "trust store cannot be nil" {
+			t.Fatalf("expected nil trust store error, got %v", err)
+		}
+	})
+
+	t.Run("no certificates", func(t *testing.T) {
+		if infos := DescribeCertificates(nil); len(infos) != 0 {
+			t.Fatalf("expected empty descriptions, got %+v", infos)
+		}
+	})
+}
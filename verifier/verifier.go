@@ -19,31 +19,41 @@ import (
 	"context"
 	"crypto"
 	"crypto/x509"
+	"encoding/asn1"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"path"
+	"path/filepath"
 	"reflect"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/mod/semver"
-	"oras.land/oras-go/v2/content"
 
 	"github.com/notaryproject/notation-core-go/revocation"
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
 	"github.com/notaryproject/notation-core-go/revocation/purpose"
 	revocationresult "github.com/notaryproject/notation-core-go/revocation/result"
 	"github.com/notaryproject/notation-core-go/signature"
 	nx509 "github.com/notaryproject/notation-core-go/x509"
 	"github.com/notaryproject/notation-go"
 	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/internal/clock"
+	set "github.com/notaryproject/notation-go/internal/container"
 	"github.com/notaryproject/notation-go/internal/envelope"
 	"github.com/notaryproject/notation-go/internal/pkix"
 	notationsemver "github.com/notaryproject/notation-go/internal/semver"
 	"github.com/notaryproject/notation-go/internal/slices"
 	trustpolicyInternal "github.com/notaryproject/notation-go/internal/trustpolicy"
 	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation-go/metrics"
 	"github.com/notaryproject/notation-go/plugin"
+	"github.com/notaryproject/notation-go/plugin/proto"
+	"github.com/notaryproject/notation-go/verifier/crl"
 	"github.com/notaryproject/notation-go/verifier/trustpolicy"
 	"github.com/notaryproject/notation-go/verifier/truststore"
 	pluginframework "github.com/notaryproject/notation-plugin-framework-go/plugin"
@@ -52,6 +62,15 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
+// oidExtensionExtendedKeyUsage is the object identifier of the X.509 extended
+// key usage extension.
+var oidExtensionExtendedKeyUsage = asn1.ObjectIdentifier{2, 5, 29, 37}
+
+// defaultMaxClockSkew is the maximum clock skew allowed between the signing
+// time of a signature and the time of verification if
+// [VerifierOptions.MaxClockSkew] is not set.
+const defaultMaxClockSkew = 5 * time.Minute
+
 var algorithms = map[crypto.Hash]digest.Algorithm{
 	crypto.SHA256: digest.SHA256,
 	crypto.SHA384: digest.SHA384,
@@ -60,6 +79,10 @@ var algorithms = map[crypto.Hash]digest.Algorithm{
 
 // verifier implements [notation.Verifier], [notation.BlobVerifier] and
 // notation.verifySkipper interfaces.
+//
+// verifier is safe for concurrent use by multiple goroutines. Its trust
+// policies and options are fixed once created, while the certificates of its
+// trust stores can be swapped with UpdateTrustStore.
 type verifier struct {
 	ociTrustPolicyDoc               *trustpolicy.OCIDocument
 	blobTrustPolicyDoc              *trustpolicy.BlobDocument
@@ -68,6 +91,13 @@ type verifier struct {
 	revocationClient                revocation.Revocation
 	revocationCodeSigningValidator  revocation.Validator
 	revocationTimestampingValidator revocation.Validator
+	maxClockSkew                    time.Duration
+
+	// trustStoreOverrides holds the certificates of the trust stores
+	// replaced by UpdateTrustStore, keyed by "{type}:{name}". The map is
+	// copied on write, so that a verification reads a consistent snapshot.
+	trustStoreOverrides map[string][]*x509.Certificate
+	trustStoreLock      sync.Mutex
 }
 
 // VerifierOptions specifies additional parameters that can be set when using
@@ -97,6 +127,39 @@ type VerifierOptions struct {
 
 	// PluginManager manages plugins installed on the system.
 	PluginManager plugin.Manager
+
+	// PreloadTrustStores makes [NewVerifierWithOptions] load and validate all
+	// the trust stores referenced by the trust policy documents, so that a
+	// misconfigured trust store fails the creation of the verifier instead of
+	// the first verification. The trust stores are still read on each
+	// verification.
+	PreloadTrustStores bool
+
+	// CacheDir is the root directory of the caches of the verifier, in place
+	// of the process-wide {NOTATION_CACHE} directory, e.g. to isolate the
+	// cached revocation data of tenants from each other.
+	// If set, the CRLs downloaded by the default revocation validators are
+	// cached in its [dir.PathCRLCache] subdirectory. It does not apply to
+	// the validators set by RevocationCodeSigningValidator,
+	// RevocationTimestampingValidator or RevocationClient.
+	// If empty, the default revocation validators do not cache CRLs.
+	CacheDir string
+
+	// MaxClockSkew is the maximum duration by which the signing time of a
+	// signature may be ahead of the time of verification. A signature signed
+	// further in the future fails authenticity with a
+	// [notation.ErrorSigningTimeInFuture].
+	// If zero, a maximum clock skew of 5 minutes is allowed. A negative
+	// value is rejected.
+	MaxClockSkew time.Duration
+
+	// TrustedCertificates holds the trusted certificates of named trust stores
+	// in memory, keyed by the trust store reference as used in the trust
+	// policy documents, e.g. "ca:acme-rootcerts". If set, the trust stores
+	// are served from it instead of the trust store directory, and the
+	// trustStore argument of [NewVerifierWithOptions] must be nil.
+	// See [truststore.NewX509TrustStoreFromCertificates].
+	TrustedCertificates map[string][]*x509.Certificate
 }
 
 // NewOCIVerifierFromConfig returns an OCI verifier based on local file system
@@ -131,6 +194,33 @@ func NewBlobVerifierFromConfig() (*verifier, error) {
 	})
 }
 
+// VerifyWithTrustStoreDir verifies the signature envelope of the OCI artifact
+// described by artifactDesc in one call, e.g. for scripts and tests, with a
+// verifier reading the trust stores from trustStoreDir and applying policy.
+// trustStoreDir is laid out as the notation configuration directory, i.e. the
+// trusted certificates are read from
+// {trustStoreDir}/truststore/x509/{store-type}/{named-store}.
+// Verification plugins are looked up from the plugin directory of notation.
+//
+// For repeated verifications, create the verifier once with
+// [NewVerifierWithOptions] and use [notation.VerifySignature] instead.
+func VerifyWithTrustStoreDir(ctx context.Context, artifactDesc ocispec.Descriptor, envelope []byte, trustStoreDir string, policy *trustpolicy.Document, opts notation.VerifySignatureOptions) (*notation.VerificationOutcome, error) {
+	if trustStoreDir == "" {
+		return nil, errors.New("trustStoreDir cannot be empty")
+	}
+	if policy == nil {
+		return nil, errors.New("policy cannot be nil")
+	}
+	v, err := NewVerifierWithOptions(truststore.NewX509TrustStore(dir.NewSysFS(trustStoreDir)), VerifierOptions{
+		OCITrustPolicy: policy,
+		PluginManager:  plugin.NewCLIManager(dir.PluginFS()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return notation.VerifySignature(ctx, v, artifactDesc, envelope, opts)
+}
+
 // NewWithOptions creates a new verifier given ociTrustPolicy, trustStore,
 // pluginManager, and VerifierOptions.
 //
@@ -144,9 +234,27 @@ func NewWithOptions(ociTrustPolicy *trustpolicy.OCIDocument, trustStore truststo
 
 // NewVerifierWithOptions creates a new verifier given trustStore and
 // verifierOptions.
+//
+// The returned verifier is safe for concurrent use by multiple goroutines,
+// as long as trustStore, the plugin manager and the revocation validators are
+// safe for concurrent use, which is the case of the implementations provided
+// by notation-go and notation-core-go. The trust policy documents must not be
+// modified after the verifier is created. Trust stores are read on each
+// verification, so updates to the trust stores take effect without creating
+// a new verifier.
 func NewVerifierWithOptions(trustStore truststore.X509TrustStore, verifierOptions VerifierOptions) (*verifier, error) {
 	ociTrustPolicy := verifierOptions.OCITrustPolicy
 	blobTrustPolicy := verifierOptions.BlobTrustPolicy
+	if verifierOptions.TrustedCertificates != nil {
+		if trustStore != nil {
+			return nil, errors.New("trustStore and verifierOptions.TrustedCertificates cannot both be set")
+		}
+		var err error
+		trustStore, err = truststore.NewX509TrustStoreFromCertificates(verifierOptions.TrustedCertificates)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if trustStore == nil {
 		return nil, errors.New("trustStore cannot be nil")
 	}
@@ -163,19 +271,124 @@ func NewVerifierWithOptions(trustStore truststore.X509TrustStore, verifierOption
 			return nil, err
 		}
 	}
+	if verifierOptions.MaxClockSkew < 0 {
+		return nil, fmt.Errorf("verifierOptions.MaxClockSkew expects a non-negative duration, got %v", verifierOptions.MaxClockSkew)
+	}
 	v := &verifier{
 		ociTrustPolicyDoc:  ociTrustPolicy,
 		blobTrustPolicyDoc: blobTrustPolicy,
 		trustStore:         trustStore,
 		pluginManager:      verifierOptions.PluginManager,
+		maxClockSkew:       verifierOptions.MaxClockSkew,
 	}
 
 	if err := v.setRevocation(verifierOptions); err != nil {
 		return nil, err
 	}
+	if verifierOptions.PreloadTrustStores {
+		if err := v.preloadTrustStores(context.Background()); err != nil {
+			return nil, err
+		}
+	}
 	return v, nil
 }
 
+// preloadTrustStores loads all the trust stores referenced by the trust
+// policy documents of v, and returns the errors of all the trust stores that
+// failed to load.
+func (v *verifier) preloadTrustStores(ctx context.Context) error {
+	var trustStores []string
+	if v.ociTrustPolicyDoc != nil {
+		for _, policy := range v.ociTrustPolicyDoc.TrustPolicies {
+			trustStores = append(trustStores, policy.TrustStores...)
+		}
+	}
+	if v.blobTrustPolicyDoc != nil {
+		for _, policy := range v.blobTrustPolicyDoc.TrustPolicies {
+			trustStores = append(trustStores, policy.TrustStores...)
+		}
+	}
+
+	var errs []error
+	loaded := set.New[string]()
+	for _, trustStore := range trustStores {
+		if loaded.Contains(trustStore) {
+			continue
+		}
+		loaded.Add(trustStore)
+		// the format is already validated with the trust policy documents
+		storeType, name, _ := strings.Cut(trustStore, ":")
+		if _, err := v.trustStore.GetCertificates(ctx, truststore.Type(storeType), name); err != nil {
+			errs = append(errs, fmt.Errorf("failed to preload trust store %q: %w", trustStore, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// UpdateTrustStore atomically replaces the certificates of the trust store
+// of type storeType named name with certs, e.g. to add a new root
+// certificate before removing the old one without restarting a long-lived
+// verification service. The certificates are validated as in
+// [truststore.NewX509TrustStoreFromCertificates].
+//
+// Verifications in flight keep using the trust stores as they were when
+// they started, and subsequent verifications use certs instead of the
+// certificates of the trust store the verifier was created with.
+func (v *verifier) UpdateTrustStore(storeType truststore.Type, name string, certs []*x509.Certificate) error {
+	key := string(storeType) + ":" + name
+	// validate and copy the certificates
+	store, err := truststore.NewX509TrustStoreFromCertificates(map[string][]*x509.Certificate{key: certs})
+	if err != nil {
+		return err
+	}
+	certs, err = store.GetCertificates(context.Background(), storeType, name)
+	if err != nil {
+		return err
+	}
+
+	v.trustStoreLock.Lock()
+	defer v.trustStoreLock.Unlock()
+	overrides := make(map[string][]*x509.Certificate, len(v.trustStoreOverrides)+1)
+	for k, c := range v.trustStoreOverrides {
+		overrides[k] = c
+	}
+	overrides[key] = certs
+	v.trustStoreOverrides = overrides
+	return nil
+}
+
+// trustStoreSnapshot returns the trust store of v with the certificates
+// replaced by UpdateTrustStore at the time of the call.
+func (v *verifier) trustStoreSnapshot() truststore.X509TrustStore {
+	v.trustStoreLock.Lock()
+	overrides := v.trustStoreOverrides
+	v.trustStoreLock.Unlock()
+	if len(overrides) == 0 {
+		return v.trustStore
+	}
+	return &updatedTrustStore{
+		X509TrustStore: v.trustStore,
+		overrides:      overrides,
+	}
+}
+
+// updatedTrustStore implements [truststore.X509TrustStore] by returning the
+// certificates of overrides, if any, instead of the ones of the embedded trust
+// store.
+type updatedTrustStore struct {
+	truststore.X509TrustStore
+	overrides map[string][]*x509.Certificate
+}
+
+// GetCertificates returns the certificates of the trust store of type
+// storeType named namedStore.
+func (s *updatedTrustStore) GetCertificates(ctx context.Context, storeType truststore.Type, namedStore string) ([]*x509.Certificate, error) {
+	if certs, ok := s.overrides[string(storeType)+":"+namedStore]; ok {
+		return certs, nil
+	}
+	return s.X509TrustStore.GetCertificates(ctx, storeType, namedStore)
+}
+
 // NewFromConfig returns an OCI verifier based on local file system.
 //
 // Deprecated: NewFromConfig function exists for historical compatibility and
@@ -198,12 +411,17 @@ func New(ociTrustPolicy *trustpolicy.OCIDocument, trustStore truststore.X509Trus
 
 // setRevocation sets revocation validators of v
 func (v *verifier) setRevocation(verifierOptions VerifierOptions) error {
+	crlFetcher, err := newCRLFetcher(verifierOptions.CacheDir)
+	if err != nil {
+		return err
+	}
+
 	// timestamping validator
 	revocationTimestampingValidator := verifierOptions.RevocationTimestampingValidator
-	var err error
 	if revocationTimestampingValidator == nil {
 		revocationTimestampingValidator, err = revocation.NewWithOptions(revocation.Options{
 			OCSPHTTPClient:   &http.Client{Timeout: 2 * time.Second},
+			CRLFetcher:       crlFetcher,
 			CertChainPurpose: purpose.Timestamping,
 		})
 		if err != nil {
@@ -227,6 +445,7 @@ func (v *verifier) setRevocation(verifierOptions VerifierOptions) error {
 	// both RevocationCodeSigningValidator and RevocationClient are nil
 	revocationCodeSigningValidator, err = revocation.NewWithOptions(revocation.Options{
 		OCSPHTTPClient:   &http.Client{Timeout: 2 * time.Second},
+		CRLFetcher:       crlFetcher,
 		CertChainPurpose: purpose.CodeSigning,
 	})
 	if err != nil {
@@ -236,12 +455,36 @@ func (v *verifier) setRevocation(verifierOptions VerifierOptions) error {
 	return nil
 }
 
+// newCRLFetcher returns a CRL fetcher caching the CRLs in the crl file cache
+// of cacheDir. If cacheDir is empty, nil is returned for the default
+// fetcher without cache.
+func newCRLFetcher(cacheDir string) (corecrl.Fetcher, error) {
+	if cacheDir == "" {
+		return nil, nil
+	}
+	cache, err := crl.NewFileCache(filepath.Join(cacheDir, dir.PathCRLCache))
+	if err != nil {
+		return nil, err
+	}
+	fetcher, err := corecrl.NewHTTPFetcher(&http.Client{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	fetcher.Cache = cache
+	fetcher.DiscardCacheError = true
+	return fetcher, nil
+}
+
 // SkipVerify validates whether the verification level is skip.
 func (v *verifier) SkipVerify(ctx context.Context, opts notation.VerifierVerifyOptions) (bool, *trustpolicy.VerificationLevel, error) {
 	logger := log.GetLogger(ctx)
 
 	logger.Debugf("Check verification level against artifact %v", opts.ArtifactReference)
-	trustPolicy, err := v.ociTrustPolicyDoc.GetApplicableTrustPolicy(opts.ArtifactReference)
+	policyDoc, err := v.ociTrustPolicy(opts)
+	if err != nil {
+		return false, nil, err
+	}
+	trustPolicy, err := policyDoc.GetApplicableTrustPolicy(opts.ArtifactReference)
 	if err != nil {
 		return false, nil, notation.ErrorNoApplicableTrustPolicy{Msg: err.Error()}
 	}
@@ -258,6 +501,57 @@ func (v *verifier) SkipVerify(ctx context.Context, opts notation.VerifierVerifyO
 	return false, verificationLevel, nil
 }
 
+// PlannedCheck is a validation to be performed on the signatures of an
+// artifact, as planned by the applicable trust policy statement.
+type PlannedCheck struct {
+	// Type is the type of the validation.
+	Type trustpolicy.ValidationType
+
+	// Action is the action taken when the validation fails, i.e.
+	// [trustpolicy.ActionEnforce] or [trustpolicy.ActionLog].
+	Action trustpolicy.ValidationAction
+}
+
+// PlannedChecks returns the validations that the trust policy statement
+// applicable to artifactRef performs on the signatures of the artifact, in
+// the order they are performed, without verifying anything, e.g. for
+// previewing a verification or warning about disabled checks. Validations
+// skipped by the verification level are not returned, and none is returned
+// if signature verification is skipped.
+// The authentic timestamp validation is returned if enabled, although it
+// only applies to signatures whose signing certificate chain requires it.
+func (v *verifier) PlannedChecks(ctx context.Context, artifactRef string) ([]PlannedCheck, error) {
+	skip, verificationLevel, err := v.SkipVerify(ctx, notation.VerifierVerifyOptions{ArtifactReference: artifactRef})
+	if err != nil {
+		return nil, err
+	}
+	if skip {
+		return nil, nil
+	}
+	var checks []PlannedCheck
+	for _, validationType := range trustpolicy.ValidationTypes {
+		if action := verificationLevel.Enforcement[validationType]; action != trustpolicy.ActionSkip {
+			checks = append(checks, PlannedCheck{Type: validationType, Action: action})
+		}
+	}
+	return checks, nil
+}
+
+// ociTrustPolicy returns the OCI trust policy document to verify against,
+// which is opts.OCITrustPolicy if set, or the trust policy document of v.
+func (v *verifier) ociTrustPolicy(opts notation.VerifierVerifyOptions) (*trustpolicy.OCIDocument, error) {
+	if opts.OCITrustPolicy == nil {
+		if v.ociTrustPolicyDoc == nil {
+			return nil, errors.New("ociTrustPolicyDoc is nil")
+		}
+		return v.ociTrustPolicyDoc, nil
+	}
+	if err := opts.OCITrustPolicy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid trust policy in verify options: %w", err)
+	}
+	return opts.OCITrustPolicy, nil
+}
+
 // VerifyBlob verifies the signature of given blob, and returns the outcome upon
 // successful verification.
 func (v *verifier) VerifyBlob(ctx context.Context, descGenFunc notation.BlobDescriptorGenerator, signature []byte, opts notation.BlobVerifierVerifyOptions) (*notation.VerificationOutcome, error) {
@@ -284,13 +578,18 @@ func (v *verifier) VerifyBlob(ctx context.Context, descGenFunc notation.BlobDesc
 	outcome := &notation.VerificationOutcome{
 		RawSignature:      signature,
 		VerificationLevel: verificationLevel,
+		TrustPolicyName:   trustPolicy.Name,
 	}
 	// verificationLevel is skip
 	if reflect.DeepEqual(verificationLevel, trustpolicy.LevelSkip) {
 		logger.Debug("Skipping signature verification")
+		outcome.SkipReason = notation.SkipReasonVerificationLevel
 		return outcome, nil
 	}
-	err = v.processSignature(ctx, signature, opts.SignatureMediaType, trustPolicy.Name, trustPolicy.TrustedIdentities, trustPolicy.TrustStores, trustPolicy.SignatureVerification, opts.PluginConfig, outcome)
+	err = v.processSignature(ctx, signature, opts.SignatureMediaType, trustPolicy.Name, trustPolicy.TrustedIdentities, trustPolicy.TrustStores, trustPolicy.SignatureVerification, opts.PluginConfig, revocationOptions{
+		collectEvidence: opts.CollectRevocationEvidence,
+		requireCheck:    opts.RequireRevocationCheck,
+	}, nil, clock.Now(opts.Clock), outcome)
 	if err != nil {
 		outcome.Error = err
 		return outcome, err
@@ -351,11 +650,12 @@ func (v *verifier) Verify(ctx context.Context, desc ocispec.Descriptor, signatur
 	logger := log.GetLogger(ctx)
 
 	logger.Debugf("Verify signature against artifact %v referenced as %s in signature media type %v", desc.Digest, artifactRef, envelopeMediaType)
-	if v.ociTrustPolicyDoc == nil {
-		return nil, errors.New("ociTrustPolicyDoc is nil")
+	policyDoc, err := v.ociTrustPolicy(opts)
+	if err != nil {
+		return nil, err
 	}
 
-	trustPolicy, err := v.ociTrustPolicyDoc.GetApplicableTrustPolicy(artifactRef)
+	trustPolicy, err := policyDoc.GetApplicableTrustPolicy(artifactRef)
 	if err != nil {
 		return nil, notation.ErrorNoApplicableTrustPolicy{Msg: err.Error()}
 	}
@@ -367,13 +667,20 @@ func (v *verifier) Verify(ctx context.Context, desc ocispec.Descriptor, signatur
 	outcome := &notation.VerificationOutcome{
 		RawSignature:      signature,
 		VerificationLevel: verificationLevel,
+		TrustPolicyName:   trustPolicy.Name,
 	}
 	// verificationLevel is skip
 	if reflect.DeepEqual(verificationLevel, trustpolicy.LevelSkip) {
 		logger.Debug("Skipping signature verification")
+		outcome.SkipReason = notation.SkipReasonVerificationLevel
 		return outcome, nil
 	}
-	err = v.processSignature(ctx, signature, envelopeMediaType, trustPolicy.Name, trustPolicy.TrustedIdentities, trustPolicy.TrustStores, trustPolicy.SignatureVerification, pluginConfig, outcome)
+	err = v.processSignature(ctx, signature, envelopeMediaType, trustPolicy.Name, trustPolicy.TrustedIdentities, trustPolicy.TrustStores, trustPolicy.SignatureVerification, pluginConfig, revocationOptions{
+		collectEvidence: opts.CollectRevocationEvidence,
+		requireCheck:    opts.RequireRevocationCheck,
+		dataProvider:    opts.RevocationData,
+		maxDataAge:      opts.RevocationDataMaxAge,
+	}, opts.CertificateChainValidator, clock.Now(opts.Clock), outcome)
 
 	if err != nil {
 		outcome.Error = err
@@ -388,10 +695,19 @@ func (v *verifier) Verify(ctx context.Context, desc ocispec.Descriptor, signatur
 		return outcome, err
 	}
 
-	if !content.Equal(payload.TargetArtifact, desc) {
+	if err := verifyTargetArtifact(payload.TargetArtifact, desc); err != nil {
 		logger.Infof("Target artifact in signature payload: %+v", payload.TargetArtifact)
 		logger.Infof("Target artifact that want to be verified: %+v", desc)
-		outcome.Error = errors.New("content descriptor mismatch")
+		// the binding between the signature and the artifact is part of the
+		// integrity check
+		for _, result := range outcome.VerificationResults {
+			if result.Type == trustpolicy.TypeIntegrity {
+				result.Error = err
+				break
+			}
+		}
+		outcome.Error = err
+		return outcome, err
 	}
 
 	if len(opts.UserMetadata) > 0 {
@@ -404,18 +720,26 @@ func (v *verifier) Verify(ctx context.Context, desc ocispec.Descriptor, signatur
 	return outcome, outcome.Error
 }
 
-func (v *verifier) processSignature(ctx context.Context, sigBlob []byte, envelopeMediaType, policyName string, trustedIdentities, trustStores []string, signatureVerification trustpolicy.SignatureVerification, pluginConfig map[string]string, outcome *notation.VerificationOutcome) error {
+func (v *verifier) processSignature(ctx context.Context, sigBlob []byte, envelopeMediaType, policyName string, trustedIdentities, trustStores []string, signatureVerification trustpolicy.SignatureVerification, pluginConfig map[string]string, revocationOpts revocationOptions, certChainValidator func([]*x509.Certificate) error, timeOfVerification time.Time, outcome *notation.VerificationOutcome) error {
 	logger := log.GetLogger(ctx)
+	// the trust stores are consistent for the whole verification even if
+	// they are updated concurrently
+	x509TrustStore := v.trustStoreSnapshot()
 
 	// verify integrity first. notation will always verify integrity no matter
 	// what the signing scheme is
+	integrityStart := time.Now()
 	envContent, integrityResult := verifyIntegrity(sigBlob, envelopeMediaType, outcome)
+	metrics.ObserveSince(ctx, metrics.StageIntegrity, integrityStart)
 	outcome.EnvelopeContent = envContent
 	outcome.VerificationResults = append(outcome.VerificationResults, integrityResult)
 	if integrityResult.Error != nil {
 		logVerificationResult(logger, integrityResult)
 		return integrityResult.Error
 	}
+	if outcome.PayloadVersion > 1 {
+		logger.Warnf("Signature payload of schema version %d is newer than the supported version 1, its unknown fields are ignored", outcome.PayloadVersion)
+	}
 
 	// check if we need to verify using a plugin
 	var pluginCapabilities []pluginframework.Capability
@@ -472,7 +796,8 @@ func (v *verifier) processSignature(ctx context.Context, sigBlob []byte, envelop
 
 	// verify x509 trust store based authenticity
 	logger.Debug("Validating cert chain")
-	trustCerts, err := loadX509TrustStores(ctx, outcome.EnvelopeContent.SignerInfo.SignedAttributes.SigningScheme, policyName, trustStores, v.trustStore)
+	authenticityStart := time.Now()
+	trustCerts, err := loadX509TrustStores(ctx, outcome.EnvelopeContent.SignerInfo.SignedAttributes.SigningScheme, policyName, trustStores, x509TrustStore)
 	var authenticityResult *notation.ValidationResult
 	if err != nil {
 		authenticityResult = &notation.ValidationResult{
@@ -484,6 +809,16 @@ func (v *verifier) processSignature(ctx context.Context, sigBlob []byte, envelop
 		// verify authenticity
 		authenticityResult = verifyAuthenticity(trustCerts, outcome)
 	}
+	if authenticityResult.Error == nil {
+		authenticityResult.Error = verifySignatureAlgorithm(policyName, signatureVerification.AllowedSignatureAlgorithms, outcome.EnvelopeContent.SignerInfo.SignatureAlgorithm)
+	}
+	if authenticityResult.Error == nil {
+		authenticityResult.Error = verifyExtKeyUsages(policyName, signatureVerification.RequiredExtKeyUsages, outcome.EnvelopeContent.SignerInfo.CertificateChain)
+	}
+	if authenticityResult.Error == nil {
+		authenticityResult.Error = v.verifySigningTimeSkew(outcome.EnvelopeContent.SignerInfo.SignedAttributes.SigningTime, timeOfVerification)
+	}
+	metrics.ObserveSince(ctx, metrics.StageAuthenticity, authenticityStart)
 	outcome.VerificationResults = append(outcome.VerificationResults, authenticityResult)
 	logVerificationResult(logger, authenticityResult)
 	if isCriticalFailure(authenticityResult) {
@@ -504,9 +839,21 @@ func (v *verifier) processSignature(ctx context.Context, sigBlob []byte, envelop
 		}
 	}
 
+	// run the custom certificate chain checks of the caller once the
+	// built-in authenticity checks have passed
+	if certChainValidator != nil && authenticityResult.Error == nil {
+		logger.Debug("Validating cert chain with the custom certificate chain validator")
+		if err := certChainValidator(outcome.EnvelopeContent.SignerInfo.CertificateChain); err != nil {
+			authenticityResult.Error = notation.ErrorVerificationFailed{Msg: err.Error()}
+			authenticityResult.Action = trustpolicy.ActionEnforce
+			logVerificationResult(logger, authenticityResult)
+			return authenticityResult.Error
+		}
+	}
+
 	// verify expiry
 	logger.Debug("Validating expiry")
-	expiryResult := verifyExpiry(outcome)
+	expiryResult := verifyExpiry(outcome, timeOfVerification)
 	outcome.VerificationResults = append(outcome.VerificationResults, expiryResult)
 	logVerificationResult(logger, expiryResult)
 	if isCriticalFailure(expiryResult) {
@@ -515,7 +862,9 @@ func (v *verifier) processSignature(ctx context.Context, sigBlob []byte, envelop
 
 	// verify authentic timestamp
 	logger.Debug("Validating authentic timestamp")
-	authenticTimestampResult := verifyAuthenticTimestamp(ctx, policyName, trustStores, signatureVerification, v.trustStore, v.revocationTimestampingValidator, outcome)
+	authenticTimestampStart := time.Now()
+	authenticTimestampResult := verifyAuthenticTimestamp(ctx, policyName, trustStores, signatureVerification, x509TrustStore, v.revocationTimestampingValidator, timeOfVerification, outcome)
+	metrics.ObserveSince(ctx, metrics.StageAuthenticTimestamp, authenticTimestampStart)
 	outcome.VerificationResults = append(outcome.VerificationResults, authenticTimestampResult)
 	logVerificationResult(logger, authenticTimestampResult)
 	if isCriticalFailure(authenticTimestampResult) {
@@ -523,13 +872,27 @@ func (v *verifier) processSignature(ctx context.Context, sigBlob []byte, envelop
 	}
 
 	// verify revocation
+	// a required revocation check cannot be skipped by the trust policy
+	if outcome.VerificationLevel.Enforcement[trustpolicy.TypeRevocation] == trustpolicy.ActionSkip && revocationOpts.requireCheck {
+		revocationResult := &notation.ValidationResult{
+			Type:   trustpolicy.TypeRevocation,
+			Action: trustpolicy.ActionEnforce,
+			Error:  fmt.Errorf("revocation check is required, but trust policy %q skips the revocation validation", policyName),
+		}
+		outcome.VerificationResults = append(outcome.VerificationResults, revocationResult)
+		logVerificationResult(logger, revocationResult)
+		return revocationResult.Error
+	}
 	// check if we need to bypass the revocation check, since revocation can be
 	// skipped using a trust policy or a plugin may override the check
 	if outcome.VerificationLevel.Enforcement[trustpolicy.TypeRevocation] != trustpolicy.ActionSkip &&
 		!slices.Contains(pluginCapabilities, pluginframework.CapabilityRevocationCheckVerifier) {
 
 		logger.Debug("Validating revocation")
-		revocationResult := v.verifyRevocation(ctx, outcome)
+		revocationOpts.timeOfVerification = timeOfVerification
+		revocationStart := time.Now()
+		revocationResult := v.verifyRevocation(ctx, outcome, revocationOpts)
+		metrics.ObserveSince(ctx, metrics.StageRevocation, revocationStart)
 		outcome.VerificationResults = append(outcome.VerificationResults, revocationResult)
 		logVerificationResult(logger, revocationResult)
 		if isCriticalFailure(revocationResult) {
@@ -552,24 +915,70 @@ func (v *verifier) processSignature(ctx context.Context, sigBlob []byte, envelop
 
 		if len(capabilitiesToVerify) > 0 {
 			logger.Debugf("Executing verification plugin %q with capabilities %v", verificationPluginName, capabilitiesToVerify)
+			pluginStart := time.Now()
 			response, err := executePlugin(ctx, installedPlugin, capabilitiesToVerify, outcome.EnvelopeContent, trustedIdentities, pluginConfig)
+			metrics.ObserveSince(ctx, metrics.StagePlugin, pluginStart)
 			if err != nil {
 				return fmt.Errorf("failed to verify with plugin %s: %w", verificationPluginName, err)
 			}
 
-			return processPluginResponse(capabilitiesToVerify, response, outcome)
+			return processPluginResponse(capabilitiesToVerify, response, outcome, revocationOpts.requireCheck)
 		}
 	}
 	return nil
 }
 
-func (v *verifier) verifyRevocation(ctx context.Context, outcome *notation.VerificationOutcome) *notation.ValidationResult {
+// revocationOptions configures the revocation check of the signing
+// certificate chain for a single verification.
+type revocationOptions struct {
+	// collectEvidence retains the revocation results in
+	// outcome.RevocationEvidence
+	collectEvidence bool
+
+	// requireCheck enforces failing to determine the revocation status
+	// regardless of the verification level
+	requireCheck bool
+
+	// dataProvider supplies the revocation data instead of the network
+	dataProvider notation.RevocationDataProvider
+
+	// maxDataAge is the maximum age of the revocation data supplied by
+	// dataProvider
+	maxDataAge time.Duration
+
+	// timeOfVerification is the time against which the freshness of the
+	// revocation data supplied by dataProvider is checked
+	timeOfVerification time.Time
+}
+
+// verifyRevocation checks the revocation status of the signing certificate
+// chain as configured by revocationOpts.
+func (v *verifier) verifyRevocation(ctx context.Context, outcome *notation.VerificationOutcome, revocationOpts revocationOptions) *notation.ValidationResult {
 	logger := log.GetLogger(ctx)
 
-	if v.revocationCodeSigningValidator == nil && v.revocationClient == nil {
+	// the action applied when the revocation status cannot be determined
+	indeterminateAction := outcome.VerificationLevel.Enforcement[trustpolicy.TypeRevocation]
+	if revocationOpts.requireCheck {
+		indeterminateAction = trustpolicy.ActionEnforce
+	}
+
+	codeSigningValidator := v.revocationCodeSigningValidator
+	if revocationOpts.dataProvider != nil {
+		var err error
+		codeSigningValidator, err = newOfflineRevocationValidator(revocationOpts.dataProvider, outcome.EnvelopeContent.SignerInfo.CertificateChain, revocationOpts.maxDataAge, revocationOpts.timeOfVerification)
+		if err != nil {
+			return &notation.ValidationResult{
+				Type:   trustpolicy.TypeRevocation,
+				Action: indeterminateAction,
+				Error:  fmt.Errorf("unable to check revocation status, err: %s", err.Error()),
+			}
+		}
+	}
+
+	if codeSigningValidator == nil && v.revocationClient == nil {
 		return &notation.ValidationResult{
 			Type:   trustpolicy.TypeRevocation,
-			Action: outcome.VerificationLevel.Enforcement[trustpolicy.TypeRevocation],
+			Action: indeterminateAction,
 			Error:  fmt.Errorf("unable to check revocation status, code signing revocation validator cannot be nil"),
 		}
 	}
@@ -581,8 +990,8 @@ func (v *verifier) verifyRevocation(ctx context.Context, outcome *notation.Verif
 
 	var certResults []*revocationresult.CertRevocationResult
 	var err error
-	if v.revocationCodeSigningValidator != nil {
-		certResults, err = v.revocationCodeSigningValidator.ValidateContext(ctx, revocation.ValidateContextOptions{
+	if codeSigningValidator != nil {
+		certResults, err = codeSigningValidator.ValidateContext(ctx, revocation.ValidateContextOptions{
 			CertChain:            outcome.EnvelopeContent.SignerInfo.CertificateChain,
 			AuthenticSigningTime: authenticSigningTime,
 		})
@@ -593,10 +1002,20 @@ func (v *verifier) verifyRevocation(ctx context.Context, outcome *notation.Verif
 		logger.Debug("Error while checking revocation status, err: %s", err.Error())
 		return &notation.ValidationResult{
 			Type:   trustpolicy.TypeRevocation,
-			Action: outcome.VerificationLevel.Enforcement[trustpolicy.TypeRevocation],
+			Action: indeterminateAction,
 			Error:  fmt.Errorf("unable to check revocation status, err: %s", err.Error()),
 		}
 	}
+	if revocationOpts.collectEvidence {
+		certChain := outcome.EnvelopeContent.SignerInfo.CertificateChain
+		for i, certResult := range certResults {
+			evidence := notation.RevocationEvidence{Result: certResult}
+			if i < len(certChain) {
+				evidence.Certificate = certChain[i]
+			}
+			outcome.RevocationEvidence = append(outcome.RevocationEvidence, evidence)
+		}
+	}
 
 	result := &notation.ValidationResult{
 		Type:   trustpolicy.TypeRevocation,
@@ -610,13 +1029,40 @@ func (v *verifier) verifyRevocation(ctx context.Context, outcome *notation.Verif
 		result.Error = fmt.Errorf("signing certificate with subject %q is revoked", problematicCertSubject)
 	default:
 		// revocationresult.ResultUnknown
-		result.Error = fmt.Errorf("signing certificate with subject %q revocation status is unknown", problematicCertSubject)
+		if revocationOpts.requireCheck {
+			result.Action = indeterminateAction
+			result.Error = fmt.Errorf("signing certificate with subject %q revocation status is unknown, and a definitive revocation check is required: %s", problematicCertSubject, revocationUnknownReason(certResults, outcome.EnvelopeContent.SignerInfo.CertificateChain, problematicCertSubject))
+		} else {
+			result.Error = fmt.Errorf("signing certificate with subject %q revocation status is unknown", problematicCertSubject)
+		}
 	}
 
 	return result
 }
 
-func processPluginResponse(capabilitiesToVerify []pluginframework.Capability, response *pluginframework.VerifySignatureResponse, outcome *notation.VerificationOutcome) error {
+// revocationUnknownReason describes why the revocation status of the
+// certificate with the given subject is unknown, i.e. the revocation method
+// used and the errors of the revocation servers.
+func revocationUnknownReason(certResults []*revocationresult.CertRevocationResult, certChain []*x509.Certificate, subject string) string {
+	for i, certResult := range certResults {
+		if i >= len(certChain) || certChain[i].Subject.String() != subject || certResult.Result != revocationresult.ResultUnknown {
+			continue
+		}
+		var reasons []string
+		for _, serverResult := range certResult.ServerResults {
+			if serverResult.Error != nil {
+				reasons = append(reasons, fmt.Sprintf("%s check against %q failed: %v", serverResult.RevocationMethod, serverResult.Server, serverResult.Error))
+			}
+		}
+		if len(reasons) == 0 {
+			return fmt.Sprintf("%s check was inconclusive", certResult.RevocationMethod)
+		}
+		return strings.Join(reasons, "; ")
+	}
+	return "no revocation method could determine the status"
+}
+
+func processPluginResponse(capabilitiesToVerify []pluginframework.Capability, response *pluginframework.VerifySignatureResponse, outcome *notation.VerificationOutcome, requireRevocationCheck bool) error {
 	verificationPluginName, err := getVerificationPlugin(&outcome.EnvelopeContent.SignerInfo)
 	if err != nil {
 		return err
@@ -657,10 +1103,14 @@ func processPluginResponse(capabilitiesToVerify []pluginframework.Capability, re
 		case pluginframework.CapabilityRevocationCheckVerifier:
 			var revocationResult *notation.ValidationResult
 			if !pluginResult.Success {
+				action := outcome.VerificationLevel.Enforcement[trustpolicy.TypeRevocation]
+				if requireRevocationCheck {
+					action = trustpolicy.ActionEnforce
+				}
 				revocationResult = &notation.ValidationResult{
 					Error:  fmt.Errorf("revocation check by verification plugin %q failed with reason %q", verificationPluginName, pluginResult.Reason),
 					Type:   trustpolicy.TypeRevocation,
-					Action: outcome.VerificationLevel.Enforcement[trustpolicy.TypeRevocation],
+					Action: action,
 				}
 			} else {
 				revocationResult = &notation.ValidationResult{
@@ -709,13 +1159,15 @@ func verifyIntegrity(sigBlob []byte, envelopeMediaType string, outcome *notation
 		}
 	}
 
-	if err := envelope.ValidatePayloadContentType(&envContent.Payload); err != nil {
+	payloadVersion, err := envelope.PayloadVersion(envContent.Payload.ContentType)
+	if err != nil {
 		return nil, &notation.ValidationResult{
 			Error:  err,
 			Type:   trustpolicy.TypeIntegrity,
 			Action: outcome.VerificationLevel.Enforcement[trustpolicy.TypeIntegrity],
 		}
 	}
+	outcome.PayloadVersion = payloadVersion
 
 	// integrity has been verified successfully
 	return envContent, &notation.ValidationResult{
@@ -724,6 +1176,25 @@ func verifyIntegrity(sigBlob []byte, envelopeMediaType string, outcome *notation
 	}
 }
 
+// verifyTargetArtifact verifies that the target artifact in the signature
+// payload matches the descriptor of the artifact being verified.
+func verifyTargetArtifact(targetArtifact, desc ocispec.Descriptor) error {
+	var mismatches []string
+	if targetArtifact.Digest != desc.Digest {
+		mismatches = append(mismatches, fmt.Sprintf("digest %q does not match %q", targetArtifact.Digest, desc.Digest))
+	}
+	if targetArtifact.Size != desc.Size {
+		mismatches = append(mismatches, fmt.Sprintf("size %d does not match %d", targetArtifact.Size, desc.Size))
+	}
+	if targetArtifact.MediaType != desc.MediaType {
+		mismatches = append(mismatches, fmt.Sprintf("media type %q does not match %q", targetArtifact.MediaType, desc.MediaType))
+	}
+	if len(mismatches) > 0 {
+		return notation.ErrorTargetArtifactMismatch{Msg: "content descriptor mismatch: target artifact in the signature payload " + strings.Join(mismatches, ", ")}
+	}
+	return nil
+}
+
 func verifyAuthenticity(trustCerts []*x509.Certificate, outcome *notation.VerificationOutcome) *notation.ValidationResult {
 	if len(trustCerts) < 1 {
 		return &notation.ValidationResult{
@@ -756,24 +1227,118 @@ func verifyAuthenticity(trustCerts []*x509.Certificate, outcome *notation.Verifi
 	}
 }
 
+// verifySignatureAlgorithm checks that alg is one of the allowed signature
+// algorithms of the trust policy statement. All the algorithms are allowed if
+// allowedAlgorithms is empty.
+func verifySignatureAlgorithm(policyName string, allowedAlgorithms []string, alg signature.Algorithm) error {
+	if len(allowedAlgorithms) == 0 {
+		return nil
+	}
+	name, err := proto.EncodeSigningAlgorithm(alg)
+	if err != nil {
+		return notation.ErrorVerificationInconclusive{Msg: fmt.Sprintf("failed to check the signature algorithm: %v", err)}
+	}
+	if !slices.Contains(allowedAlgorithms, string(name)) {
+		return notation.ErrorVerificationFailed{Msg: fmt.Sprintf("signature algorithm %s is not allowed by trust policy statement %q, allowed signature algorithms are %q", name, policyName, allowedAlgorithms)}
+	}
+	return nil
+}
+
+// verifyExtKeyUsages checks that the signing certificate contains all the
+// extended key usages required by the trust policy statement.
+func verifyExtKeyUsages(policyName string, requiredEKUs []string, certChain []*x509.Certificate) error {
+	if len(requiredEKUs) == 0 {
+		return nil
+	}
+	if len(certChain) == 0 {
+		return notation.ErrorVerificationFailed{Msg: "signing certificate is missing"}
+	}
+	leaf := certChain[0]
+	var ekus []asn1.ObjectIdentifier
+	for _, ext := range leaf.Extensions {
+		if !ext.Id.Equal(oidExtensionExtendedKeyUsage) {
+			continue
+		}
+		if rest, err := asn1.Unmarshal(ext.Value, &ekus); err != nil || len(rest) != 0 {
+			return notation.ErrorVerificationFailed{Msg: fmt.Sprintf("signing certificate with subject %q has a malformed extended key usage extension", leaf.Subject)}
+		}
+		break
+	}
+	certEKUs := make([]string, 0, len(ekus))
+	for _, eku := range ekus {
+		certEKUs = append(certEKUs, eku.String())
+	}
+	for _, required := range requiredEKUs {
+		if !slices.Contains(certEKUs, required) {
+			return notation.ErrorVerificationFailed{Msg: fmt.Sprintf("signing certificate with subject %q is missing extended key usage %s required by trust policy statement %q", leaf.Subject, required, policyName)}
+		}
+	}
+	return nil
+}
+
 func verifyUserMetadata(logger log.Logger, payload *envelope.Payload, userMetadata map[string]string) error {
 	logger.Debugf("Verifying that metadata %v is present in signature", userMetadata)
 	logger.Debugf("Signature metadata: %v", payload.TargetArtifact.Annotations)
 
 	for k, v := range userMetadata {
-		if got, ok := payload.TargetArtifact.Annotations[k]; !ok || got != v {
+		got, ok := payload.TargetArtifact.Annotations[k]
+		if !ok {
 			logger.Errorf("User required metadata %s=%s is not present in the signature", k, v)
-			return notation.ErrorUserMetadataVerificationFailed{}
+			return notation.ErrorUserMetadataVerificationFailed{Msg: fmt.Sprintf("unable to find specified metadata %q in the signature", k)}
+		}
+		matched, err := matchUserMetadataValue(v, got)
+		if err != nil {
+			return notation.ErrorUserMetadataVerificationFailed{Msg: fmt.Sprintf("invalid pattern %q for metadata %q: %v", v, k, err)}
+		}
+		if !matched {
+			logger.Errorf("User required metadata %s=%s does not match %s=%s in the signature", k, v, k, got)
+			return notation.ErrorUserMetadataVerificationFailed{Msg: fmt.Sprintf("value of metadata %q in the signature does not match %q", k, v)}
+		}
+	}
+
+	return nil
+}
+
+// matchUserMetadataValue matches the signature metadata value got against the
+// user required value want, which is either an exact value or a pattern
+// prefixed with [notation.UserMetadataRegexPrefix] or
+// [notation.UserMetadataGlobPrefix].
+func matchUserMetadataValue(want, got string) (bool, error) {
+	if pattern, ok := strings.CutPrefix(want, notation.UserMetadataRegexPrefix); ok {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return false, err
 		}
+		return re.MatchString(got), nil
+	}
+	if pattern, ok := strings.CutPrefix(want, notation.UserMetadataGlobPrefix); ok {
+		return path.Match(pattern, got)
 	}
+	return got == want, nil
+}
 
+// verifySigningTimeSkew returns a [notation.ErrorSigningTimeInFuture] if
+// signingTime is ahead of timeOfVerification by more than the maximum clock
+// skew of v.
+func (v *verifier) verifySigningTimeSkew(signingTime, timeOfVerification time.Time) error {
+	maxClockSkew := v.maxClockSkew
+	if maxClockSkew == 0 {
+		maxClockSkew = defaultMaxClockSkew
+	}
+	if skew := signingTime.Sub(timeOfVerification); skew > maxClockSkew {
+		return notation.ErrorSigningTimeInFuture{
+			SigningTime:  signingTime,
+			Skew:         skew,
+			MaxClockSkew: maxClockSkew,
+		}
+	}
 	return nil
 }
 
-func verifyExpiry(outcome *notation.VerificationOutcome) *notation.ValidationResult {
-	if expiry := outcome.EnvelopeContent.SignerInfo.SignedAttributes.Expiry; !expiry.IsZero() && !time.Now().Before(expiry) {
+func verifyExpiry(outcome *notation.VerificationOutcome, timeOfVerification time.Time) *notation.ValidationResult {
+	if expiry := outcome.EnvelopeContent.SignerInfo.SignedAttributes.Expiry; !expiry.IsZero() && !timeOfVerification.Before(expiry) {
 		return &notation.ValidationResult{
-			Error:  fmt.Errorf("digital signature has expired on %q", expiry.Format(time.RFC1123Z)),
+			Error:  notation.ErrorSignatureExpired{Expiry: expiry},
 			Type:   trustpolicy.TypeExpiry,
 			Action: outcome.VerificationLevel.Enforcement[trustpolicy.TypeExpiry],
 		}
@@ -785,7 +1350,7 @@ func verifyExpiry(outcome *notation.VerificationOutcome) *notation.ValidationRes
 	}
 }
 
-func verifyAuthenticTimestamp(ctx context.Context, policyName string, trustStores []string, signatureVerification trustpolicy.SignatureVerification, x509TrustStore truststore.X509TrustStore, r revocation.Validator, outcome *notation.VerificationOutcome) *notation.ValidationResult {
+func verifyAuthenticTimestamp(ctx context.Context, policyName string, trustStores []string, signatureVerification trustpolicy.SignatureVerification, x509TrustStore truststore.X509TrustStore, r revocation.Validator, timeOfVerification time.Time, outcome *notation.VerificationOutcome) *notation.ValidationResult {
 	logger := log.GetLogger(ctx)
 
 	signerInfo := outcome.EnvelopeContent.SignerInfo
@@ -793,7 +1358,7 @@ func verifyAuthenticTimestamp(ctx context.Context, policyName string, trustStore
 	if signerInfo.SignedAttributes.SigningScheme == signature.SigningSchemeX509 {
 		logger.Debug("Under signing scheme notary.x509...")
 		return &notation.ValidationResult{
-			Error:  verifyTimestamp(ctx, policyName, trustStores, signatureVerification, x509TrustStore, r, outcome),
+			Error:  verifyTimestamp(ctx, policyName, trustStores, signatureVerification, x509TrustStore, r, timeOfVerification, outcome),
 			Type:   trustpolicy.TypeAuthenticTimestamp,
 			Action: outcome.VerificationLevel.Enforcement[trustpolicy.TypeAuthenticTimestamp],
 		}
@@ -991,7 +1556,7 @@ func isRequiredVerificationPluginVer(pluginVer string, minPluginVer string) bool
 
 // verifyTimestamp provides core verification logic of authentic timestamp under
 // signing scheme `notary.x509`.
-func verifyTimestamp(ctx context.Context, policyName string, trustStores []string, signatureVerification trustpolicy.SignatureVerification, x509TrustStore truststore.X509TrustStore, r revocation.Validator, outcome *notation.VerificationOutcome) error {
+func verifyTimestamp(ctx context.Context, policyName string, trustStores []string, signatureVerification trustpolicy.SignatureVerification, x509TrustStore truststore.X509TrustStore, r revocation.Validator, timeOfVerification time.Time, outcome *notation.VerificationOutcome) error {
 	logger := log.GetLogger(ctx)
 
 	signerInfo := outcome.EnvelopeContent.SignerInfo
@@ -1008,7 +1573,6 @@ func verifyTimestamp(ctx context.Context, policyName string, trustStores []strin
 	}
 
 	// check based on 'verifyTimestamp' field
-	timeOfVerification := time.Now()
 	if performTimestampVerification &&
 		signatureVerification.VerifyTimestamp == trustpolicy.OptionAfterCertExpiry {
 		// check if signing cert chain has expired
@@ -1033,7 +1597,11 @@ func verifyTimestamp(ctx context.Context, policyName string, trustStores []strin
 				return fmt.Errorf("verification time is before certificate %q validity period, it will be valid from %q", cert.Subject, cert.NotBefore.Format(time.RFC1123Z))
 			}
 			if timeOfVerification.After(cert.NotAfter) {
-				return fmt.Errorf("verification time is after certificate %q validity period, it was expired at %q", cert.Subject, cert.NotAfter.Format(time.RFC1123Z))
+				return notation.ErrorCertificateExpired{
+					Msg:      fmt.Sprintf("verification time is after certificate %q validity period, it was expired at %q", cert.Subject, cert.NotAfter.Format(time.RFC1123Z)),
+					Subject:  cert.Subject.String(),
+					NotAfter: cert.NotAfter,
+				}
 			}
 		}
 
@@ -1101,7 +1669,12 @@ func verifyTimestamp(ctx context.Context, policyName string, trustStores []strin
 			return fmt.Errorf("timestamp can be before certificate %q validity period, it will be valid from %q", cert.Subject, cert.NotBefore.Format(time.RFC1123Z))
 		}
 		if !timestamp.BoundedBefore(cert.NotAfter) {
-			return fmt.Errorf("timestamp can be after certificate %q validity period, it was expired at %q", cert.Subject, cert.NotAfter.Format(time.RFC1123Z))
+			return notation.ErrorCertificateExpired{
+				Msg:         fmt.Sprintf("timestamp can be after certificate %q validity period, it was expired at %q", cert.Subject, cert.NotAfter.Format(time.RFC1123Z)),
+				Subject:     cert.Subject.String(),
+				NotAfter:    cert.NotAfter,
+				Timestamped: true,
+			}
 		}
 		if timeOfVerification.After(cert.NotAfter) {
 			logger.Debugf("Certificate %q expired at %q, but timestamp is within certificate validity period", cert.Subject, cert.NotAfter.Format(time.RFC1123Z))
@@ -26,12 +26,15 @@ import (
 	"path/filepath"
 	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"golang.org/x/crypto/ocsp"
 
 	"github.com/notaryproject/notation-core-go/revocation"
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
 	"github.com/notaryproject/notation-core-go/revocation/purpose"
 	"github.com/notaryproject/notation-core-go/revocation/result"
 	revocationresult "github.com/notaryproject/notation-core-go/revocation/result"
@@ -47,6 +50,7 @@ import (
 	"github.com/notaryproject/notation-go/log"
 	"github.com/notaryproject/notation-go/plugin/proto"
 	"github.com/notaryproject/notation-go/signer"
+	"github.com/notaryproject/notation-go/verifier/crl"
 	"github.com/notaryproject/notation-go/verifier/trustpolicy"
 	"github.com/notaryproject/notation-go/verifier/truststore"
 	"github.com/opencontainers/go-digest"
@@ -308,6 +312,18 @@ func assertNotationVerification(t *testing.T, scheme signature.SigningScheme) {
 		})
 	}
 
+	// Expiry Success before the expiry time
+	for _, level := range verificationLevels {
+		policyDocument := dummyOCIPolicyDocument()
+		testCases = append(testCases, testCase{
+			signatureBlob:     expiredSigEnv,
+			verificationType:  trustpolicy.TypeExpiry,
+			verificationLevel: level,
+			policyDocument:    policyDocument,
+			opts:              notation.VerifierVerifyOptions{ArtifactReference: mock.SampleArtifactUri, SignatureMediaType: "application/jose+json", Clock: fixedClock(time.Date(2022, 7, 29, 23, 58, 0, 0, time.UTC))},
+		})
+	}
+
 	// Expiry Failure
 	for _, level := range verificationLevels {
 		policyDocument := dummyOCIPolicyDocument()
@@ -353,6 +369,12 @@ func assertNotationVerification(t *testing.T, scheme signature.SigningScheme) {
 	}
 }
 
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
 func TestVerifyRevocationEnvelope(t *testing.T) {
 	// Test values
 	desc := ocispec.Descriptor{
@@ -523,7 +545,7 @@ func TestVerifyRevocation(t *testing.T) {
 
 	t.Run("verifyRevocation nil client", func(t *testing.T) {
 		v := &verifier{}
-		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()))
+		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()), revocationOptions{})
 		expectedErrMsg := "unable to check revocation status, code signing revocation validator cannot be nil"
 		if result.Error == nil || result.Error.Error() != expectedErrMsg {
 			t.Fatalf("expected verifyRevocation to fail with %s, but got %v", expectedErrMsg, result.Error)
@@ -537,7 +559,7 @@ func TestVerifyRevocation(t *testing.T) {
 		v := &verifier{
 			revocationClient: revocationClient,
 		}
-		result := v.verifyRevocation(ctx, createMockOutcome(invalidChain, time.Now()))
+		result := v.verifyRevocation(ctx, createMockOutcome(invalidChain, time.Now()), revocationOptions{})
 		expectedErrMsg := "unable to check revocation status, err: invalid chain: expected chain to be correct and complete: invalid certificates or certificate with subject \"CN=Notation Test Revokable RSA Chain Cert 2,O=Notary,L=Seattle,ST=WA,C=US\" is not issued by \"CN=Notation Test Revokable RSA Chain Cert 3,O=Notary,L=Seattle,ST=WA,C=US\". Error: x509: invalid signature: parent certificate cannot sign this kind of certificate"
 		if result.Error == nil || result.Error.Error() != expectedErrMsg {
 			t.Fatalf("expected verifyRevocation to fail with %s, but got %v", expectedErrMsg, result.Error)
@@ -551,10 +573,39 @@ func TestVerifyRevocation(t *testing.T) {
 		v := &verifier{
 			revocationClient: revocationClient,
 		}
-		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()))
+		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()), revocationOptions{})
+		if result.Error != nil {
+			t.Fatalf("expected verifyRevocation to succeed, but got %v", result.Error)
+		}
+	})
+	t.Run("verifyRevocation collects evidence", func(t *testing.T) {
+		revocationClient, err := revocation.New(goodClient)
+		if err != nil {
+			t.Fatalf("unexpected error while creating revocation object: %v", err)
+		}
+		v := &verifier{
+			revocationClient: revocationClient,
+		}
+		outcome := createMockOutcome(revokableChain, time.Now())
+		if result := v.verifyRevocation(ctx, outcome, revocationOptions{}); result.Error != nil || outcome.RevocationEvidence != nil {
+			t.Fatalf("expected no revocation evidence to be collected, but got %v, %v", result.Error, outcome.RevocationEvidence)
+		}
+		result := v.verifyRevocation(ctx, outcome, revocationOptions{collectEvidence: true})
 		if result.Error != nil {
 			t.Fatalf("expected verifyRevocation to succeed, but got %v", result.Error)
 		}
+		if len(outcome.RevocationEvidence) != len(revokableChain) {
+			t.Fatalf("expected %d revocation evidences, but got %d", len(revokableChain), len(outcome.RevocationEvidence))
+		}
+		for i, evidence := range outcome.RevocationEvidence {
+			if !evidence.Certificate.Equal(revokableChain[i]) {
+				t.Fatalf("expected evidence %d for certificate %q, but got %q", i, revokableChain[i].Subject, evidence.Certificate.Subject)
+			}
+		}
+		leafEvidence := outcome.RevocationEvidence[0].Result
+		if leafEvidence.Result != revocationresult.ResultOK || len(leafEvidence.ServerResults) == 0 || leafEvidence.ServerResults[0].Server == "" {
+			t.Fatalf("expected the leaf certificate evidence to record the OCSP server result, but got %+v", leafEvidence)
+		}
 	})
 	t.Run("verifyRevocation OCSP revoked no invalidity", func(t *testing.T) {
 		revocationClient, err := revocation.New(revokedClient)
@@ -564,7 +615,7 @@ func TestVerifyRevocation(t *testing.T) {
 		v := &verifier{
 			revocationClient: revocationClient,
 		}
-		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()))
+		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()), revocationOptions{})
 		if result.Error == nil || result.Error.Error() != revokedMsg {
 			t.Fatalf("expected verifyRevocation to fail with %s, but got %v", revokedMsg, result.Error)
 		}
@@ -577,7 +628,7 @@ func TestVerifyRevocation(t *testing.T) {
 		v := &verifier{
 			revocationClient: revocationClient,
 		}
-		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()))
+		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()), revocationOptions{})
 		if result.Error == nil || result.Error.Error() != revokedMsg {
 			t.Fatalf("expected verifyRevocation to fail with %s, but got %v", revokedMsg, result.Error)
 		}
@@ -590,11 +641,54 @@ func TestVerifyRevocation(t *testing.T) {
 		v := &verifier{
 			revocationClient: revocationClient,
 		}
-		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()))
+		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()), revocationOptions{})
 		if result.Error == nil || result.Error.Error() != unknownMsg {
 			t.Fatalf("expected verifyRevocation to fail with %s, but got %v", unknownMsg, result.Error)
 		}
 	})
+	t.Run("verifyRevocation OCSP unknown with required check", func(t *testing.T) {
+		revocationClient, err := revocation.New(unknownClient)
+		if err != nil {
+			t.Fatalf("unexpected error while creating revocation object: %v", err)
+		}
+		v := &verifier{
+			revocationClient: revocationClient,
+		}
+		outcome := createMockOutcome(revokableChain, time.Now())
+		outcome.VerificationLevel.Enforcement[trustpolicy.TypeRevocation] = trustpolicy.ActionLog
+		result := v.verifyRevocation(ctx, outcome, revocationOptions{requireCheck: true})
+		if result.Action != trustpolicy.ActionEnforce {
+			t.Fatalf("expected revocation result to be enforced, but got %v", result.Action)
+		}
+		expectedPrefix := unknownMsg + ", and a definitive revocation check is required: OCSP check against"
+		if result.Error == nil || !strings.HasPrefix(result.Error.Error(), expectedPrefix) {
+			t.Fatalf("expected verifyRevocation to fail with %s, but got %v", expectedPrefix, result.Error)
+		}
+	})
+	t.Run("verifyRevocation nil client with required check", func(t *testing.T) {
+		v := &verifier{}
+		outcome := createMockOutcome(revokableChain, time.Now())
+		outcome.VerificationLevel.Enforcement[trustpolicy.TypeRevocation] = trustpolicy.ActionLog
+		result := v.verifyRevocation(ctx, outcome, revocationOptions{requireCheck: true})
+		if result.Error == nil || result.Action != trustpolicy.ActionEnforce {
+			t.Fatalf("expected enforced revocation failure, but got %v, %v", result.Action, result.Error)
+		}
+	})
+	t.Run("verifyRevocation revoked with required check", func(t *testing.T) {
+		revocationClient, err := revocation.New(revokedClient)
+		if err != nil {
+			t.Fatalf("unexpected error while creating revocation object: %v", err)
+		}
+		v := &verifier{
+			revocationClient: revocationClient,
+		}
+		outcome := createMockOutcome(revokableChain, time.Now())
+		outcome.VerificationLevel.Enforcement[trustpolicy.TypeRevocation] = trustpolicy.ActionLog
+		result := v.verifyRevocation(ctx, outcome, revocationOptions{requireCheck: true})
+		if result.Error == nil || result.Error.Error() != revokedMsg || result.Action != trustpolicy.ActionLog {
+			t.Fatalf("expected logged revocation failure %s, but got %v, %v", revokedMsg, result.Action, result.Error)
+		}
+	})
 	t.Run("verifyRevocation OCSP unknown then revoked", func(t *testing.T) {
 		revocationClient, err := revocation.New(unknownRevokedClient)
 		if err != nil {
@@ -603,7 +697,7 @@ func TestVerifyRevocation(t *testing.T) {
 		v := &verifier{
 			revocationClient: revocationClient,
 		}
-		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()))
+		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()), revocationOptions{})
 		if result.Error == nil || result.Error.Error() != multiMsg {
 			t.Fatalf("expected verifyRevocation to fail with %s, but got %v", multiMsg, result.Error)
 		}
@@ -616,7 +710,7 @@ func TestVerifyRevocation(t *testing.T) {
 		v := &verifier{
 			revocationClient: revocationClient,
 		}
-		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()))
+		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()), revocationOptions{})
 		if result.Error == nil || result.Error.Error() != revokedMsg {
 			t.Fatalf("expected verifyRevocation to fail with %s, but got %v", revokedMsg, result.Error)
 		}
@@ -629,7 +723,7 @@ func TestVerifyRevocation(t *testing.T) {
 		v := &verifier{
 			revocationClient: revocationClient,
 		}
-		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()))
+		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()), revocationOptions{})
 		if result.Error != nil {
 			t.Fatalf("expected verifyRevocation to succeed, but got %v", result.Error)
 		}
@@ -642,7 +736,7 @@ func TestVerifyRevocation(t *testing.T) {
 		v := &verifier{
 			revocationClient: revocationClient,
 		}
-		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()))
+		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now()), revocationOptions{})
 		if result.Error == nil || result.Error.Error() != unknownMsg {
 			t.Fatalf("expected verifyRevocation to fail with %s, but got %v", unknownMsg, result.Error)
 		}
@@ -655,7 +749,7 @@ func TestVerifyRevocation(t *testing.T) {
 		v := &verifier{
 			revocationClient: revocationClient,
 		}
-		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now().Add(-4*time.Hour)))
+		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now().Add(-4*time.Hour)), revocationOptions{})
 		if result.Error == nil || result.Error.Error() != revokedMsg {
 			t.Fatalf("expected verifyRevocation to fail with %s, but got %v", revokedMsg, result.Error)
 		}
@@ -669,7 +763,7 @@ func TestVerifyRevocation(t *testing.T) {
 		v := &verifier{
 			revocationClient: revocationClient,
 		}
-		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, zeroTime))
+		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, zeroTime), revocationOptions{})
 		if result.Error == nil || result.Error.Error() != expectedErrMsg {
 			t.Fatalf("expected verifyRevocation to fail with %s, but got %v", expectedErrMsg, result.Error)
 		}
@@ -685,7 +779,7 @@ func TestVerifyRevocation(t *testing.T) {
 		v := &verifier{
 			revocationClient: revocationClient,
 		}
-		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now().Add(-4*time.Hour)))
+		result := v.verifyRevocation(ctx, createMockOutcome(revokableChain, time.Now().Add(-4*time.Hour)), revocationOptions{})
 		if result.Error != nil {
 			t.Fatalf("expected verifyRevocation to succeed, but got %v", result.Error)
 		}
@@ -706,7 +800,7 @@ func TestVerifyRevocation(t *testing.T) {
 		v := &verifier{
 			revocationClient: revocationClient,
 		}
-		result := v.verifyRevocation(ctx, outcome)
+		result := v.verifyRevocation(ctx, outcome, revocationOptions{})
 		if result.Error == nil || result.Error.Error() != revokedMsg {
 			t.Fatalf("expected verifyRevocation to fail with %s, but got %v", revokedMsg, result.Error)
 		}
@@ -831,6 +925,432 @@ func TestNewVerifierWithOptionsError(t *testing.T) {
 	if err == nil || err.Error() != "trustStore cannot be nil" {
 		t.Errorf("expected err but not found.")
 	}
+
+	_, err = NewVerifierWithOptions(store, VerifierOptions{
+		OCITrustPolicy: &ociPolicy,
+		PluginManager:  pm,
+		MaxClockSkew:   -time.Minute,
+	})
+	if err == nil || err.Error() != "verifierOptions.MaxClockSkew expects a non-negative duration, got -1m0s" {
+		t.Errorf("expected MaxClockSkew err but got %v", err)
+	}
+}
+
+func TestVerifySigningTimeInFuture(t *testing.T) {
+	policyDocument := dummyOCIPolicyDocument()
+	dir.UserConfigDir = "testdata"
+	v := verifier{
+		ociTrustPolicyDoc: &policyDocument,
+		trustStore:        truststore.NewX509TrustStore(dir.ConfigFS()),
+		pluginManager:     mock.PluginManager{},
+	}
+	opts := notation.VerifierVerifyOptions{
+		ArtifactReference:  mock.SampleArtifactUri,
+		SignatureMediaType: "application/jose+json",
+		Clock:              fixedClock(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	outcome, err := v.Verify(context.Background(), ocispec.Descriptor{}, mock.MockCaValidSigEnv, opts)
+	var futureErr notation.ErrorSigningTimeInFuture
+	if !errors.As(err, &futureErr) {
+		t.Fatalf("expected ErrorSigningTimeInFuture, but got %v", err)
+	}
+	if outcome.TrustPolicyName != "test-statement-name" {
+		t.Fatalf("expected trust policy name %q of the failed outcome, but got %q", "test-statement-name", outcome.TrustPolicyName)
+	}
+	if futureErr.MaxClockSkew != defaultMaxClockSkew {
+		t.Fatalf("expected max clock skew %v, but got %v", defaultMaxClockSkew, futureErr.MaxClockSkew)
+	}
+	signingTime := outcome.EnvelopeContent.SignerInfo.SignedAttributes.SigningTime
+	if want := signingTime.Sub(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)); futureErr.Skew != want {
+		t.Fatalf("expected skew %v, but got %v", want, futureErr.Skew)
+	}
+}
+
+func TestPlannedChecks(t *testing.T) {
+	policyDocument := dummyOCIPolicyDocument()
+	policyDocument.TrustPolicies = append(policyDocument.TrustPolicies,
+		trustpolicy.OCITrustPolicy{
+			Name:           "permissive-statement",
+			RegistryScopes: []string{"registry.acme-rockets.io/software/permissive"},
+			SignatureVerification: trustpolicy.SignatureVerification{
+				VerificationLevel: "permissive",
+				Override:          map[trustpolicy.ValidationType]trustpolicy.ValidationAction{trustpolicy.TypeRevocation: trustpolicy.ActionSkip},
+			},
+			TrustStores:       []string{"ca:valid-trust-store"},
+			TrustedIdentities: []string{"*"},
+		},
+		trustpolicy.OCITrustPolicy{
+			Name:                  "skip-statement",
+			RegistryScopes:        []string{"registry.acme-rockets.io/software/skip"},
+			SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: "skip"},
+		},
+	)
+	v, err := NewVerifierWithOptions(&testTrustStore{}, VerifierOptions{
+		OCITrustPolicy: &policyDocument,
+		PluginManager:  pm,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error while creating verifier: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		artifactRef string
+		want        []PlannedCheck
+		wantErr     bool
+	}{
+		{
+			name:        "strict",
+			artifactRef: mock.SampleArtifactUri,
+			want: []PlannedCheck{
+				{Type: trustpolicy.TypeIntegrity, Action: trustpolicy.ActionEnforce},
+				{Type: trustpolicy.TypeAuthenticity, Action: trustpolicy.ActionEnforce},
+				{Type: trustpolicy.TypeAuthenticTimestamp, Action: trustpolicy.ActionEnforce},
+				{Type: trustpolicy.TypeExpiry, Action: trustpolicy.ActionEnforce},
+				{Type: trustpolicy.TypeRevocation, Action: trustpolicy.ActionEnforce},
+			},
+		},
+		{
+			name:        "permissive without revocation",
+			artifactRef: "registry.acme-rockets.io/software/permissive@sha256:60043cf45eaebc4c0867fea485a039b598f52fd09fd5b07b0b2d2f88fad9d74e",
+			want: []PlannedCheck{
+				{Type: trustpolicy.TypeIntegrity, Action: trustpolicy.ActionEnforce},
+				{Type: trustpolicy.TypeAuthenticity, Action: trustpolicy.ActionEnforce},
+				{Type: trustpolicy.TypeAuthenticTimestamp, Action: trustpolicy.ActionLog},
+				{Type: trustpolicy.TypeExpiry, Action: trustpolicy.ActionLog},
+			},
+		},
+		{
+			name:        "skip",
+			artifactRef: "registry.acme-rockets.io/software/skip@sha256:60043cf45eaebc4c0867fea485a039b598f52fd09fd5b07b0b2d2f88fad9d74e",
+		},
+		{
+			name:        "no applicable trust policy",
+			artifactRef: "registry.acme-rockets.io/software/unknown@sha256:60043cf45eaebc4c0867fea485a039b598f52fd09fd5b07b0b2d2f88fad9d74e",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.PlannedChecks(context.Background(), tt.artifactRef)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlannedChecks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("PlannedChecks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyExpiry(t *testing.T) {
+	expiry := time.Date(2024, 6, 18, 7, 30, 31, 0, time.UTC)
+	outcome := &notation.VerificationOutcome{
+		EnvelopeContent: &signature.EnvelopeContent{
+			SignerInfo: signature.SignerInfo{
+				SignedAttributes: signature.SignedAttributes{Expiry: expiry},
+			},
+		},
+		VerificationLevel: trustpolicy.LevelStrict,
+	}
+
+	if result := verifyExpiry(outcome, expiry.Add(-time.Second)); result.Error != nil {
+		t.Fatalf("expected nil error before expiry, but got %v", result.Error)
+	}
+	result := verifyExpiry(outcome, expiry)
+	var expiredErr notation.ErrorSignatureExpired
+	if !errors.As(result.Error, &expiredErr) || !expiredErr.Expiry.Equal(expiry) {
+		t.Fatalf("expected ErrorSignatureExpired with expiry %v, but got %v", expiry, result.Error)
+	}
+	if result.Type != trustpolicy.TypeExpiry || result.Action != trustpolicy.ActionEnforce {
+		t.Fatalf("expected enforced expiry validation result, but got %+v", result)
+	}
+}
+
+func TestVerifySigningTimeSkew(t *testing.T) {
+	now := time.Date(2024, 6, 18, 7, 30, 31, 0, time.UTC)
+	tests := []struct {
+		name         string
+		maxClockSkew time.Duration
+		signingTime  time.Time
+		wantSkew     time.Duration
+	}{
+		{
+			name:        "past signing time",
+			signingTime: now.Add(-time.Hour),
+		},
+		{
+			name:        "within default skew",
+			signingTime: now.Add(defaultMaxClockSkew),
+		},
+		{
+			name:        "beyond default skew",
+			signingTime: now.Add(defaultMaxClockSkew + time.Second),
+			wantSkew:    defaultMaxClockSkew + time.Second,
+		},
+		{
+			name:         "within configured skew",
+			maxClockSkew: time.Hour,
+			signingTime:  now.Add(time.Hour),
+		},
+		{
+			name:         "beyond configured skew",
+			maxClockSkew: time.Minute,
+			signingTime:  now.Add(2 * time.Minute),
+			wantSkew:     2 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &verifier{maxClockSkew: tt.maxClockSkew}
+			err := v.verifySigningTimeSkew(tt.signingTime, now)
+			if tt.wantSkew == 0 {
+				if err != nil {
+					t.Fatalf("expected nil error, but got %v", err)
+				}
+				return
+			}
+			var futureErr notation.ErrorSigningTimeInFuture
+			if !errors.As(err, &futureErr) || futureErr.Skew != tt.wantSkew {
+				t.Fatalf("expected ErrorSigningTimeInFuture with skew %v, but got %v", tt.wantSkew, err)
+			}
+		})
+	}
+}
+
+func TestNewVerifierWithOptionsCacheDir(t *testing.T) {
+	ociPolicyDoc := dummyOCIPolicyDocument()
+
+	t.Run("crl file cache", func(t *testing.T) {
+		cacheDir := filepath.Join(t.TempDir(), "tenant")
+		if _, err := NewVerifierWithOptions(&testTrustStore{}, VerifierOptions{
+			OCITrustPolicy: &ociPolicyDoc,
+			PluginManager:  pm,
+			CacheDir:       cacheDir,
+		}); err != nil {
+			t.Fatalf("expected NewVerifierWithOptions constructor to succeed, but got %v", err)
+		}
+		if info, err := os.Stat(filepath.Join(cacheDir, dir.PathCRLCache)); err != nil || !info.IsDir() {
+			t.Fatalf("expected crl file cache to be created in %s, but got %v", cacheDir, err)
+		}
+
+		fetcher, err := newCRLFetcher(cacheDir)
+		if err != nil {
+			t.Fatalf("expected newCRLFetcher to succeed, but got %v", err)
+		}
+		httpFetcher, ok := fetcher.(*corecrl.HTTPFetcher)
+		if !ok {
+			t.Fatalf("expected *crl.HTTPFetcher, but got %T", fetcher)
+		}
+		if _, ok := httpFetcher.Cache.(*crl.FileCache); !ok {
+			t.Fatalf("expected *crl.FileCache, but got %T", httpFetcher.Cache)
+		}
+	})
+
+	t.Run("no cache", func(t *testing.T) {
+		fetcher, err := newCRLFetcher("")
+		if err != nil || fetcher != nil {
+			t.Fatalf("expected nil fetcher, but got %v, %v", fetcher, err)
+		}
+	})
+
+	t.Run("invalid cache dir", func(t *testing.T) {
+		cacheDir := filepath.Join(t.TempDir(), "file")
+		if err := os.WriteFile(cacheDir, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		_, err := NewVerifierWithOptions(&testTrustStore{}, VerifierOptions{
+			OCITrustPolicy: &ociPolicyDoc,
+			PluginManager:  pm,
+			CacheDir:       cacheDir,
+		})
+		if err == nil || !strings.HasPrefix(err.Error(), "failed to create crl file cache") {
+			t.Fatalf("expected crl file cache error, but got %v", err)
+		}
+	})
+}
+
+func TestNewVerifierWithOptionsPreloadTrustStores(t *testing.T) {
+	trustStore := truststore.NewX509TrustStore(dir.NewSysFS(filepath.FromSlash("testdata")))
+
+	t.Run("valid trust stores", func(t *testing.T) {
+		ociPolicyDoc := dummyOCIPolicyDocument()
+		blobPolicyDoc := dummyBlobPolicyDocument()
+		_, err := NewVerifierWithOptions(trustStore, VerifierOptions{
+			OCITrustPolicy:     &ociPolicyDoc,
+			BlobTrustPolicy:    &blobPolicyDoc,
+			PluginManager:      pm,
+			PreloadTrustStores: true,
+		})
+		if err != nil {
+			t.Fatalf("expected NewVerifierWithOptions constructor to succeed, but got %v", err)
+		}
+	})
+
+	t.Run("missing trust stores", func(t *testing.T) {
+		ociPolicyDoc := dummyOCIPolicyDocument()
+		ociPolicyDoc.TrustPolicies[0].TrustStores = []string{"ca:valid-trust-store", "ca:missing-trust-store"}
+		blobPolicyDoc := dummyBlobPolicyDocument()
+		blobPolicyDoc.TrustPolicies[0].TrustStores = []string{"ca:missing-trust-store", "tsa:missing-tsa-store"}
+		opts := VerifierOptions{
+			OCITrustPolicy:  &ociPolicyDoc,
+			BlobTrustPolicy: &blobPolicyDoc,
+			PluginManager:   pm,
+		}
+		if _, err := NewVerifierWithOptions(trustStore, opts); err != nil {
+			t.Fatalf("expected NewVerifierWithOptions constructor to succeed without preloading, but got %v", err)
+		}
+
+		opts.PreloadTrustStores = true
+		_, err := NewVerifierWithOptions(trustStore, opts)
+		if err == nil {
+			t.Fatal("expected NewVerifierWithOptions constructor to fail, but got nil")
+		}
+		for _, want := range []string{`"ca:missing-trust-store"`, `"tsa:missing-tsa-store"`} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("expected error to contain %s, but got %v", want, err)
+			}
+		}
+		if strings.Count(err.Error(), `"ca:missing-trust-store"`) != 1 {
+			t.Errorf("expected trust store ca:missing-trust-store to be reported once, but got %v", err)
+		}
+		var trustStoreErr truststore.TrustStoreError
+		if !errors.As(err, &trustStoreErr) {
+			t.Errorf("expected error to wrap truststore.TrustStoreError, but got %T", err)
+		}
+	})
+}
+
+func TestNewVerifierWithOptionsTrustedCertificates(t *testing.T) {
+	certs, err := (&testTrustStore{}).GetCertificates(context.Background(), truststore.TypeCA, "dummy-ts")
+	if err != nil {
+		t.Fatalf("unexpected error while reading trusted certificates: %v", err)
+	}
+	policy := &trustpolicy.BlobDocument{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.BlobTrustPolicy{
+			{
+				Name:                  "blob-test-policy",
+				SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: "strict"},
+				TrustStores:           []string{"ca:in-memory-ts"},
+				TrustedIdentities:     []string{"*"},
+			},
+		},
+	}
+	opts := notation.BlobVerifierVerifyOptions{
+		SignatureMediaType: jws.MediaTypeEnvelope,
+		TrustPolicyName:    "blob-test-policy",
+	}
+
+	t.Run("verify with in-memory trust store", func(t *testing.T) {
+		v, err := NewVerifierWithOptions(nil, VerifierOptions{
+			BlobTrustPolicy:     policy,
+			PluginManager:       pm,
+			TrustedCertificates: map[string][]*x509.Certificate{"ca:in-memory-ts": certs},
+			PreloadTrustStores:  true,
+		})
+		if err != nil {
+			t.Fatalf("expected NewVerifierWithOptions constructor to succeed, but got %v", err)
+		}
+		if _, err := v.VerifyBlob(context.Background(), getTestDescGenFunc(false, ""), []byte(testSig), opts); err != nil {
+			t.Fatalf("VerifyBlob() returned unexpected error: %v", err)
+		}
+	})
+
+	t.Run("missing in-memory trust store", func(t *testing.T) {
+		v, err := NewVerifierWithOptions(nil, VerifierOptions{
+			BlobTrustPolicy:     policy,
+			PluginManager:       pm,
+			TrustedCertificates: map[string][]*x509.Certificate{"ca:other-ts": certs},
+		})
+		if err != nil {
+			t.Fatalf("expected NewVerifierWithOptions constructor to succeed, but got %v", err)
+		}
+		_, err = v.VerifyBlob(context.Background(), getTestDescGenFunc(false, ""), []byte(testSig), opts)
+		var trustStoreErr truststore.TrustStoreError
+		if !errors.As(err, &trustStoreErr) {
+			t.Fatalf("expected error to wrap truststore.TrustStoreError, but got %v", err)
+		}
+	})
+
+	t.Run("invalid trust store reference", func(t *testing.T) {
+		_, err := NewVerifierWithOptions(nil, VerifierOptions{
+			BlobTrustPolicy:     policy,
+			PluginManager:       pm,
+			TrustedCertificates: map[string][]*x509.Certificate{"in-memory-ts": certs},
+		})
+		expectedErrMsg := `trust store reference "in-memory-ts" must be of the format {type}:{name}`
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, but got %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("both trust store and trusted certificates", func(t *testing.T) {
+		_, err := NewVerifierWithOptions(&testTrustStore{}, VerifierOptions{
+			BlobTrustPolicy:     policy,
+			PluginManager:       pm,
+			TrustedCertificates: map[string][]*x509.Certificate{"ca:in-memory-ts": certs},
+		})
+		expectedErrMsg := "trustStore and verifierOptions.TrustedCertificates cannot both be set"
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, but got %v", expectedErrMsg, err)
+		}
+	})
+}
+
+func TestUpdateTrustStore(t *testing.T) {
+	certs, err := (&testTrustStore{}).GetCertificates(context.Background(), truststore.TypeCA, "dummy-ts")
+	if err != nil {
+		t.Fatalf("unexpected error while reading trusted certificates: %v", err)
+	}
+	otherCerts := []*x509.Certificate{testhelper.GetRSARootCertificate().Cert}
+	policy := &trustpolicy.BlobDocument{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.BlobTrustPolicy{
+			{
+				Name:                  "blob-test-policy",
+				SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: "strict"},
+				TrustStores:           []string{"ca:in-memory-ts"},
+				TrustedIdentities:     []string{"*"},
+			},
+		},
+	}
+	opts := notation.BlobVerifierVerifyOptions{
+		SignatureMediaType: jws.MediaTypeEnvelope,
+		TrustPolicyName:    "blob-test-policy",
+	}
+	v, err := NewVerifierWithOptions(nil, VerifierOptions{
+		BlobTrustPolicy:     policy,
+		PluginManager:       pm,
+		TrustedCertificates: map[string][]*x509.Certificate{"ca:in-memory-ts": otherCerts},
+	})
+	if err != nil {
+		t.Fatalf("expected NewVerifierWithOptions constructor to succeed, but got %v", err)
+	}
+	if _, err := v.VerifyBlob(context.Background(), getTestDescGenFunc(false, ""), []byte(testSig), opts); err == nil {
+		t.Fatal("expected VerifyBlob() to fail before the trust store is updated")
+	}
+
+	snapshot := v.trustStoreSnapshot()
+	if err := v.UpdateTrustStore(truststore.TypeCA, "in-memory-ts", append(otherCerts, certs...)); err != nil {
+		t.Fatalf("UpdateTrustStore() error = %v", err)
+	}
+	if _, err := v.VerifyBlob(context.Background(), getTestDescGenFunc(false, ""), []byte(testSig), opts); err != nil {
+		t.Fatalf("VerifyBlob() returned unexpected error: %v", err)
+	}
+	got, err := snapshot.GetCertificates(context.Background(), truststore.TypeCA, "in-memory-ts")
+	if err != nil || len(got) != 1 || !got[0].Equal(otherCerts[0]) {
+		t.Fatalf("expected the snapshot taken before the update to be unchanged, but got %v, %v", got, err)
+	}
+
+	// the certificates of the other trust stores are unchanged
+	if _, err := v.trustStoreSnapshot().GetCertificates(context.Background(), truststore.TypeCA, "other-ts"); err == nil {
+		t.Fatal("expected error for missing trust store")
+	}
+
+	if err := v.UpdateTrustStore("invalid", "in-memory-ts", certs); err == nil {
+		t.Fatal("expected UpdateTrustStore() to fail for invalid trust store type")
+	}
 }
 
 func TestNewOCIVerifierFromConfig(t *testing.T) {
@@ -901,9 +1421,13 @@ func TestVerifyBlob(t *testing.T) {
 
 	t.Run("without user defined metadata", func(t *testing.T) {
 		// verify with
-		if _, err = v.VerifyBlob(context.Background(), descGenFunc, []byte(testSig), opts); err != nil {
+		outcome, err := v.VerifyBlob(context.Background(), descGenFunc, []byte(testSig), opts)
+		if err != nil {
 			t.Fatalf("VerifyBlob() returned unexpected error: %v", err)
 		}
+		if outcome.TrustPolicyName != "blob-test-policy" {
+			t.Fatalf("expected trust policy name %q, got %q", "blob-test-policy", outcome.TrustPolicyName)
+		}
 	})
 
 	t.Run("with user defined metadata", func(t *testing.T) {
@@ -913,12 +1437,70 @@ func TestVerifyBlob(t *testing.T) {
 		}
 	})
 
+	t.Run("signature algorithm allowed", func(t *testing.T) {
+		policy.TrustPolicies[0].SignatureVerification.AllowedSignatureAlgorithms = trustpolicy.SignatureAlgorithms
+		defer func() { policy.TrustPolicies[0].SignatureVerification.AllowedSignatureAlgorithms = nil }()
+		if _, err = v.VerifyBlob(context.Background(), descGenFunc, []byte(testSig), opts); err != nil {
+			t.Fatalf("VerifyBlob() returned unexpected error: %v", err)
+		}
+	})
+
+	t.Run("signature algorithm not allowed", func(t *testing.T) {
+		sigEnv, err := signature.ParseEnvelope(jws.MediaTypeEnvelope, []byte(testSig))
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := sigEnv.Content()
+		if err != nil {
+			t.Fatal(err)
+		}
+		alg, err := proto.EncodeSigningAlgorithm(content.SignerInfo.SignatureAlgorithm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var allowed []string
+		for _, a := range trustpolicy.SignatureAlgorithms {
+			if a != string(alg) {
+				allowed = append(allowed, a)
+			}
+		}
+		policy.TrustPolicies[0].SignatureVerification.AllowedSignatureAlgorithms = allowed
+		defer func() { policy.TrustPolicies[0].SignatureVerification.AllowedSignatureAlgorithms = nil }()
+		_, err = v.VerifyBlob(context.Background(), descGenFunc, []byte(testSig), opts)
+		wantErr := fmt.Sprintf("signature algorithm %s is not allowed by trust policy statement \"blob-test-policy\", allowed signature algorithms are %q", alg, allowed)
+		if err == nil || err.Error() != wantErr {
+			t.Fatalf("VerifyBlob() expected error %q, got %v", wantErr, err)
+		}
+	})
+
+	t.Run("required extended key usage present", func(t *testing.T) {
+		policy.TrustPolicies[0].SignatureVerification.RequiredExtKeyUsages = []string{"1.3.6.1.5.5.7.3.3"}
+		defer func() { policy.TrustPolicies[0].SignatureVerification.RequiredExtKeyUsages = nil }()
+		if _, err = v.VerifyBlob(context.Background(), descGenFunc, []byte(testSig), opts); err != nil {
+			t.Fatalf("VerifyBlob() returned unexpected error: %v", err)
+		}
+	})
+
+	t.Run("required extended key usage missing", func(t *testing.T) {
+		policy.TrustPolicies[0].SignatureVerification.RequiredExtKeyUsages = []string{"1.3.6.1.5.5.7.3.3", "1.3.6.1.4.1.99999.1"}
+		defer func() { policy.TrustPolicies[0].SignatureVerification.RequiredExtKeyUsages = nil }()
+		_, err = v.VerifyBlob(context.Background(), descGenFunc, []byte(testSig), opts)
+		wantErr := "signing certificate with subject \"CN=Notation Example self-signed,O=Notary,L=Seattle,ST=WA,C=US\" is missing extended key usage 1.3.6.1.4.1.99999.1 required by trust policy statement \"blob-test-policy\""
+		if err == nil || err.Error() != wantErr {
+			t.Fatalf("VerifyBlob() expected error %q, got %v", wantErr, err)
+		}
+	})
+
 	t.Run("trust policy set to skip", func(t *testing.T) {
 		policy.TrustPolicies[0].SignatureVerification = trustpolicy.SignatureVerification{VerificationLevel: "skip"}
 		opts.UserMetadata = map[string]string{"buildId": "101"}
-		if _, err = v.VerifyBlob(context.Background(), descGenFunc, []byte(testSig), opts); err != nil {
+		outcome, err := v.VerifyBlob(context.Background(), descGenFunc, []byte(testSig), opts)
+		if err != nil {
 			t.Fatalf("VerifyBlob() with user metadata returned unexpected error: %v", err)
 		}
+		if outcome.SkipReason != notation.SkipReasonVerificationLevel {
+			t.Fatalf("expected skip reason %q, got %q", notation.SkipReasonVerificationLevel, outcome.SkipReason)
+		}
 	})
 }
 
@@ -975,7 +1557,7 @@ func TestVerifyBlob_Error(t *testing.T) {
 		descGenFunc := getTestDescGenFunc(false, "")
 		opts.UserMetadata = map[string]string{"buildId": "zzz"}
 		_, err = v.VerifyBlob(context.Background(), descGenFunc, []byte(testSig), opts)
-		if err == nil || err.Error() != "unable to find specified metadata in the signature" {
+		if err == nil || err.Error() != `value of metadata "buildId" in the signature does not match "zzz"` {
 			t.Fatalf("VerifyBlob() with user metadata returned unexpected error: %v", err)
 		}
 	})
@@ -1323,6 +1905,15 @@ func TestVerifyUserMetadata(t *testing.T) {
 		{map[string]string{"io.wabbit-networks.buildId": "321"}, true},
 		{map[string]string{"io.wabbit-networks.buildId": "123", "io.wabbit-networks.buildTime": "1672944615"}, false},
 		{map[string]string{"io.wabbit-networks.buildId": "123", "io.wabbit-networks.buildTime": "1"}, true},
+		{map[string]string{"io.wabbit-networks.buildId": "regex:1[0-9]+"}, false},
+		{map[string]string{"io.wabbit-networks.buildId": "regex:2[0-9]+"}, true},
+		{map[string]string{"io.wabbit-networks.buildId": "regex:12"}, true},
+		{map[string]string{"io.wabbit-networks.buildId": "regex:("}, true},
+		{map[string]string{"io.wabbit-networks.buildId": "glob:1*"}, false},
+		{map[string]string{"io.wabbit-networks.buildId": "glob:?2?"}, false},
+		{map[string]string{"io.wabbit-networks.buildId": "glob:2*"}, true},
+		{map[string]string{"io.wabbit-networks.buildId": "glob:["}, true},
+		{map[string]string{"io.wabbit-networks.buildId": "1*"}, true},
 	}
 
 	for i, tt := range tests {
@@ -1345,6 +1936,172 @@ func TestVerifyUserMetadata(t *testing.T) {
 	}
 }
 
+func TestVerifyTargetArtifactMismatch(t *testing.T) {
+	policyDocument := dummyOCIPolicyDocument()
+	policyDocument.TrustPolicies[0].SignatureVerification.VerificationLevel = trustpolicy.LevelAudit.Name
+	revocationClient, err := revocation.New(&http.Client{Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error while creating revocation object: %v", err)
+	}
+	verifier := verifier{
+		ociTrustPolicyDoc: &policyDocument,
+		trustStore:        truststore.NewX509TrustStore(dir.ConfigFS()),
+		pluginManager:     mock.PluginManager{},
+		revocationClient:  revocationClient,
+	}
+	desc := mock.MetadataSigEnvDescriptor
+	desc.Size++
+
+	outcome, err := verifier.Verify(context.Background(), desc, mock.MockSigEnvWithMetadata, notation.VerifierVerifyOptions{
+		ArtifactReference:  mock.SampleArtifactUri,
+		SignatureMediaType: "application/jose+json",
+	})
+	if !errors.As(err, &notation.ErrorTargetArtifactMismatch{}) {
+		t.Fatalf("expected ErrorTargetArtifactMismatch, got %v", err)
+	}
+	expectedErr := fmt.Sprintf("content descriptor mismatch: target artifact in the signature payload size %d does not match %d", desc.Size-1, desc.Size)
+	if err.Error() != expectedErr {
+		t.Fatalf("expected error %q, got %q", expectedErr, err)
+	}
+	if outcome.VerificationResults[0].Type != trustpolicy.TypeIntegrity || outcome.VerificationResults[0].Error != err {
+		t.Fatalf("expected integrity result to record the mismatch, got %+v", outcome.VerificationResults[0])
+	}
+}
+
+func TestVerifyWithTrustPolicyOverride(t *testing.T) {
+	defaultPolicy := dummyOCIPolicyDocument()
+	v, err := NewVerifierWithOptions(&testTrustStore{}, VerifierOptions{
+		OCITrustPolicy: &defaultPolicy,
+		PluginManager:  pm,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: mock.SampleDigest, Size: 100}
+
+	tenantA := dummyOCIPolicyDocument()
+	tenantA.TrustPolicies[0].Name = "tenant-a"
+	tenantA.TrustPolicies[0].RegistryScopes = []string{"registry.acme-rockets.io/software/net-monitor"}
+	tenantA.TrustPolicies[0].SignatureVerification = trustpolicy.SignatureVerification{VerificationLevel: trustpolicy.LevelSkip.Name}
+	tenantA.TrustPolicies[0].TrustStores = nil
+	tenantA.TrustPolicies[0].TrustedIdentities = nil
+
+	tenantB := dummyOCIPolicyDocument()
+	tenantB.TrustPolicies[0].Name = "tenant-b"
+	tenantB.TrustPolicies[0].RegistryScopes = []string{"registry.wabbit-networks.io/software/net-monitor"}
+
+	t.Run("tenant policy in scope", func(t *testing.T) {
+		opts := notation.VerifierVerifyOptions{
+			ArtifactReference:  mock.SampleArtifactUri,
+			SignatureMediaType: "application/jose+json",
+			OCITrustPolicy:     &tenantA,
+		}
+		outcome, err := v.Verify(context.Background(), desc, nil, opts)
+		if err != nil {
+			t.Fatalf("expected Verify to succeed, but got %v", err)
+		}
+		if outcome.SkipReason != notation.SkipReasonVerificationLevel {
+			t.Fatalf("expected verification to be skipped by the tenant policy, got %+v", outcome)
+		}
+		skip, _, err := v.SkipVerify(context.Background(), opts)
+		if err != nil || !skip {
+			t.Fatalf("expected SkipVerify to skip with the tenant policy, got %v, %v", skip, err)
+		}
+	})
+
+	t.Run("tenant policy out of scope", func(t *testing.T) {
+		opts := notation.VerifierVerifyOptions{
+			ArtifactReference:  mock.SampleArtifactUri,
+			SignatureMediaType: "application/jose+json",
+			OCITrustPolicy:     &tenantB,
+		}
+		_, err := v.Verify(context.Background(), desc, nil, opts)
+		if !errors.As(err, &notation.ErrorNoApplicableTrustPolicy{}) {
+			t.Fatalf("expected ErrorNoApplicableTrustPolicy, got %v", err)
+		}
+		if _, _, err := v.SkipVerify(context.Background(), opts); !errors.As(err, &notation.ErrorNoApplicableTrustPolicy{}) {
+			t.Fatalf("expected ErrorNoApplicableTrustPolicy, got %v", err)
+		}
+	})
+
+	t.Run("default policy", func(t *testing.T) {
+		skip, level, err := v.SkipVerify(context.Background(), notation.VerifierVerifyOptions{
+			ArtifactReference: mock.SampleArtifactUri,
+		})
+		if err != nil || skip || level != trustpolicy.LevelStrict {
+			t.Fatalf("expected the default strict policy to apply, got %v, %v, %v", skip, level, err)
+		}
+	})
+
+	t.Run("invalid tenant policy", func(t *testing.T) {
+		invalid := dummyOCIPolicyDocument()
+		invalid.Version = ""
+		_, err := v.Verify(context.Background(), desc, nil, notation.VerifierVerifyOptions{
+			ArtifactReference:  mock.SampleArtifactUri,
+			SignatureMediaType: "application/jose+json",
+			OCITrustPolicy:     &invalid,
+		})
+		expectedErr := "invalid trust policy in verify options: oci trust policy document has empty version, version must be specified"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("expected error %q, got %v", expectedErr, err)
+		}
+	})
+}
+
+func TestVerifyTargetArtifact(t *testing.T) {
+	desc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: mock.SampleDigest, Size: 100}
+	if err := verifyTargetArtifact(desc, desc); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	mismatched := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageIndex, Digest: mock.ZeroDigest, Size: 10}
+	expectedErr := fmt.Sprintf("content descriptor mismatch: target artifact in the signature payload digest %q does not match %q, size 10 does not match 100, media type %q does not match %q", mock.ZeroDigest, mock.SampleDigest, ocispec.MediaTypeImageIndex, ocispec.MediaTypeImageManifest)
+	if err := verifyTargetArtifact(mismatched, desc); err == nil || err.Error() != expectedErr {
+		t.Fatalf("expected error %q, got %v", expectedErr, err)
+	}
+
+	// artifacts addressed by sha512 digests are bound to the exact digest
+	content := []byte(`{"schemaVersion":2}`)
+	sha512Desc := ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: digest.SHA512.FromBytes(content), Size: int64(len(content))}
+	if err := verifyTargetArtifact(sha512Desc, sha512Desc); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	sha256Desc := sha512Desc
+	sha256Desc.Digest = digest.SHA256.FromBytes(content)
+	var mismatchErr notation.ErrorTargetArtifactMismatch
+	if err := verifyTargetArtifact(sha256Desc, sha512Desc); !errors.As(err, &mismatchErr) {
+		t.Fatalf("expected ErrorTargetArtifactMismatch, got %v", err)
+	}
+}
+
+func TestVerifyUserMetadataError(t *testing.T) {
+	payload := &envelope.Payload{
+		TargetArtifact: ocispec.Descriptor{
+			Annotations: map[string]string{"build.env": "prod-east"},
+		},
+	}
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		wantErr  string
+	}{
+		{"missing key", map[string]string{"build.id": "1"}, `unable to find specified metadata "build.id" in the signature`},
+		{"mismatch", map[string]string{"build.env": "regex:dev-.*"}, `value of metadata "build.env" in the signature does not match "regex:dev-.*"`},
+		{"invalid pattern", map[string]string{"build.env": "regex:("}, "invalid pattern \"regex:(\" for metadata \"build.env\": error parsing regexp: missing closing ): `^(?:()$`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyUserMetadata(log.Discard, payload, tt.metadata)
+			var metadataErr notation.ErrorUserMetadataVerificationFailed
+			if !errors.As(err, &metadataErr) {
+				t.Fatalf("expected ErrorUserMetadataVerificationFailed, got %v", err)
+			}
+			if err.Error() != tt.wantErr {
+				t.Fatalf("expected error %q, got %q", tt.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestPluginVersionCompatibility(t *testing.T) {
 
 	errTemplate := "found plugin io.cncf.notary.plugin.unittest.mock with version 1.0.0 but signature verification needs plugin version greater than or equal to "
@@ -1597,3 +2354,286 @@ func getTestDescGenFunc(returnErr bool, customDigest digest.Digest) notation.Blo
 		}, err
 	}
 }
+
+func TestVerifierConcurrentUse(t *testing.T) {
+	policy := &trustpolicy.BlobDocument{
+		Version: "1.0",
+		TrustPolicies: []trustpolicy.BlobTrustPolicy{
+			{
+				Name:                  "blob-test-policy",
+				SignatureVerification: trustpolicy.SignatureVerification{VerificationLevel: "strict"},
+				TrustStores:           []string{"ca:dummy-ts"},
+				TrustedIdentities:     []string{"*"},
+			},
+		},
+	}
+	v, err := NewVerifierWithOptions(&testTrustStore{}, VerifierOptions{
+		BlobTrustPolicy: policy,
+		PluginManager:   pm,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error while creating verifier: %v", err)
+	}
+	opts := notation.BlobVerifierVerifyOptions{
+		SignatureMediaType: jws.MediaTypeEnvelope,
+		TrustPolicyName:    "blob-test-policy",
+		UserMetadata:       map[string]string{"buildId": "101"},
+	}
+
+	const numGoroutines = 16
+	var wg sync.WaitGroup
+	errs := make(chan error, numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 4; j++ {
+				if _, err := v.VerifyBlob(context.Background(), getTestDescGenFunc(false, ""), []byte(testSig), opts); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("VerifyBlob() returned unexpected error: %v", err)
+	}
+}
+
+func TestVerifyCertificateChainValidator(t *testing.T) {
+	policyDocument := dummyOCIPolicyDocument()
+	policyDocument.TrustPolicies[0].SignatureVerification.Override = map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+		trustpolicy.TypeAuthenticity: trustpolicy.ActionLog,
+		trustpolicy.TypeRevocation:   trustpolicy.ActionSkip,
+	}
+	dir.UserConfigDir = "testdata"
+	v := verifier{
+		ociTrustPolicyDoc: &policyDocument,
+		trustStore:        truststore.NewX509TrustStore(dir.ConfigFS()),
+		pluginManager:     mock.PluginManager{},
+	}
+	opts := notation.VerifierVerifyOptions{
+		ArtifactReference:  mock.SampleArtifactUri,
+		SignatureMediaType: "application/jose+json",
+	}
+
+	t.Run("accepted chain", func(t *testing.T) {
+		var chain []*x509.Certificate
+		opts.CertificateChainValidator = func(c []*x509.Certificate) error {
+			chain = c
+			return nil
+		}
+		if _, err := v.Verify(context.Background(), mock.ImageDescriptor, mock.MockCaValidSigEnv, opts); err != nil {
+			t.Fatalf("Verify() returned unexpected error: %v", err)
+		}
+		if len(chain) == 0 {
+			t.Fatal("expected the certificate chain validator to be invoked with the signing certificate chain")
+		}
+	})
+
+	t.Run("rejected chain", func(t *testing.T) {
+		opts.CertificateChainValidator = func(c []*x509.Certificate) error {
+			return errors.New("missing required certificate policy")
+		}
+		outcome, err := v.Verify(context.Background(), mock.ImageDescriptor, mock.MockCaValidSigEnv, opts)
+		expectedErr := notation.ErrorVerificationFailed{Msg: "missing required certificate policy"}
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("expected error %v, but got %v", expectedErr, err)
+		}
+		verifyResult(outcome, notation.ValidationResult{
+			Type:   trustpolicy.TypeAuthenticity,
+			Action: trustpolicy.ActionEnforce,
+			Error:  expectedErr,
+		}, expectedErr, t)
+	})
+}
+
+func TestVerifyRequireRevocationCheck(t *testing.T) {
+	dir.UserConfigDir = "testdata"
+	x509TrustStore := truststore.NewX509TrustStore(dir.ConfigFS())
+
+	t.Run("revocation skipped by trust policy", func(t *testing.T) {
+		policyDocument := dummyOCIPolicyDocument()
+		policyDocument.TrustPolicies[0].SignatureVerification.Override = map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+			trustpolicy.TypeAuthenticity: trustpolicy.ActionLog,
+			trustpolicy.TypeRevocation:   trustpolicy.ActionSkip,
+		}
+		v := verifier{
+			ociTrustPolicyDoc: &policyDocument,
+			trustStore:        x509TrustStore,
+			pluginManager:     mock.PluginManager{},
+		}
+		opts := notation.VerifierVerifyOptions{
+			ArtifactReference:  mock.SampleArtifactUri,
+			SignatureMediaType: "application/jose+json",
+		}
+		if _, err := v.Verify(context.Background(), mock.ImageDescriptor, mock.MockCaValidSigEnv, opts); err != nil {
+			t.Fatalf("expected verification to succeed without RequireRevocationCheck, but got %v", err)
+		}
+
+		opts.RequireRevocationCheck = true
+		outcome, err := v.Verify(context.Background(), mock.ImageDescriptor, mock.MockCaValidSigEnv, opts)
+		expectedErr := fmt.Errorf("revocation check is required, but trust policy %q skips the revocation validation", policyDocument.TrustPolicies[0].Name)
+		if err == nil || err.Error() != expectedErr.Error() {
+			t.Fatalf("expected error %v, but got %v", expectedErr, err)
+		}
+		verifyResult(outcome, notation.ValidationResult{
+			Type:   trustpolicy.TypeRevocation,
+			Action: trustpolicy.ActionEnforce,
+			Error:  expectedErr,
+		}, expectedErr, t)
+	})
+
+	t.Run("revocation checked by plugin", func(t *testing.T) {
+		policyDocument := dummyOCIPolicyDocument()
+		policyDocument.TrustPolicies[0].SignatureVerification.Override = map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+			trustpolicy.TypeRevocation: trustpolicy.ActionLog,
+		}
+		pluginManager := mock.PluginManager{}
+		pluginManager.PluginCapabilities = []proto.Capability{proto.CapabilityRevocationCheckVerifier}
+		pluginManager.PluginRunnerExecuteResponse = &proto.VerifySignatureResponse{
+			VerificationResults: map[proto.Capability]*proto.VerificationResult{
+				proto.CapabilityRevocationCheckVerifier: {
+					Success: false,
+					Reason:  "revocation status unknown",
+				},
+			},
+			ProcessedAttributes: []interface{}{mock.PluginExtendedCriticalAttribute.Key},
+		}
+		v := verifier{
+			ociTrustPolicyDoc: &policyDocument,
+			trustStore:        x509TrustStore,
+			pluginManager:     pluginManager,
+		}
+		opts := notation.VerifierVerifyOptions{
+			ArtifactReference:  mock.SampleArtifactUri,
+			SignatureMediaType: "application/jose+json",
+		}
+		if _, err := v.Verify(context.Background(), mock.ImageDescriptor, mock.MockCaPluginSigEnv, opts); err != nil {
+			t.Fatalf("expected verification to succeed without RequireRevocationCheck, but got %v", err)
+		}
+
+		opts.RequireRevocationCheck = true
+		outcome, err := v.Verify(context.Background(), mock.ImageDescriptor, mock.MockCaPluginSigEnv, opts)
+		expectedErr := errors.New(`revocation check by verification plugin "plugin-name" failed with reason "revocation status unknown"`)
+		if err == nil || err.Error() != expectedErr.Error() {
+			t.Fatalf("expected error %v, but got %v", expectedErr, err)
+		}
+		verifyResult(outcome, notation.ValidationResult{
+			Type:   trustpolicy.TypeRevocation,
+			Action: trustpolicy.ActionEnforce,
+			Error:  expectedErr,
+		}, expectedErr, t)
+	})
+}
+
+func TestVerifyWithTrustStoreDir(t *testing.T) {
+	policyDocument := dummyOCIPolicyDocument()
+	policyDocument.TrustPolicies[0].SignatureVerification.Override = map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+		trustpolicy.TypeRevocation: trustpolicy.ActionSkip,
+	}
+	opts := notation.VerifySignatureOptions{
+		VerifierVerifyOptions: notation.VerifierVerifyOptions{
+			ArtifactReference:  mock.SampleArtifactUri,
+			SignatureMediaType: "application/jose+json",
+		},
+	}
+	outcome, err := VerifyWithTrustStoreDir(context.Background(), mock.ImageDescriptor, mock.MockCaValidSigEnv, "testdata", &policyDocument, opts)
+	if err != nil {
+		t.Fatalf("VerifyWithTrustStoreDir() returned unexpected error: %v", err)
+	}
+	if outcome.TrustPolicyName != "test-statement-name" {
+		t.Fatalf("expected trust policy name %q, but got %q", "test-statement-name", outcome.TrustPolicyName)
+	}
+
+	t.Run("missing trust store", func(t *testing.T) {
+		if _, err := VerifyWithTrustStoreDir(context.Background(), mock.ImageDescriptor, mock.MockCaValidSigEnv, t.TempDir(), &policyDocument, opts); err == nil {
+			t.Fatal("expected VerifyWithTrustStoreDir() to fail without the trust store")
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		if _, err := VerifyWithTrustStoreDir(context.Background(), mock.ImageDescriptor, mock.MockCaValidSigEnv, "", &policyDocument, opts); err == nil || err.Error() != "trustStoreDir cannot be empty" {
+			t.Fatalf("expected error for empty trustStoreDir, but got %v", err)
+		}
+		if _, err := VerifyWithTrustStoreDir(context.Background(), mock.ImageDescriptor, mock.MockCaValidSigEnv, "testdata", nil, opts); err == nil || err.Error() != "policy cannot be nil" {
+			t.Fatalf("expected error for nil policy, but got %v", err)
+		}
+	})
+}
+
+// futurePayloadPacker packs a notary payload of a newer schema version with
+// an additional field.
+type futurePayloadPacker struct{}
+
+func (futurePayloadPacker) PackPayload(_ context.Context, desc ocispec.Descriptor) (signature.Payload, error) {
+	content, err := json.Marshal(map[string]any{
+		"targetArtifact": desc,
+		"futureField":    "value",
+	})
+	if err != nil {
+		return signature.Payload{}, err
+	}
+	return signature.Payload{
+		ContentType: "application/vnd.cncf.notary.payload.v2+json",
+		Content:     content,
+	}, nil
+}
+
+func TestVerifyNewerPayloadVersion(t *testing.T) {
+	chain := testhelper.GetRevokableRSAChain(2)
+	internalSigner, err := signer.New(chain[0].PrivateKey, []*x509.Certificate{chain[0].Cert, chain[1].Cert})
+	if err != nil {
+		t.Fatalf("Unexpected error while creating signer: %v", err)
+	}
+	desc := mock.ImageDescriptor
+	desc.Annotations = map[string]string{"key": "value"}
+	envelopeBlob, _, err := internalSigner.Sign(context.Background(), desc, notation.SignerSignOptions{
+		SignatureMediaType: "application/jose+json",
+		PayloadPacker:      futurePayloadPacker{},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error while generating blob: %v", err)
+	}
+
+	policyDocument := dummyOCIPolicyDocument()
+	policyDocument.TrustPolicies[0].TrustStores = []string{"ca:test-ts"}
+	policyDocument.TrustPolicies[0].TrustedIdentities = []string{"*"}
+	policyDocument.TrustPolicies[0].SignatureVerification.Override = map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+		trustpolicy.TypeRevocation: trustpolicy.ActionSkip,
+	}
+	v, err := NewVerifierWithOptions(nil, VerifierOptions{
+		OCITrustPolicy:      &policyDocument,
+		PluginManager:       mock.PluginManager{},
+		TrustedCertificates: map[string][]*x509.Certificate{"ca:test-ts": {chain[1].Cert}},
+	})
+	if err != nil {
+		t.Fatalf("NewVerifierWithOptions() error = %v", err)
+	}
+	opts := notation.VerifierVerifyOptions{
+		ArtifactReference:  mock.SampleArtifactUri,
+		SignatureMediaType: "application/jose+json",
+		UserMetadata:       map[string]string{"key": "value"},
+	}
+	outcome, err := v.Verify(context.Background(), mock.ImageDescriptor, envelopeBlob, opts)
+	if err != nil {
+		t.Fatalf("Verify() returned unexpected error: %v", err)
+	}
+	if outcome.PayloadVersion != 2 {
+		t.Fatalf("expected payload version 2, but got %d", outcome.PayloadVersion)
+	}
+	metadata, err := outcome.UserMetadata()
+	if err != nil {
+		t.Fatalf("UserMetadata() error = %v", err)
+	}
+	if !reflect.DeepEqual(metadata, desc.Annotations) {
+		t.Fatalf("expected user metadata %v, but got %v", desc.Annotations, metadata)
+	}
+
+	// the known fields are still validated
+	if _, err := v.Verify(context.Background(), ocispec.Descriptor{Digest: digest.FromString("other")}, envelopeBlob, opts); err == nil {
+		t.Fatal("expected Verify() to fail for a different artifact")
+	}
+}